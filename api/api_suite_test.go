@@ -16,9 +16,14 @@ import (
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
 	buildfakes "github.com/concourse/atc/api/buildserver/fakes"
+	"github.com/concourse/atc/api/jobserver"
+	jobserverfakes "github.com/concourse/atc/api/jobserver/fakes"
 	pipeserverfakes "github.com/concourse/atc/api/pipes/fakes"
+	"github.com/concourse/atc/api/resourceserver"
+	resourceserverfakes "github.com/concourse/atc/api/resourceserver/fakes"
 	workerserverfakes "github.com/concourse/atc/api/workerserver/fakes"
 	authfakes "github.com/concourse/atc/auth/fakes"
+	"github.com/concourse/atc/db"
 	dbfakes "github.com/concourse/atc/db/fakes"
 	enginefakes "github.com/concourse/atc/engine/fakes"
 	workerfakes "github.com/concourse/atc/worker/fakes"
@@ -27,19 +32,26 @@ import (
 var (
 	sink *lager.ReconfigurableSink
 
-	authValidator       *authfakes.FakeValidator
-	fakeEngine          *enginefakes.FakeEngine
-	fakeWorkerClient    *workerfakes.FakeClient
-	buildsDB            *buildfakes.FakeBuildsDB
-	configDB            *dbfakes.FakeConfigDB
-	workerDB            *workerserverfakes.FakeWorkerDB
-	pipeDB              *pipeserverfakes.FakePipeDB
-	pipelineDBFactory   *dbfakes.FakePipelineDBFactory
-	pipelinesDB         *dbfakes.FakePipelinesDB
-	configValidationErr error
-	peerAddr            string
-	drain               chan struct{}
-	cliDownloadsDir     string
+	authValidator            *authfakes.FakeValidator
+	fakeEngine               *enginefakes.FakeEngine
+	fakeWorkerClient         *workerfakes.FakeClient
+	buildsDB                 *buildfakes.FakeBuildsDB
+	configDB                 *dbfakes.FakeConfigDB
+	workerDB                 *workerserverfakes.FakeWorkerDB
+	pipeDB                   *pipeserverfakes.FakePipeDB
+	pipelineDBFactory        *dbfakes.FakePipelineDBFactory
+	pipelinesDB              *dbfakes.FakePipelinesDB
+	fakeScanner              *resourceserverfakes.FakeScanner
+	scannerPipelineDB        db.PipelineDB
+	fakeScheduler            *jobserverfakes.FakeScheduler
+	schedulerPipelineDB      db.PipelineDB
+	fakeBuildScheduler       *buildfakes.FakeScheduler
+	buildSchedulerPipelineDB db.PipelineDB
+	configValidationErr      error
+	peerAddr                 string
+	drain                    chan struct{}
+	cliDownloadsDir          string
+	allowIgnoringPassed      bool
 
 	constructedEventHandler *fakeEventHandlerFactory
 
@@ -76,11 +88,18 @@ var _ = BeforeEach(func() {
 	workerDB = new(workerserverfakes.FakeWorkerDB)
 	pipeDB = new(pipeserverfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
+	fakeScanner = new(resourceserverfakes.FakeScanner)
+	scannerPipelineDB = nil
+	fakeScheduler = new(jobserverfakes.FakeScheduler)
+	schedulerPipelineDB = nil
+	fakeBuildScheduler = new(buildfakes.FakeScheduler)
+	buildSchedulerPipelineDB = nil
 
 	authValidator = new(authfakes.FakeValidator)
 	configValidationErr = nil
 	peerAddr = "127.0.0.1:1234"
 	drain = make(chan struct{})
+	allowIgnoringPassed = true
 
 	fakeEngine = new(enginefakes.FakeEngine)
 	fakeWorkerClient = new(workerfakes.FakeClient)
@@ -92,6 +111,16 @@ var _ = BeforeEach(func() {
 
 	constructedEventHandler = &fakeEventHandlerFactory{}
 
+	startServer()
+
+	client = &http.Client{
+		Transport: &http.Transport{},
+	}
+})
+
+// startServer serves the API with the suite's fakes and settings, so that it
+// can be started again once they have been changed.
+func startServer() {
 	logger := lagertest.NewTestLogger("callbacks")
 
 	sink = lager.NewReconfigurableSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
@@ -112,6 +141,19 @@ var _ = BeforeEach(func() {
 		func(atc.Config) error { return configValidationErr },
 		peerAddr,
 		constructedEventHandler.Construct,
+		func(pipelineDB db.PipelineDB) resourceserver.Scanner {
+			scannerPipelineDB = pipelineDB
+			return fakeScanner
+		},
+		func(pipelineDB db.PipelineDB) jobserver.Scheduler {
+			schedulerPipelineDB = pipelineDB
+			return fakeScheduler
+		},
+		func(pipelineDB db.PipelineDB) buildserver.Scheduler {
+			buildSchedulerPipelineDB = pipelineDB
+			return fakeBuildScheduler
+		},
+		allowIgnoringPassed,
 		drain,
 
 		fakeEngine,
@@ -124,11 +166,7 @@ var _ = BeforeEach(func() {
 	Ω(err).ShouldNot(HaveOccurred())
 
 	server = httptest.NewServer(handler)
-
-	client = &http.Client{
-		Transport: &http.Transport{},
-	}
-})
+}
 
 var _ = AfterEach(func() {
 	server.Close()
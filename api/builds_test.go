@@ -15,6 +15,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	dbfakes "github.com/concourse/atc/db/fakes"
 	enginefakes "github.com/concourse/atc/engine/fakes"
 )
 
@@ -490,4 +491,185 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/builds/:build_id/rerun", func() {
+		var (
+			pipelineDB *dbfakes.FakePipelineDB
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			pipelineDB = new(dbfakes.FakePipelineDB)
+			pipelineDBFactory.BuildWithNameReturns(pipelineDB, nil)
+
+			buildsDB.GetBuildReturns(db.Build{
+				ID:               128,
+				Name:             "1",
+				JobName:          "some-job",
+				PipelineName:     "some-pipeline",
+				Status:           db.StatusFailed,
+				InputsDetermined: true,
+			}, nil)
+
+			pipelineDB.GetConfigReturns(atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource"},
+				},
+			}, 1, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/128/rerun", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the rerun is created", func() {
+				BeforeEach(func() {
+					fakeBuildScheduler.RerunBuildReturns(db.Build{
+						ID:           129,
+						Name:         "2",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusPending,
+						RerunOf:      128,
+					}, nil)
+				})
+
+				It("reruns the build with the job's config", func() {
+					Ω(pipelineDBFactory.BuildWithNameArgsForCall(0)).Should(Equal("some-pipeline"))
+					Ω(buildSchedulerPipelineDB).Should(Equal(pipelineDB))
+
+					Ω(fakeBuildScheduler.RerunBuildCallCount()).Should(Equal(1))
+					_, buildID, job, resources := fakeBuildScheduler.RerunBuildArgsForCall(0)
+					Ω(buildID).Should(Equal(128))
+					Ω(job).Should(Equal(atc.JobConfig{Name: "some-job"}))
+					Ω(resources).Should(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
+				})
+
+				It("returns 201 with the rerun", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusCreated))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(body).Should(MatchJSON(`{
+						"id": 129,
+						"name": "2",
+						"status": "pending",
+						"job_name": "some-job",
+						"url": "/pipelines/some-pipeline/jobs/some-job/builds/2",
+						"rerun_of": 128
+					}`))
+				})
+			})
+
+			Context("when rerunning fails", func() {
+				BeforeEach(func() {
+					fakeBuildScheduler.RerunBuildReturns(db.Build{}, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when rerunning finds the build's inputs were not determined", func() {
+				BeforeEach(func() {
+					fakeBuildScheduler.RerunBuildReturns(db.Build{}, db.ErrInputsNotDetermined)
+				})
+
+				It("returns 400", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the build's inputs were never determined", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{
+						ID:           128,
+						Name:         "1",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusErrored,
+					}, nil)
+				})
+
+				It("returns 400 without rerunning anything", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+					Ω(fakeBuildScheduler.RerunBuildCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the build is a one-off build", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{ID: 128, Name: "1"}, nil)
+				})
+
+				It("returns 400 without rerunning anything", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+					Ω(fakeBuildScheduler.RerunBuildCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the build's job is no longer configured", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+					Ω(fakeBuildScheduler.RerunBuildCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the build cannot be found", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{}, db.ErrNoBuild)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the build fails", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildReturns(db.Build{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 without rerunning anything", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+					Ω(fakeBuildScheduler.RerunBuildCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not rerun the build", func() {
+				Ω(fakeBuildScheduler.RerunBuildCallCount()).Should(BeZero())
+			})
+		})
+	})
 })
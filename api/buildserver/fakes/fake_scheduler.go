@@ -0,0 +1,64 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeScheduler struct {
+	RerunBuildStub        func(lager.Logger, int, atc.JobConfig, atc.ResourceConfigs) (db.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.JobConfig
+		arg4 atc.ResourceConfigs
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+}
+
+func (fake *FakeScheduler) RerunBuild(arg1 lager.Logger, arg2 int, arg3 atc.JobConfig, arg4 atc.ResourceConfigs) (db.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.JobConfig
+		arg4 atc.ResourceConfigs
+	}{arg1, arg2, arg3, arg4})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.rerunBuildReturns.result1, fake.rerunBuildReturns.result2
+	}
+}
+
+func (fake *FakeScheduler) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeScheduler) RerunBuildArgsForCall(i int) (lager.Logger, int, atc.JobConfig, atc.ResourceConfigs) {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return fake.rerunBuildArgsForCall[i].arg1, fake.rerunBuildArgsForCall[i].arg2, fake.rerunBuildArgsForCall[i].arg3, fake.rerunBuildArgsForCall[i].arg4
+}
+
+func (fake *FakeScheduler) RerunBuildReturns(result1 db.Build, result2 error) {
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+var _ buildserver.Scheduler = new(FakeScheduler)
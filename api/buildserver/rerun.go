@@ -0,0 +1,76 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) RerunBuild(w http.ResponseWriter, r *http.Request) {
+	buildID, err := strconv.Atoi(r.FormValue(":build_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rLog := s.logger.Session("rerun", lager.Data{
+		"build": buildID,
+	})
+
+	build, err := s.db.GetBuild(buildID)
+	if err == db.ErrNoBuild {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		rLog.Error("failed-to-get-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if build.OneOff() || !build.InputsDetermined {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipelineDB, err := s.pipelineDBFactory.BuildWithName(build.PipelineName)
+	if err != nil {
+		rLog.Error("failed-to-get-pipeline-db", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	config, _, err := pipelineDB.GetConfig()
+	if err != nil {
+		rLog.Error("failed-to-get-config", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	job, found := config.Jobs.Lookup(build.JobName)
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	rerun, err := s.schedulerFactory(pipelineDB).RerunBuild(rLog, build.ID, job, config.Resources)
+	if err == db.ErrInputsNotDetermined {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		rLog.Error("failed-to-rerun-build", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	json.NewEncoder(w).Encode(present.Build(rerun))
+}
@@ -14,6 +14,14 @@ import (
 
 type EventHandlerFactory func(BuildsDB, int) http.Handler
 
+//go:generate counterfeiter . Scheduler
+
+type Scheduler interface {
+	RerunBuild(lager.Logger, int, atc.JobConfig, atc.ResourceConfigs) (db.Build, error)
+}
+
+type SchedulerFactory func(db.PipelineDB) Scheduler
+
 type Server struct {
 	logger lager.Logger
 
@@ -21,7 +29,9 @@ type Server struct {
 	workerClient        worker.Client
 	db                  BuildsDB
 	configDB            db.ConfigDB
+	pipelineDBFactory   db.PipelineDBFactory
 	eventHandlerFactory EventHandlerFactory
+	schedulerFactory    SchedulerFactory
 	drain               <-chan struct{}
 	fallback            auth.Validator
 
@@ -45,7 +55,9 @@ func NewServer(
 	workerClient worker.Client,
 	db BuildsDB,
 	configDB db.ConfigDB,
+	pipelineDBFactory db.PipelineDBFactory,
 	eventHandlerFactory EventHandlerFactory,
+	schedulerFactory SchedulerFactory,
 	drain <-chan struct{},
 	fallback auth.Validator,
 ) *Server {
@@ -55,7 +67,9 @@ func NewServer(
 		workerClient:        workerClient,
 		db:                  db,
 		configDB:            configDB,
+		pipelineDBFactory:   pipelineDBFactory,
 		eventHandlerFactory: eventHandlerFactory,
+		schedulerFactory:    schedulerFactory,
 		drain:               drain,
 		fallback:            fallback,
 
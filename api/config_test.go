@@ -373,6 +373,47 @@ jobs:
 							})
 						})
 
+						Context("when the payload contains get steps with versions", func() {
+							BeforeEach(func() {
+								payload := `
+resources:
+- name: some-resource
+  type: git
+- name: some-other-resource
+  type: git
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    version: every
+  - get: some-other-resource
+    version: {ref: abcdef, branch: master}`
+
+								request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+							})
+
+							It("returns 200", func() {
+								Ω(response.StatusCode).Should(Equal(http.StatusOK))
+							})
+
+							It("saves the versions", func() {
+								Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
+
+								_, config, _, _ := configDB.SaveConfigArgsForCall(0)
+								Ω(config.Jobs[0].Plan).Should(Equal(atc.PlanSequence{
+									{
+										Get:     "some-resource",
+										Version: atc.VersionEvery,
+									},
+									{
+										Get:     "some-other-resource",
+										Version: atc.VersionConfig(`{"branch":"master","ref":"abcdef"}`),
+									},
+								}))
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								configDB.SaveConfigReturns(true, nil)
@@ -621,6 +662,132 @@ jobs:
 						Ω(configDB.SaveConfigCallCount()).Should(BeZero())
 					})
 				})
+
+				Context("when the config contains template placeholders", func() {
+					setRequestWithVars := func(vars map[string]interface{}) {
+						templatedConfig := config
+						templatedConfig.Resources = atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-type",
+								Source: atc.Source{
+									"source-config": "{{source-value}}",
+									"nested":        "{{nested-source}}",
+								},
+							},
+						}
+
+						body := &bytes.Buffer{}
+						writer := multipart.NewWriter(body)
+
+						yamlWriter, err := writer.CreatePart(
+							textproto.MIMEHeader{
+								"Content-type": {"application/x-yaml"},
+							},
+						)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						yml, err := yaml.Marshal(templatedConfig)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						_, err = yamlWriter.Write(yml)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						if vars == nil {
+							writer.Close()
+
+							request.Header.Set("Content-Type", writer.FormDataContentType())
+							request.Body = gbytes.BufferWithBytes(body.Bytes())
+							return
+						}
+
+						varsWriter, err := writer.CreatePart(
+							textproto.MIMEHeader{
+								"Content-Disposition": {`form-data; name="vars"`},
+								"Content-type":        {"application/x-yaml"},
+							},
+						)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						varsYAML, err := yaml.Marshal(vars)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						_, err = varsWriter.Write(varsYAML)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						writer.Close()
+
+						request.Header.Set("Content-Type", writer.FormDataContentType())
+						request.Body = gbytes.BufferWithBytes(body.Bytes())
+					}
+
+					Context("when all of the vars are provided", func() {
+						BeforeEach(func() {
+							setRequestWithVars(map[string]interface{}{
+								"source-value": "some-value",
+								"nested-source": map[string]interface{}{
+									"key": "value",
+									"nested": map[string]interface{}{
+										"key": "value",
+									},
+								},
+							})
+						})
+
+						It("returns 200", func() {
+							Ω(response.StatusCode).Should(Equal(http.StatusOK))
+						})
+
+						It("saves the config with the vars substituted", func() {
+							Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
+
+							_, savedConfig, _, _ := configDB.SaveConfigArgsForCall(0)
+							Ω(savedConfig).Should(Equal(config))
+						})
+					})
+
+					Context("when some vars are missing", func() {
+						BeforeEach(func() {
+							setRequestWithVars(map[string]interface{}{
+								"source-value": "some-value",
+							})
+						})
+
+						It("returns 400", func() {
+							Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+						})
+
+						It("lists each missing var and its location", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Ω(err).ShouldNot(HaveOccurred())
+
+							Ω(body).Should(ContainSubstring("unresolved template variables:"))
+							Ω(body).Should(ContainSubstring("- nested-source (at resources[0].source.nested)"))
+						})
+
+						It("does not save it", func() {
+							Ω(configDB.SaveConfigCallCount()).Should(BeZero())
+						})
+					})
+
+					Context("when no vars are given", func() {
+						BeforeEach(func() {
+							setRequestWithVars(nil)
+						})
+
+						It("saves the config with the placeholders left alone", func() {
+							Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+							Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
+
+							_, savedConfig, _, _ := configDB.SaveConfigArgsForCall(0)
+							Ω(savedConfig.Resources[0].Source).Should(Equal(atc.Source{
+								"source-config": "{{source-value}}",
+								"nested":        "{{nested-source}}",
+							}))
+						})
+					})
+				})
 			})
 
 			Context("when a config version is not specified", func() {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/template"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
@@ -27,6 +28,7 @@ var (
 	ErrFailedToConstructDecoder   = errors.New("decoder could not be constructed")
 	ErrCouldNotDecode             = errors.New("data could not be decoded into config structure")
 	ErrInvalidPausedValue         = errors.New("invalid paused value")
+	ErrMalformedVars              = errors.New("vars could not be decoded")
 )
 
 type ExtraKeysError struct {
@@ -89,12 +91,22 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		fmt.Fprintf(w, "invalid paused value")
 		return
+	case ErrMalformedVars:
+		session.Error("malformed-vars", err)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "vars could not be decoded")
+		return
 	default:
 		if err != nil {
-			if eke, ok := err.(ExtraKeysError); ok {
+			switch e := err.(type) {
+			case ExtraKeysError:
 				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprintln(w, eke)
-			} else {
+				fmt.Fprintln(w, e)
+			case template.UnresolvedVarsError:
+				session.Info("unresolved-vars", lager.Data{"vars": e.Vars})
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, e)
+			default:
 				session.Error("unexpected-error", err)
 				w.WriteHeader(http.StatusInternalServerError)
 			}
@@ -133,14 +145,18 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func requestToConfig(contentType string, requestBody io.ReadCloser) (interface{}, db.PipelinePausedState, error) {
+func requestToConfig(contentType string, requestBody io.ReadCloser) (interface{}, db.PipelinePausedState, template.Variables, error) {
 	var err error
 	var configStructure interface{}
 	pausedState := db.PipelineNoChange
 
+	// left nil unless a vars document is given, so that configs which were
+	// never templated are saved as-is
+	var vars template.Variables
+
 	mediaType, params, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return atc.Config{}, db.PipelineNoChange, ErrCannotParseContentType
+		return atc.Config{}, db.PipelineNoChange, nil, ErrCannotParseContentType
 	}
 
 	switch mediaType {
@@ -163,13 +179,14 @@ func requestToConfig(contentType string, requestBody io.ReadCloser) (interface{}
 			}
 
 			if err != nil {
-				return atc.Config{}, db.PipelineNoChange, err
+				return atc.Config{}, db.PipelineNoChange, nil, err
 			}
 
-			if part.FormName() == "paused" {
+			switch part.FormName() {
+			case "paused":
 				pausedValue, err := ioutil.ReadAll(part)
 				if err != nil {
-					return atc.Config{}, db.PipelineNoChange, err
+					return atc.Config{}, db.PipelineNoChange, nil, err
 				}
 
 				if string(pausedValue) == "true" {
@@ -177,26 +194,64 @@ func requestToConfig(contentType string, requestBody io.ReadCloser) (interface{}
 				} else if string(pausedValue) == "false" {
 					pausedState = db.PipelineUnpaused
 				} else {
-					return atc.Config{}, db.PipelineNoChange, ErrInvalidPausedValue
+					return atc.Config{}, db.PipelineNoChange, nil, ErrInvalidPausedValue
+				}
+
+			case "vars":
+				partContentType := part.Header.Get("Content-type")
+				varsStructure, _, _, err := requestToConfig(partContentType, part)
+				if err != nil {
+					return atc.Config{}, db.PipelineNoChange, nil, ErrMalformedVars
+				}
+
+				vars, err = requestToVars(varsStructure)
+				if err != nil {
+					return atc.Config{}, db.PipelineNoChange, nil, err
 				}
-			} else {
+
+			default:
 				partContentType := part.Header.Get("Content-type")
-				configStructure, _, err = requestToConfig(partContentType, part)
+				configStructure, _, _, err = requestToConfig(partContentType, part)
 			}
 		}
 	default:
-		return atc.Config{}, db.PipelineNoChange, ErrStatusUnsupportedMediaType
+		return atc.Config{}, db.PipelineNoChange, nil, ErrStatusUnsupportedMediaType
+	}
+
+	return configStructure, pausedState, vars, nil
+}
+
+func requestToVars(varsStructure interface{}) (template.Variables, error) {
+	if varsStructure == nil {
+		return template.Variables{}, nil
+	}
+
+	sanitized, err := sanitize(varsStructure)
+	if err != nil {
+		return nil, ErrMalformedVars
+	}
+
+	varsMap, ok := sanitized.(map[string]interface{})
+	if !ok {
+		return nil, ErrMalformedVars
 	}
 
-	return configStructure, pausedState, nil
+	return template.Variables(varsMap), nil
 }
 
 func saveConfigRequestUnmarshler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
-	configStructure, pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body)
+	configStructure, pausedState, vars, err := requestToConfig(r.Header.Get("Content-Type"), r.Body)
 	if err != nil {
 		return atc.Config{}, db.PipelineNoChange, err
 	}
 
+	if vars != nil {
+		configStructure, err = template.Evaluate(configStructure, vars)
+		if err != nil {
+			return atc.Config{}, db.PipelineNoChange, err
+		}
+	}
+
 	var config atc.Config
 	var md mapstructure.Metadata
 	msConfig := &mapstructure.DecoderConfig{
@@ -219,8 +274,13 @@ func saveConfigRequestUnmarshler(r *http.Request) (atc.Config, db.PipelinePaused
 					return data, nil
 				}
 
+				sanitized, err := sanitize(data)
+				if err != nil {
+					return nil, err
+				}
+
 				// format it as JSON/YAML would
-				return json.Marshal(data)
+				return json.Marshal(sanitized)
 			}
 
 			return data, nil
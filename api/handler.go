@@ -40,6 +40,10 @@ func NewHandler(
 	configValidator configserver.ConfigValidator,
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
+	scannerFactory resourceserver.ScannerFactory,
+	schedulerFactory jobserver.SchedulerFactory,
+	buildSchedulerFactory buildserver.SchedulerFactory,
+	allowIgnoringPassed bool,
 	drain <-chan struct{},
 
 	engine engine.Engine,
@@ -62,7 +66,9 @@ func NewHandler(
 		workerClient,
 		buildsDB,
 		configDB,
+		pipelineDBFactory,
 		eventHandlerFactory,
+		buildSchedulerFactory,
 		drain,
 		validator,
 	)
@@ -72,8 +78,8 @@ func NewHandler(
 		workerClient,
 	)
 
-	jobServer := jobserver.NewServer(logger)
-	resourceServer := resourceserver.NewServer(logger, validator)
+	jobServer := jobserver.NewServer(logger, workerClient, schedulerFactory, allowIgnoringPassed)
+	resourceServer := resourceserver.NewServer(logger, validator, scannerFactory)
 	pipeServer := pipes.NewServer(logger, peerURL, pipeDB)
 
 	pipelineServer := pipelineserver.NewServer(logger, pipelinesDB)
@@ -104,13 +110,19 @@ func NewHandler(
 		atc.CreateBuild: validate(http.HandlerFunc(buildServer.CreateBuild)),
 		atc.BuildEvents: http.HandlerFunc(buildServer.BuildEvents),
 		atc.AbortBuild:  validate(http.HandlerFunc(buildServer.AbortBuild)),
+		atc.RerunBuild:  validate(http.HandlerFunc(buildServer.RerunBuild)),
 
-		atc.ListJobs:      pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:        pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds: pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.GetJobBuild:   pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.PauseJob:      validate(pipelineHandlerFactory.HandlerFor(jobServer.PauseJob)),
-		atc.UnpauseJob:    validate(pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob)),
+		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.CreateJobBuild: validate(pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild)),
+		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.PauseJob:       validate(pipelineHandlerFactory.HandlerFor(jobServer.PauseJob)),
+		atc.UnpauseJob:     validate(pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob)),
+		atc.ClearJobCaches: validate(pipelineHandlerFactory.HandlerFor(jobServer.ClearCaches)),
+
+		atc.GetJobSchedulingStatus: pipelineHandlerFactory.HandlerFor(jobServer.GetSchedulingStatus),
+		atc.PreviewJobInputs:       pipelineHandlerFactory.HandlerFor(jobServer.PreviewInputs),
 
 		atc.ListPipelines:   http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.DeletePipeline:  validate(pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline)),
@@ -121,8 +133,14 @@ func NewHandler(
 		atc.ListResources:          pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
 		atc.EnableResourceVersion:  validate(pipelineHandlerFactory.HandlerFor(resourceServer.EnableResourceVersion)),
 		atc.DisableResourceVersion: validate(pipelineHandlerFactory.HandlerFor(resourceServer.DisableResourceVersion)),
+		atc.PinResourceVersion:     validate(pipelineHandlerFactory.HandlerFor(resourceServer.PinResourceVersion)),
+		atc.UnpinResource:          validate(pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource)),
 		atc.PauseResource:          validate(pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource)),
 		atc.UnpauseResource:        validate(pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource)),
+		atc.CheckResource:          validate(pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource)),
+
+		// authenticated by the resource's webhook token instead
+		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 
 		atc.CreatePipe: validate(http.HandlerFunc(pipeServer.CreatePipe)),
 		atc.WritePipe:  validate(http.HandlerFunc(pipeServer.WritePipe)),
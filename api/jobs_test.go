@@ -1,10 +1,12 @@
 package api_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	dbfakes "github.com/concourse/atc/db/fakes"
+	"github.com/concourse/atc/scheduler"
 )
 
 var _ = Describe("Jobs API", func() {
@@ -581,6 +584,181 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/pipelines/:pipeline_name/jobs/:job_name/builds", func() {
+		var requestBody string
+		var response *http.Response
+
+		BeforeEach(func() {
+			requestBody = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/pipelines/some-pipeline/jobs/some-job/builds", bytes.NewBufferString(requestBody))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when the job exists", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Jobs: []atc.JobConfig{
+							{Name: "some-job"},
+						},
+						Resources: []atc.ResourceConfig{
+							{Name: "some-resource"},
+						},
+					}, 1, nil)
+				})
+
+				Context("when triggering the build succeeds", func() {
+					BeforeEach(func() {
+						fakeScheduler.TriggerWithVersionsReturns(db.Build{
+							ID:           1,
+							Name:         "1",
+							JobName:      "some-job",
+							PipelineName: "some-pipeline",
+							Status:       db.StatusPending,
+						}, nil)
+					})
+
+					It("triggers the build using the pipeline's scheduler", func() {
+						Ω(schedulerPipelineDB).Should(Equal(pipelineDB))
+
+						Ω(fakeScheduler.TriggerWithVersionsCallCount()).Should(Equal(1))
+						_, job, resources, inputs, ignorePassed := fakeScheduler.TriggerWithVersionsArgsForCall(0)
+						Ω(job).Should(Equal(atc.JobConfig{Name: "some-job"}))
+						Ω(resources).Should(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
+						Ω(inputs).Should(BeEmpty())
+						Ω(ignorePassed).Should(BeFalse())
+					})
+
+					It("returns 201 Created with the build", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusCreated))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						Ω(body).Should(MatchJSON(`{
+							"id": 1,
+							"name": "1",
+							"job_name": "some-job",
+							"status": "pending",
+							"url": "/pipelines/some-pipeline/jobs/some-job/builds/1"
+						}`))
+					})
+
+					Context("when versions are chosen for the inputs", func() {
+						BeforeEach(func() {
+							requestBody = `{
+								"inputs": [
+									{"name": "some-input", "version_id": 42},
+									{"name": "some-other-input", "version": {"ref": "abcdef"}}
+								],
+								"ignore_passed": true
+							}`
+						})
+
+						It("triggers the build with them", func() {
+							_, _, _, inputs, ignorePassed := fakeScheduler.TriggerWithVersionsArgsForCall(0)
+							Ω(inputs).Should(Equal([]atc.JobBuildInput{
+								{Name: "some-input", VersionID: 42},
+								{Name: "some-other-input", Version: atc.Version{"ref": "abcdef"}},
+							}))
+							Ω(ignorePassed).Should(BeTrue())
+						})
+
+						Context("when ignoring passed constraints is not allowed", func() {
+							BeforeEach(func() {
+								allowIgnoringPassed = false
+
+								server.Close()
+								startServer()
+							})
+
+							It("returns 403 Forbidden without triggering a build", func() {
+								Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+
+								body, err := ioutil.ReadAll(response.Body)
+								Ω(err).ShouldNot(HaveOccurred())
+								Ω(string(body)).Should(Equal("ignoring passed constraints is not allowed"))
+
+								Ω(fakeScheduler.TriggerWithVersionsCallCount()).Should(BeZero())
+							})
+						})
+					})
+				})
+
+				Context("when the chosen versions are invalid", func() {
+					BeforeEach(func() {
+						fakeScheduler.TriggerWithVersionsReturns(db.Build{}, scheduler.InputVersionsError{Reason: "bad versions"})
+					})
+
+					It("returns 400 Bad Request with the reason", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(string(body)).Should(Equal("invalid input versions: bad versions"))
+					})
+				})
+
+				Context("when triggering the build fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.TriggerWithVersionsReturns(db.Build{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the request body is malformed", func() {
+					BeforeEach(func() {
+						requestBody = "{"
+					})
+
+					It("returns 400 Bad Request without triggering a build", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+						Ω(fakeScheduler.TriggerWithVersionsCallCount()).Should(BeZero())
+					})
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not trigger a build", func() {
+				Ω(fakeScheduler.TriggerWithVersionsCallCount()).Should(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:pipeline_name/jobs/:job_name/pause", func() {
 		var response *http.Response
 
@@ -700,4 +878,379 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("DELETE /api/v1/pipelines/:pipeline_name/jobs/:job_name/caches", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/pipelines/some-pipeline/jobs/job-name/caches", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				pipelineDB.GetPipelineNameReturns("some-pipeline")
+			})
+
+			It("injects the PipelineDB", func() {
+				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
+				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineName).Should(Equal("some-pipeline"))
+			})
+
+			Context("when clearing the caches succeeds", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.ClearCachesReturns(nil)
+				})
+
+				It("clears the right job's caches", func() {
+					Ω(fakeWorkerClient.ClearCachesCallCount()).Should(Equal(1))
+
+					pipelineName, jobName := fakeWorkerClient.ClearCachesArgsForCall(0)
+					Ω(pipelineName).Should(Equal("some-pipeline"))
+					Ω(jobName).Should(Equal("job-name"))
+				})
+
+				It("returns 204", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNoContent))
+				})
+			})
+
+			Context("when clearing the caches fails", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.ClearCachesReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not clear any caches", func() {
+				Ω(fakeWorkerClient.ClearCachesCallCount()).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/pipelines/:pipeline_name/jobs/:job_name/scheduling-status", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			pipelineDB.GetConfigReturns(atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}, 1, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/jobs/some-job/scheduling-status")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(pipelineDBFactory.BuildWithNameArgsForCall(0)).Should(Equal("some-pipeline"))
+		})
+
+		Context("when a status has been recorded", func() {
+			BeforeEach(func() {
+				pipelineDB.GetJobSchedulingStatusReturns(db.SchedulingStatus{
+					Reason: db.SchedulingReasonNoVersions,
+					Time:   time.Unix(1, 0),
+				}, nil)
+
+				fakeScheduler.ExplainSchedulingStatusReturns(db.SchedulingStatus{
+					Reason:    db.SchedulingReasonPassedUnsatisfied,
+					Input:     "some-input",
+					Resource:  "some-resource",
+					PassedJob: "some-upstream-job",
+					Time:      time.Unix(1, 0),
+				}, nil)
+			})
+
+			It("returns 200 OK with the status, explained by the scheduler", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+				Ω(pipelineDB.GetJobSchedulingStatusArgsForCall(0)).Should(Equal("some-job"))
+
+				Ω(fakeScheduler.ExplainSchedulingStatusCallCount()).Should(Equal(1))
+				job, _, status := fakeScheduler.ExplainSchedulingStatusArgsForCall(0)
+				Ω(job.Name).Should(Equal("some-job"))
+				Ω(status).Should(Equal(db.SchedulingStatus{
+					Reason: db.SchedulingReasonNoVersions,
+					Time:   time.Unix(1, 0),
+				}))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(body).Should(MatchJSON(`{
+					"reason": "passed-unsatisfied",
+					"description": "job 'some-upstream-job' has not succeeded with a version of resource 'some-resource' for input 'some-input'",
+					"input": "some-input",
+					"resource": "some-resource",
+					"passed_job": "some-upstream-job",
+					"time": 1
+				}`))
+			})
+
+			Context("when explaining the status fails", func() {
+				BeforeEach(func() {
+					fakeScheduler.ExplainSchedulingStatusReturns(db.SchedulingStatus{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when no status has been recorded", func() {
+			BeforeEach(func() {
+				pipelineDB.GetJobSchedulingStatusReturns(db.SchedulingStatus{}, db.ErrNoSchedulingStatus)
+			})
+
+			It("returns 404 Not Found", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the status fails", func() {
+			BeforeEach(func() {
+				pipelineDB.GetJobSchedulingStatusReturns(db.SchedulingStatus{}, errors.New("oh no!"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the job is not configured", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+			})
+
+			It("returns 404 Not Found", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				Ω(pipelineDB.GetJobSchedulingStatusCallCount()).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
+		var response *http.Response
+
+		buildInputs := []db.BuildInput{
+			{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  db.Version{"some": "version"},
+					Metadata: []db.MetadataField{
+						{Name: "some", Value: "metadata"},
+					},
+				},
+			},
+		}
+
+		BeforeEach(func() {
+			pipelineDB.GetConfigReturns(atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						Plan: atc.PlanSequence{
+							{
+								Get:      "some-input",
+								Resource: "some-resource",
+								Passed:   []string{"some-upstream-job"},
+							},
+						},
+					},
+				},
+			}, 1, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/jobs/some-job/inputs")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(pipelineDBFactory.BuildWithNameArgsForCall(0)).Should(Equal("some-pipeline"))
+		})
+
+		Context("when versions satisfy the inputs", func() {
+			BeforeEach(func() {
+				pipelineDB.GetLatestInputVersionsReturns(buildInputs, nil)
+				pipelineDB.GetJobBuildForInputsReturns(db.Build{}, db.ErrNoBuild)
+			})
+
+			It("determines them for the job's inputs", func() {
+				Ω(pipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
+				jobName, inputs := pipelineDB.GetLatestInputVersionsArgsForCall(0)
+				Ω(jobName).Should(Equal("some-job"))
+				Ω(inputs).Should(Equal([]atc.JobInput{
+					{
+						Name:     "some-input",
+						Resource: "some-resource",
+						Passed:   []string{"some-upstream-job"},
+					},
+				}))
+
+				Ω(pipelineDB.GetJobBuildForInputsCallCount()).Should(Equal(1))
+				jobName, checkedInputs := pipelineDB.GetJobBuildForInputsArgsForCall(0)
+				Ω(jobName).Should(Equal("some-job"))
+				Ω(checkedInputs).Should(Equal(buildInputs))
+			})
+
+			It("does not create a build", func() {
+				Ω(pipelineDB.CreateJobBuildCallCount()).Should(BeZero())
+				Ω(pipelineDB.CreateJobBuildForCandidateInputsCallCount()).Should(BeZero())
+			})
+
+			It("returns 200 OK with the versions", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(body).Should(MatchJSON(`{
+					"inputs": [
+						{
+							"name": "some-input",
+							"resource": "some-resource",
+							"type": "some-type",
+							"version": {"some": "version"},
+							"metadata": [{"name": "some", "value": "metadata"}]
+						}
+					],
+					"existing_build": null
+				}`))
+			})
+
+			Context("when a build has already used the versions", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildForInputsReturns(db.Build{
+						ID:           42,
+						Name:         "3",
+						JobName:      "some-job",
+						PipelineName: "some-pipeline",
+						Status:       db.StatusSucceeded,
+					}, nil)
+				})
+
+				It("includes the build", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+					var preview atc.JobInputsPreview
+					err := json.NewDecoder(response.Body).Decode(&preview)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(preview.ExistingBuild).Should(Equal(&atc.Build{
+						ID:      42,
+						Name:    "3",
+						Status:  "succeeded",
+						JobName: "some-job",
+						URL:     "/pipelines/some-pipeline/jobs/some-job/builds/3",
+					}))
+				})
+			})
+
+			Context("when looking up an existing build fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildForInputsReturns(db.Build{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when no versions satisfy the inputs", func() {
+			BeforeEach(func() {
+				pipelineDB.GetLatestInputVersionsReturns(nil, db.ErrNoVersions)
+
+				fakeScheduler.ExplainSchedulingStatusReturns(db.SchedulingStatus{
+					Reason:   db.SchedulingReasonNoVersions,
+					Input:    "some-input",
+					Resource: "some-resource",
+					Time:     time.Unix(1, 0),
+				}, nil)
+			})
+
+			It("returns 200 OK with no inputs, explaining why", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				Ω(pipelineDB.GetJobBuildForInputsCallCount()).Should(BeZero())
+
+				Ω(fakeScheduler.ExplainSchedulingStatusCallCount()).Should(Equal(1))
+				job, _, status := fakeScheduler.ExplainSchedulingStatusArgsForCall(0)
+				Ω(job.Name).Should(Equal("some-job"))
+				Ω(status.Reason).Should(Equal(db.SchedulingReasonNoVersions))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(body).Should(MatchJSON(`{
+					"inputs": [],
+					"existing_build": null,
+					"unsatisfied": {
+						"reason": "no-versions",
+						"description": "resource 'some-resource' has no versions for input 'some-input'",
+						"input": "some-input",
+						"resource": "some-resource",
+						"time": 1
+					}
+				}`))
+			})
+
+			Context("when explaining why fails", func() {
+				BeforeEach(func() {
+					fakeScheduler.ExplainSchedulingStatusReturns(db.SchedulingStatus{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when determining the versions fails", func() {
+			BeforeEach(func() {
+				pipelineDB.GetLatestInputVersionsReturns(nil, errors.New("oh no!"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+			})
+		})
+
+		Context("when the job is not configured", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+			})
+
+			It("returns 404 Not Found", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				Ω(pipelineDB.GetLatestInputVersionsCallCount()).Should(BeZero())
+			})
+		})
+	})
 })
@@ -0,0 +1,29 @@
+package jobserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ClearCaches(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := rata.Param(r, "job_name")
+
+		logger := s.logger.Session("clear-caches", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"job":      jobName,
+		})
+
+		err := s.workerClient.ClearCaches(pipelineDB.GetPipelineName(), jobName)
+		if err != nil {
+			logger.Error("failed", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,75 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/scheduler"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CreateJobBuild(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := rata.Param(r, "job_name")
+
+		logger := s.logger.Session("create-job-build", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"job":      jobName,
+		})
+
+		var request atc.JobBuildRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if request.IgnorePassed && !s.allowIgnoringPassed {
+			logger.Info("ignoring-passed-not-allowed")
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, "ignoring passed constraints is not allowed")
+			return
+		}
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		job, found := config.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		build, err := s.schedulerFactory(pipelineDB).TriggerWithVersions(
+			logger,
+			job,
+			config.Resources,
+			request.Inputs,
+			request.IgnorePassed,
+		)
+		if err != nil {
+			if _, ok := err.(scheduler.InputVersionsError); ok {
+				w.WriteHeader(http.StatusBadRequest)
+				io.WriteString(w, err.Error())
+				return
+			}
+
+			logger.Error("failed-to-trigger", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		json.NewEncoder(w).Encode(present.Build(build))
+	})
+}
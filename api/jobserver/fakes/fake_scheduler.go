@@ -0,0 +1,112 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/jobserver"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeScheduler struct {
+	TriggerWithVersionsStub        func(lager.Logger, atc.JobConfig, atc.ResourceConfigs, []atc.JobBuildInput, bool) (db.Build, error)
+	triggerWithVersionsMutex       sync.RWMutex
+	triggerWithVersionsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.JobConfig
+		arg3 atc.ResourceConfigs
+		arg4 []atc.JobBuildInput
+		arg5 bool
+	}
+	triggerWithVersionsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	ExplainSchedulingStatusStub        func(atc.JobConfig, atc.ResourceConfigs, db.SchedulingStatus) (db.SchedulingStatus, error)
+	explainSchedulingStatusMutex       sync.RWMutex
+	explainSchedulingStatusArgsForCall []struct {
+		arg1 atc.JobConfig
+		arg2 atc.ResourceConfigs
+		arg3 db.SchedulingStatus
+	}
+	explainSchedulingStatusReturns struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}
+}
+
+func (fake *FakeScheduler) TriggerWithVersions(arg1 lager.Logger, arg2 atc.JobConfig, arg3 atc.ResourceConfigs, arg4 []atc.JobBuildInput, arg5 bool) (db.Build, error) {
+	fake.triggerWithVersionsMutex.Lock()
+	fake.triggerWithVersionsArgsForCall = append(fake.triggerWithVersionsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.JobConfig
+		arg3 atc.ResourceConfigs
+		arg4 []atc.JobBuildInput
+		arg5 bool
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.triggerWithVersionsMutex.Unlock()
+	if fake.TriggerWithVersionsStub != nil {
+		return fake.TriggerWithVersionsStub(arg1, arg2, arg3, arg4, arg5)
+	} else {
+		return fake.triggerWithVersionsReturns.result1, fake.triggerWithVersionsReturns.result2
+	}
+}
+
+func (fake *FakeScheduler) TriggerWithVersionsCallCount() int {
+	fake.triggerWithVersionsMutex.RLock()
+	defer fake.triggerWithVersionsMutex.RUnlock()
+	return len(fake.triggerWithVersionsArgsForCall)
+}
+
+func (fake *FakeScheduler) TriggerWithVersionsArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, []atc.JobBuildInput, bool) {
+	fake.triggerWithVersionsMutex.RLock()
+	defer fake.triggerWithVersionsMutex.RUnlock()
+	return fake.triggerWithVersionsArgsForCall[i].arg1, fake.triggerWithVersionsArgsForCall[i].arg2, fake.triggerWithVersionsArgsForCall[i].arg3, fake.triggerWithVersionsArgsForCall[i].arg4, fake.triggerWithVersionsArgsForCall[i].arg5
+}
+
+func (fake *FakeScheduler) TriggerWithVersionsReturns(result1 db.Build, result2 error) {
+	fake.TriggerWithVersionsStub = nil
+	fake.triggerWithVersionsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatus(arg1 atc.JobConfig, arg2 atc.ResourceConfigs, arg3 db.SchedulingStatus) (db.SchedulingStatus, error) {
+	fake.explainSchedulingStatusMutex.Lock()
+	fake.explainSchedulingStatusArgsForCall = append(fake.explainSchedulingStatusArgsForCall, struct {
+		arg1 atc.JobConfig
+		arg2 atc.ResourceConfigs
+		arg3 db.SchedulingStatus
+	}{arg1, arg2, arg3})
+	fake.explainSchedulingStatusMutex.Unlock()
+	if fake.ExplainSchedulingStatusStub != nil {
+		return fake.ExplainSchedulingStatusStub(arg1, arg2, arg3)
+	} else {
+		return fake.explainSchedulingStatusReturns.result1, fake.explainSchedulingStatusReturns.result2
+	}
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatusCallCount() int {
+	fake.explainSchedulingStatusMutex.RLock()
+	defer fake.explainSchedulingStatusMutex.RUnlock()
+	return len(fake.explainSchedulingStatusArgsForCall)
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatusArgsForCall(i int) (atc.JobConfig, atc.ResourceConfigs, db.SchedulingStatus) {
+	fake.explainSchedulingStatusMutex.RLock()
+	defer fake.explainSchedulingStatusMutex.RUnlock()
+	return fake.explainSchedulingStatusArgsForCall[i].arg1, fake.explainSchedulingStatusArgsForCall[i].arg2, fake.explainSchedulingStatusArgsForCall[i].arg3
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatusReturns(result1 db.SchedulingStatus, result2 error) {
+	fake.ExplainSchedulingStatusStub = nil
+	fake.explainSchedulingStatusReturns = struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}{result1, result2}
+}
+
+var _ jobserver.Scheduler = new(FakeScheduler)
@@ -0,0 +1,57 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) GetSchedulingStatus(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		logger := s.logger.Session("get-scheduling-status", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"job":      jobName,
+		})
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		job, found := config.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status, err := pipelineDB.GetJobSchedulingStatus(jobName)
+		if err == db.ErrNoSchedulingStatus {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-get-scheduling-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		status, err = s.schedulerFactory(pipelineDB).ExplainSchedulingStatus(job, config.Resources, status)
+		if err != nil {
+			logger.Error("failed-to-explain-scheduling-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(present.SchedulingStatus(status))
+	})
+}
@@ -0,0 +1,95 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+// PreviewInputs determines the versions that the next build of the job would
+// use, like the scheduler does, but without creating a build. If no versions
+// satisfy the job's inputs, the preview has no inputs and explains why.
+func (s *Server) PreviewInputs(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		logger := s.logger.Session("preview-inputs", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"job":      jobName,
+		})
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		job, found := config.Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		buildInputs, err := pipelineDB.GetLatestInputVersions(job.Name, job.Inputs())
+		if err == db.ErrNoVersions {
+			status, err := s.schedulerFactory(pipelineDB).ExplainSchedulingStatus(job, config.Resources, db.SchedulingStatus{
+				Reason: db.SchedulingReasonNoVersions,
+				Time:   time.Now(),
+			})
+			if err != nil {
+				logger.Error("failed-to-explain-missing-versions", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			presented := present.SchedulingStatus(status)
+
+			w.WriteHeader(http.StatusOK)
+
+			json.NewEncoder(w).Encode(atc.JobInputsPreview{
+				Inputs:      []atc.BuildInput{},
+				Unsatisfied: &presented,
+			})
+
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-get-latest-input-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		preview := atc.JobInputsPreview{
+			Inputs: []atc.BuildInput{},
+		}
+
+		for _, input := range buildInputs {
+			preview.Inputs = append(preview.Inputs, present.BuildInput(input))
+		}
+
+		if len(buildInputs) > 0 {
+			existingBuild, err := pipelineDB.GetJobBuildForInputs(job.Name, buildInputs)
+			switch err {
+			case nil:
+				presented := present.Build(existingBuild)
+				preview.ExistingBuild = &presented
+			case db.ErrNoBuild:
+			default:
+				logger.Error("failed-to-get-build-for-inputs", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(preview)
+	})
+}
@@ -1,15 +1,42 @@
 package jobserver
 
-import "github.com/pivotal-golang/lager"
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . Scheduler
+
+type Scheduler interface {
+	TriggerWithVersions(lager.Logger, atc.JobConfig, atc.ResourceConfigs, []atc.JobBuildInput, bool) (db.Build, error)
+	ExplainSchedulingStatus(atc.JobConfig, atc.ResourceConfigs, db.SchedulingStatus) (db.SchedulingStatus, error)
+}
+
+type SchedulerFactory func(db.PipelineDB) Scheduler
 
 type Server struct {
 	logger lager.Logger
+
+	workerClient     worker.Client
+	schedulerFactory SchedulerFactory
+
+	// whether builds may be triggered ignoring the passed constraints of the
+	// versions chosen for them
+	allowIgnoringPassed bool
 }
 
 func NewServer(
 	logger lager.Logger,
+	workerClient worker.Client,
+	schedulerFactory SchedulerFactory,
+	allowIgnoringPassed bool,
 ) *Server {
 	return &Server{
-		logger: logger,
+		logger:              logger,
+		workerClient:        workerClient,
+		schedulerFactory:    schedulerFactory,
+		allowIgnoringPassed: allowIgnoringPassed,
 	}
 }
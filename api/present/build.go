@@ -25,5 +25,6 @@ func Build(build db.Build) atc.Build {
 		Status:  string(build.Status),
 		JobName: build.JobName,
 		URL:     req.URL.String(),
+		RerunOf: build.RerunOf,
 	}
 }
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildInput(input db.BuildInput) atc.BuildInput {
+	metadata := []atc.MetadataField{}
+	for _, field := range input.Metadata {
+		metadata = append(metadata, atc.MetadataField{
+			Name:  field.Name,
+			Value: field.Value,
+		})
+	}
+
+	return atc.BuildInput{
+		Name:     input.Name,
+		Resource: input.Resource,
+		Type:     input.Type,
+		Version:  atc.Version(input.Version),
+		Metadata: metadata,
+	}
+}
@@ -33,6 +33,12 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 		checkErrString = dbResource.CheckError.Error()
 	}
 
+	pinnedVersion := atc.Version(dbResource.PinnedVersion)
+	pinnedInConfig := resource.Version != nil
+	if pinnedInConfig {
+		pinnedVersion = resource.Version
+	}
+
 	return atc.Resource{
 		Name:   resource.Name,
 		Type:   resource.Type,
@@ -41,6 +47,9 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 
 		Paused: dbResource.Paused,
 
+		PinnedVersion:  pinnedVersion,
+		PinnedInConfig: pinnedInConfig,
+
 		FailingToCheck: dbResource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func SchedulingStatus(status db.SchedulingStatus) atc.JobSchedulingStatus {
+	return atc.JobSchedulingStatus{
+		Reason:      string(status.Reason),
+		Description: status.Description(),
+
+		Input:     status.Input,
+		Resource:  status.Resource,
+		PassedJob: status.PassedJob,
+		BuildName: status.BuildName,
+
+		Time: status.Time.Unix(),
+	}
+}
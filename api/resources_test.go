@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -158,6 +159,52 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when resources are pinned", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Resources: []atc.ResourceConfig{
+							{Name: "resource-1", Type: "type-1"},
+							{Name: "resource-2", Type: "type-2", Version: atc.Version{"ref": "config-ref"}},
+						},
+					}, 1, nil)
+
+					pipelineDB.GetResourceStub = func(name string) (db.SavedResource, error) {
+						return db.SavedResource{
+							ID:              1,
+							PipelineName:    "a-pipeline",
+							PinnedVersionID: 42,
+							PinnedVersion:   db.Version{"ref": "api-ref"},
+							Resource: db.Resource{
+								Name: name,
+							},
+						}, nil
+					}
+				})
+
+				It("returns the pinned version, preferring the one in the config", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(body).Should(MatchJSON(`[
+						{
+							"name": "resource-1",
+							"type": "type-1",
+							"groups": [],
+							"url": "/pipelines/a-pipeline/resources/resource-1",
+							"pinned_version": {"ref": "api-ref"}
+						},
+						{
+							"name": "resource-2",
+							"type": "type-2",
+							"groups": [],
+							"url": "/pipelines/a-pipeline/resources/resource-2",
+							"pinned_version": {"ref": "config-ref"},
+							"pinned_in_config": true
+						}
+					]`))
+				})
+			})
+
 			Context("when getting the resource check error", func() {
 				BeforeEach(func() {
 					pipelineDB.GetResourceStub = func(name string) (db.SavedResource, error) {
@@ -303,6 +350,166 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/versions/42/pin", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
+				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineName).Should(Equal("a-pipeline"))
+			})
+
+			Context("when pinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.PinVersionedResourceReturns(nil)
+				})
+
+				It("pinned the right resource to the right version", func() {
+					resourceName, versionedResourceID := pipelineDB.PinVersionedResourceArgsForCall(0)
+					Ω(resourceName).Should(Equal("resource-name"))
+					Ω(versionedResourceID).Should(Equal(42))
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the version is not a version of the resource", func() {
+				BeforeEach(func() {
+					pipelineDB.PinVersionedResourceReturns(db.ErrNoVersionedResource)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when pinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.PinVersionedResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+
+				pipelineDB.GetConfigReturns(atc.Config{
+					Resources: []atc.ResourceConfig{
+						{Name: "resource-name", Type: "git"},
+					},
+				}, 1, nil)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceReturns(nil)
+				})
+
+				It("unpinned the right resource", func() {
+					Ω(pipelineDB.UnpinResourceArgsForCall(0)).Should(Equal("resource-name"))
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the resource is not in the pipeline's config", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{
+						Resources: []atc.ResourceConfig{
+							{Name: "some-other-resource", Type: "git"},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				})
+
+				It("does not unpin anything", func() {
+					Ω(pipelineDB.UnpinResourceCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when getting the pipeline's config fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 0, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/pause", func() {
 		var response *http.Response
 
@@ -422,4 +629,275 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/pipelines/:pipeline_name/resources/:resource_name/check", func() {
+		var (
+			body     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			body = ""
+
+			pipelineDB.GetPipelineNameReturns("a-pipeline")
+			pipelineDB.GetConfigReturns(atc.Config{
+				Resources: []atc.ResourceConfig{
+					{Name: "resource-name", Type: "git"},
+				},
+			}, 1, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/check", bytes.NewBufferString(body))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
+				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineName).Should(Equal("a-pipeline"))
+			})
+
+			Context("when checking succeeds", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns([]atc.Version{
+						{"ref": "abc"},
+						{"ref": "def"},
+					}, nil)
+				})
+
+				It("checks the resource from the latest version", func() {
+					Ω(scannerPipelineDB).Should(Equal(pipelineDB))
+
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(Equal(1))
+					_, resourceName, from := fakeScanner.ScanFromVersionArgsForCall(0)
+					Ω(resourceName).Should(Equal("resource-name"))
+					Ω(from).Should(BeNil())
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+
+				It("returns the versions that were saved", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(body).Should(MatchJSON(`{
+						"new_versions": 2,
+						"versions": [{"ref": "abc"}, {"ref": "def"}]
+					}`))
+				})
+
+				Context("when a version to check from is given", func() {
+					BeforeEach(func() {
+						body = `{"from":{"ref":"some-older-ref"}}`
+					})
+
+					It("checks the resource from the given version", func() {
+						Ω(fakeScanner.ScanFromVersionCallCount()).Should(Equal(1))
+						_, _, from := fakeScanner.ScanFromVersionArgsForCall(0)
+						Ω(from).Should(Equal(atc.Version{"ref": "some-older-ref"}))
+					})
+				})
+			})
+
+			Context("when checking fails", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+
+				It("returns the check error", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(body).Should(MatchJSON(`{"new_versions":0,"check_error":"nope"}`))
+				})
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+				})
+
+				It("does not check the resource", func() {
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the resource is not in the config", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not check the resource", func() {
+				Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var (
+			token    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			token = "some-token"
+
+			pipelineDB.GetPipelineNameReturns("a-pipeline")
+			pipelineDB.GetConfigReturns(atc.Config{
+				Resources: []atc.ResourceConfig{
+					{Name: "resource-name", Type: "git", WebhookToken: "some-token"},
+				},
+			}, 1, nil)
+
+			// the webhook must work without basic auth
+			authValidator.IsAuthenticatedReturns(false)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/check/webhook?token="+token, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("injects the proper pipelineDB", func() {
+			Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
+			pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+			Ω(pipelineName).Should(Equal("a-pipeline"))
+		})
+
+		Context("when the token matches", func() {
+			Context("when checking succeeds", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns([]atc.Version{
+						{"ref": "abc"},
+						{"ref": "def"},
+					}, nil)
+				})
+
+				It("checks the resource with the pipeline's scanner", func() {
+					Ω(scannerPipelineDB).Should(Equal(pipelineDB))
+
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(Equal(1))
+					_, resourceName, from := fakeScanner.ScanFromVersionArgsForCall(0)
+					Ω(resourceName).Should(Equal("resource-name"))
+					Ω(from).Should(BeNil())
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+
+				It("returns the number of new versions", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(body).Should(MatchJSON(`{"new_versions":2}`))
+				})
+			})
+
+			Context("when checking fails", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the token does not match", func() {
+			BeforeEach(func() {
+				token = "bogus-token"
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not check the resource", func() {
+				Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when the resource has no webhook token", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{
+					Resources: []atc.ResourceConfig{
+						{Name: "resource-name", Type: "git"},
+					},
+				}, 1, nil)
+
+				token = ""
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not check the resource", func() {
+				Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when the resource is not in the config", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+			})
+
+			It("returns 404", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when getting the config fails", func() {
+			BeforeEach(func() {
+				pipelineDB.GetConfigReturns(atc.Config{}, 0, errors.New("nope"))
+			})
+
+			It("returns 500", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
@@ -0,0 +1,63 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResource(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		logger := s.logger.Session("check-resource", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"resource": resourceName,
+		})
+
+		var request atc.CheckRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, found := config.Resources.Lookup(resourceName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		newVersions, err := s.scannerFactory(pipelineDB).ScanFromVersion(logger, resourceName, request.From)
+		if err != nil {
+			logger.Error("failed-to-check", err)
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			json.NewEncoder(w).Encode(atc.CheckResponse{
+				CheckError: err.Error(),
+			})
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(atc.CheckResponse{
+			NewVersions: len(newVersions),
+			Versions:    newVersions,
+		})
+	})
+}
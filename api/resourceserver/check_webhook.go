@@ -0,0 +1,57 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResourceWebHook(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		logger := s.logger.Session("check-resource-webhook", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"resource": resourceName,
+		})
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resourceConfig, found := config.Resources.Lookup(resourceName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		token := r.URL.Query().Get("token")
+
+		if resourceConfig.WebhookToken == "" ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(resourceConfig.WebhookToken)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		newVersions, err := s.scannerFactory(pipelineDB).ScanFromVersion(logger, resourceName, nil)
+		if err != nil {
+			logger.Error("failed-to-check", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(atc.CheckResponse{
+			NewVersions: len(newVersions),
+		})
+	})
+}
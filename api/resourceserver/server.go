@@ -3,21 +3,34 @@ package resourceserver
 import (
 	"github.com/pivotal-golang/lager"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
 )
 
+//go:generate counterfeiter . Scanner
+
+type Scanner interface {
+	ScanFromVersion(lager.Logger, string, atc.Version) ([]atc.Version, error)
+}
+
+type ScannerFactory func(db.PipelineDB) Scanner
+
 type Server struct {
 	logger lager.Logger
 
-	validator auth.Validator
+	validator      auth.Validator
+	scannerFactory ScannerFactory
 }
 
 func NewServer(
 	logger lager.Logger,
 	validator auth.Validator,
+	scannerFactory ScannerFactory,
 ) *Server {
 	return &Server{
-		logger:    logger,
-		validator: validator,
+		logger:         logger,
+		validator:      validator,
+		scannerFactory: scannerFactory,
 	}
 }
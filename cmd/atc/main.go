@@ -29,9 +29,12 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/api/jobserver"
+	"github.com/concourse/atc/api/resourceserver"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/credentials"
 	Db "github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
@@ -156,6 +159,24 @@ var checkInterval = flag.Duration(
 	"interval on which to poll for new versions of resources",
 )
 
+var credentialsDir = flag.String(
+	"credentialsDir",
+	"",
+	"directory containing a <pipeline>.yml file of ((credentials)) for each pipeline",
+)
+
+var credentialsEnvPrefix = flag.String(
+	"credentialsEnvPrefix",
+	"",
+	"resolve ((credentials)) from environment variables named <prefix>_<pipeline>__<name>",
+)
+
+var allowIgnoringPassed = flag.Bool(
+	"allowIgnoringPassed",
+	false,
+	"allow builds to be triggered with chosen versions that have not passed the jobs their inputs require",
+)
+
 var publiclyViewable = flag.Bool(
 	"publiclyViewable",
 	false,
@@ -180,6 +201,48 @@ var cliDownloadsDir = flag.String(
 	"directory containing CLI binaries to serve",
 )
 
+var abortGracePeriod = flag.Duration(
+	"abortGracePeriod",
+	10*time.Second,
+	"how long a step's process may take to exit after being terminated when its build is aborted, before it is killed",
+)
+
+var defaultTaskMemoryLimit = flag.Uint64(
+	"defaultTaskMemoryLimit",
+	0,
+	"memory limit, in bytes, for task containers that do not specify one (0 for no limit)",
+)
+
+var defaultTaskCPULimit = flag.Uint64(
+	"defaultTaskCPULimit",
+	0,
+	"cpu limit, in shares, for task containers that do not specify one (0 for no limit)",
+)
+
+var defaultTaskDiskLimit = flag.Uint64(
+	"defaultTaskDiskLimit",
+	0,
+	"disk limit, in bytes, for task containers that do not specify one (0 for no limit)",
+)
+
+var maxTaskMemoryLimit = flag.Uint64(
+	"maxTaskMemoryLimit",
+	0,
+	"maximum memory limit, in bytes, that a task container may specify (0 for no maximum; requires -defaultTaskMemoryLimit)",
+)
+
+var maxTaskCPULimit = flag.Uint64(
+	"maxTaskCPULimit",
+	0,
+	"maximum cpu limit, in shares, that a task container may specify (0 for no maximum; requires -defaultTaskCPULimit)",
+)
+
+var maxTaskDiskLimit = flag.Uint64(
+	"maxTaskDiskLimit",
+	0,
+	"maximum disk limit, in bytes, that a task container may specify (0 for no maximum; requires -defaultTaskDiskLimit)",
+)
+
 func main() {
 	flag.Parse()
 
@@ -195,6 +258,32 @@ func main() {
 		fatal(errors.New("directory specified via -public does not exist"))
 	}
 
+	if *credentialsDir != "" && *credentialsEnvPrefix != "" {
+		fatal(errors.New("only one of -credentialsDir and -credentialsEnvPrefix may be specified"))
+	}
+
+	defaultTaskContainerLimits := atc.ContainerLimits{
+		Memory: *defaultTaskMemoryLimit,
+		CPU:    *defaultTaskCPULimit,
+		Disk:   *defaultTaskDiskLimit,
+	}
+
+	maxTaskContainerLimits := atc.ContainerLimits{
+		Memory: *maxTaskMemoryLimit,
+		CPU:    *maxTaskCPULimit,
+		Disk:   *maxTaskDiskLimit,
+	}
+
+	if exceeding := defaultTaskContainerLimits.Exceeding(maxTaskContainerLimits); len(exceeding) > 0 {
+		fatal(errors.New("default task container limits must not exceed the maximums: " + strings.Join(exceeding, ", ")))
+	}
+
+	// tasks that set no limit of their own would otherwise get around the
+	// maximum
+	if unlimited := defaultTaskContainerLimits.Unlimited(maxTaskContainerLimits); len(unlimited) > 0 {
+		fatal(errors.New("default task container limits must be set for each maximum: " + strings.Join(unlimited, ", ")))
+	}
+
 	logger := lager.NewLogger("atc")
 
 	logLevel := lager.INFO
@@ -242,6 +331,7 @@ func main() {
 	}
 
 	var workerClient worker.Client
+	var workerProvider worker.WorkerProvider
 	if *gardenAddr != "" {
 		workerClient = worker.NewGardenWorker(
 			gclient.New(gconn.NewWithLogger(
@@ -256,7 +346,8 @@ func main() {
 			[]string{},
 		)
 	} else {
-		workerClient = worker.NewPool(worker.NewDBWorkerProvider(db, logger))
+		workerProvider = worker.NewDBWorkerProvider(db, logger)
+		workerClient = worker.NewPool(workerProvider)
 	}
 
 	resourceTracker := resource.NewTracker(workerClient)
@@ -267,8 +358,15 @@ func main() {
 		}
 
 		return guid.String()
-	})
-	execEngine := engine.NewExecEngine(gardenFactory, engine.NewBuildDelegateFactory(db), db)
+	}, defaultTaskContainerLimits, maxTaskContainerLimits, *abortGracePeriod)
+	var credentialManager credentials.CredentialManager
+	if *credentialsDir != "" {
+		credentialManager = credentials.NewFileCredentialManager(*credentialsDir)
+	} else if *credentialsEnvPrefix != "" {
+		credentialManager = credentials.NewEnvCredentialManager(*credentialsEnvPrefix)
+	}
+
+	execEngine := engine.NewExecEngine(gardenFactory, engine.NewBuildDelegateFactory(db), db, credentialManager)
 
 	engine := engine.NewDBEngine(engine.Engines{execEngine}, db, db)
 
@@ -293,8 +391,27 @@ func main() {
 		fatal(err)
 	}
 
+	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
+		resourceTracker,
+		*checkInterval,
+		db,
+		engine,
+		db,
+		credentialManager,
+		workerProvider,
+	)
+
 	drain := make(chan struct{})
 
+	configValidator := func(c atc.Config) error {
+		err := config.ValidateConfig(c)
+		if err != nil {
+			return err
+		}
+
+		return config.ValidateContainerLimits(c, maxTaskContainerLimits)
+	}
+
 	apiHandler, err := api.NewHandler(
 		logger,            // logger lager.Logger,
 		webValidator,      // validator auth.Validator,
@@ -307,10 +424,20 @@ func main() {
 		db, // pipeDB pipes.PipeDB,
 		db, // pipelinesDB db.PipelinesDB,
 
-		config.ValidateConfig,       // configValidator configserver.ConfigValidator,
+		configValidator,             // configValidator configserver.ConfigValidator,
 		callbacksURL.String(),       // peerURL string,
 		buildserver.NewEventHandler, // eventHandlerFactory buildserver.EventHandlerFactory,
-		drain, // drain <-chan struct{},
+		func(pipelineDB Db.PipelineDB) resourceserver.Scanner { // scannerFactory resourceserver.ScannerFactory,
+			return radarSchedulerFactory.BuildRadar(pipelineDB)
+		},
+		func(pipelineDB Db.PipelineDB) jobserver.Scheduler { // schedulerFactory jobserver.SchedulerFactory,
+			return radarSchedulerFactory.BuildScheduler(pipelineDB)
+		},
+		func(pipelineDB Db.PipelineDB) buildserver.Scheduler { // buildSchedulerFactory buildserver.SchedulerFactory,
+			return radarSchedulerFactory.BuildScheduler(pipelineDB)
+		},
+		*allowIgnoringPassed, // allowIgnoringPassed bool,
+		drain,                // drain <-chan struct{},
 
 		engine,       // engine engine.Engine,
 		workerClient, // workerClient worker.Client,
@@ -323,14 +450,6 @@ func main() {
 		fatal(err)
 	}
 
-	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
-		resourceTracker,
-		*checkInterval,
-		db,
-		engine,
-		db,
-	)
-
 	webHandler, err := web.NewHandler(
 		logger,
 		webValidator,
@@ -341,6 +460,7 @@ func main() {
 		*templatesDir,
 		*publicDir,
 		engine,
+		*allowIgnoringPassed,
 	)
 	if err != nil {
 		fatal(err)
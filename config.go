@@ -1,6 +1,9 @@
 package atc
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
 const DefaultPipelineName = "main"
@@ -40,6 +43,18 @@ type ResourceConfig struct {
 
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+
+	// how often to check for new versions, overriding the default interval
+	CheckEvery string `yaml:"check_every,omitempty" json:"check_every,omitempty" mapstructure:"check_every"`
+
+	// interrupt the check after a given duration
+	CheckTimeout string `yaml:"check_timeout,omitempty" json:"check_timeout,omitempty" mapstructure:"check_timeout"`
+
+	// shared secret allowing the check webhook to be called without auth
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
+
+	// pin every get of the resource to this version
+	Version Version `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
 
 type JobConfig struct {
@@ -48,6 +63,9 @@ type JobConfig struct {
 	Serial       bool     `yaml:"serial,omitempty" json:"serial,omitempty" mapstructure:"serial"`
 	SerialGroups []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 
+	// how many builds of the job (or of its serial groups) may run at once
+	RawMaxInFlight int `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+
 	Privileged     bool        `yaml:"privileged,omitempty" json:"privileged,omitempty" mapstructure:"privileged"`
 	TaskConfigPath string      `yaml:"build,omitempty" json:"build,omitempty" mapstructure:"build"`
 	TaskConfig     *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
@@ -56,6 +74,12 @@ type JobConfig struct {
 	OutputConfigs []JobOutputConfig `yaml:"outputs,omitempty" json:"outputs,omitempty" mapstructure:"outputs"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
+
+	// hooks run around the entire plan, as with the hooks of a single step
+	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
+	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
+	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+	Ensure  *PlanConfig `yaml:"ensure,omitempty" json:"ensure,omitempty" mapstructure:"ensure"`
 }
 
 type PluginConfig struct {
@@ -74,13 +98,45 @@ func (config JobConfig) GetSerialGroups() []string {
 		return config.SerialGroups
 	}
 
-	if config.IsSerial() {
+	if config.IsSerial() || config.RawMaxInFlight > 0 {
 		return []string{config.Name}
 	}
 
 	return []string{}
 }
 
+// MaxInFlight returns how many builds of the job's serial groups may be
+// running at once, or 0 if there is no limit.
+func (config JobConfig) MaxInFlight() int {
+	if config.Serial {
+		return 1
+	}
+
+	if config.RawMaxInFlight > 0 {
+		return config.RawMaxInFlight
+	}
+
+	if len(config.SerialGroups) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// Hooks returns the job's hooks, in the order in which they are considered
+// once the plan has finished.
+func (config JobConfig) Hooks() []PlanConfig {
+	var hooks []PlanConfig
+
+	for _, hook := range []*PlanConfig{config.Failure, config.Success, config.Abort, config.Ensure} {
+		if hook != nil {
+			hooks = append(hooks, *hook)
+		}
+	}
+
+	return hooks
+}
+
 func (config JobConfig) Inputs() []JobInput {
 	if config.InputConfigs != nil {
 		var inputs []JobInput
@@ -97,7 +153,13 @@ func (config JobConfig) Inputs() []JobInput {
 		return inputs
 	}
 
-	return collectInputs(PlanConfig{Do: &config.Plan})
+	inputs := collectInputs(PlanConfig{Do: &config.Plan})
+
+	for _, hook := range config.Hooks() {
+		inputs = append(inputs, collectInputs(hook)...)
+	}
+
+	return inputs
 }
 
 func (config JobConfig) Outputs() []JobOutput {
@@ -114,7 +176,13 @@ func (config JobConfig) Outputs() []JobOutput {
 		return outputs
 	}
 
-	return collectOutputs(PlanConfig{Do: &config.Plan})
+	outputs := collectOutputs(PlanConfig{Do: &config.Plan})
+
+	for _, hook := range config.Hooks() {
+		outputs = append(outputs, collectOutputs(hook)...)
+	}
+
+	return outputs
 }
 
 // A PlanSequence corresponds to a chain of Compose plan, with an implicit
@@ -137,6 +205,10 @@ type PlanConfig struct {
 
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
+	// used by Aggregate to limit how many of its steps run at once
+	Limit int `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	// used by Aggregate to interrupt its remaining steps once one of them fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
 
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
@@ -145,10 +217,16 @@ type PlanConfig struct {
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+	// which versions of the resource to fetch; every, latest, or a specific version
+	Version VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 
 	// name of 'output', e.g. rootfs-tarball
 	Put string `yaml:"put,omitempty" json:"put,omitempty" mapstructure:"put"`
 
+	// corresponds to a Delete resource plan
+	// name of the resource to delete a version of, e.g. pr-environment
+	Delete string `yaml:"delete,omitempty" json:"delete,omitempty" mapstructure:"delete"`
+
 	// corresponding resource config, e.g. aws-stemcell
 	Resource string `yaml:"resource,omitempty" json:"resource,omitempty" mapstructure:"resource"`
 
@@ -162,12 +240,25 @@ type PlanConfig struct {
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
-	// used by Get and Put for specifying params to the resource
+	// used by Task to give its declared inputs artifacts with other names
+	InputMapping map[string]string `yaml:"input_mapping,omitempty" json:"input_mapping,omitempty" mapstructure:"input_mapping"`
+
+	// used by Task to register its declared outputs under other names
+	OutputMapping map[string]string `yaml:"output_mapping,omitempty" json:"output_mapping,omitempty" mapstructure:"output_mapping"`
+
+	// used by Get, Put, and Delete for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
 	// used by Put to specify params for the subsequent Get
 	GetParams Params `yaml:"get_params,omitempty" json:"get_params,omitempty" mapstructure:"get_params"`
 
+	// used by Put to skip the subsequent Get, e.g. for large artifacts
+	NoGet bool `yaml:"no_get,omitempty" json:"no_get,omitempty" mapstructure:"no_get"`
+
+	// used by Get, Put, Delete, and Task to name params whose values must be redacted
+	// from the build's output
+	SensitiveParams []string `yaml:"sensitive_params,omitempty" json:"sensitive_params,omitempty" mapstructure:"sensitive_params"`
+
 	// used by any step to specify which workers are eligible to run the step
 	Tags Tags `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
 
@@ -180,12 +271,18 @@ type PlanConfig struct {
 	// used on any step to execute on successful completion of the step
 	Success *PlanConfig `yaml:"on_success,omitempty" json:"on_success,omitempty" mapstructure:"on_success"`
 
+	// used on any step to execute if the build is aborted while the step is running
+	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
+
 	// used on any step to swallow failures and errors
 	Try *PlanConfig `yaml:"try,omitempty" json:"try,omitempty" mapstructure:"try"`
 
 	// used on any step to interrupt the step after a given duration
 	Timeout string `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
 
+	// used on any step to run it again, up to this many times in total, until it succeeds
+	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+
 	// not present in yaml
 	Location *Location `yaml:"-" json:"-"`
 
@@ -206,6 +303,10 @@ func (config PlanConfig) Name() string {
 		return config.Put
 	}
 
+	if config.Delete != "" {
+		return config.Delete
+	}
+
 	if config.Task != "" {
 		return config.Task
 	}
@@ -229,6 +330,11 @@ func (config PlanConfig) ResourceName() string {
 		return resourceName
 	}
 
+	resourceName = config.Delete
+	if resourceName != "" {
+		return resourceName
+	}
+
 	panic("no resource name!")
 }
 
@@ -300,6 +406,100 @@ func (c *Condition) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// A VersionConfig determines which versions of a resource a get step
+// fetches: every version in order, only the latest version (the default), or
+// one specific version, e.g. {ref: abcdef}, which is stored as JSON.
+type VersionConfig string
+
+const (
+	VersionLatest VersionConfig = "latest"
+	VersionEvery  VersionConfig = "every"
+)
+
+func (c VersionConfig) Every() bool {
+	return c == VersionEvery
+}
+
+func (c VersionConfig) Pinned() (Version, bool) {
+	if c == "" || c == VersionLatest || c == VersionEvery {
+		return nil, false
+	}
+
+	var version Version
+	err := json.Unmarshal([]byte(c), &version)
+	if err != nil {
+		return nil, false
+	}
+
+	return version, true
+}
+
+func (c *VersionConfig) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*c = VersionConfig(str)
+		return nil
+	}
+
+	var version Version
+	if err := json.Unmarshal(data, &version); err != nil {
+		return fmt.Errorf("invalid version: %s", err)
+	}
+
+	return c.pin(version)
+}
+
+func (c VersionConfig) MarshalJSON() ([]byte, error) {
+	if version, pinned := c.Pinned(); pinned {
+		return json.Marshal(version)
+	}
+
+	return json.Marshal(string(c))
+}
+
+func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err == nil {
+		*c = VersionConfig(str)
+		return nil
+	}
+
+	var version Version
+	if err := unmarshal(&version); err != nil {
+		return fmt.Errorf("invalid version: %s", err)
+	}
+
+	return c.pin(version)
+}
+
+func (c VersionConfig) MarshalYAML() (interface{}, error) {
+	if version, pinned := c.Pinned(); pinned {
+		return version, nil
+	}
+
+	return string(c), nil
+}
+
+// PinnedVersionConfig returns a VersionConfig that is pinned to the given
+// version.
+func PinnedVersionConfig(version Version) (VersionConfig, error) {
+	var c VersionConfig
+	err := c.pin(version)
+	return c, err
+}
+
+func (c *VersionConfig) pin(version Version) error {
+	// encoding/json sorts the keys, so equal versions encode identically
+	payload, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	*c = VersionConfig(payload)
+
+	return nil
+}
+
 type ResourceConfigs []ResourceConfig
 
 func (resources ResourceConfigs) Lookup(name string) (ResourceConfig, bool) {
@@ -354,6 +554,10 @@ func collectInputs(plan PlanConfig) []JobInput {
 		inputs = append(inputs, collectInputs(*plan.Failure)...)
 	}
 
+	if plan.Abort != nil {
+		inputs = append(inputs, collectInputs(*plan.Abort)...)
+	}
+
 	if plan.Ensure != nil {
 		inputs = append(inputs, collectInputs(*plan.Ensure)...)
 	}
@@ -387,6 +591,8 @@ func collectInputs(plan PlanConfig) []JobInput {
 			Resource: resource,
 			Passed:   plan.Passed,
 			Trigger:  plan.Trigger,
+			Version:  plan.Version,
+			Tags:     plan.Tags,
 		})
 	}
 
@@ -404,6 +610,10 @@ func collectOutputs(plan PlanConfig) []JobOutput {
 		outputs = append(outputs, collectOutputs(*plan.Failure)...)
 	}
 
+	if plan.Abort != nil {
+		outputs = append(outputs, collectOutputs(*plan.Abort)...)
+	}
+
 	if plan.Ensure != nil {
 		outputs = append(outputs, collectOutputs(*plan.Ensure)...)
 	}
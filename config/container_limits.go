@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// ValidateContainerLimits rejects any task configured inline in the pipeline
+// whose container limits exceed the given maximum. Tasks configured via a
+// file can only be checked when they run.
+func ValidateContainerLimits(c atc.Config, max atc.ContainerLimits) error {
+	errorMessages := []string{}
+
+	for i, job := range c.Jobs {
+		var identifier string
+		if job.Name == "" {
+			identifier = fmt.Sprintf("jobs[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("jobs.%s", job.Name)
+		}
+
+		if job.TaskConfig != nil {
+			errorMessages = append(errorMessages, validateTaskContainerLimits(identifier+".config", *job.TaskConfig, max)...)
+		}
+
+		errorMessages = append(errorMessages, validatePlanContainerLimits(identifier+".plan", atc.PlanConfig{Do: &job.Plan}, max)...)
+
+		hooks := []struct {
+			name string
+			plan *atc.PlanConfig
+		}{
+			{"on_success", job.Success},
+			{"on_failure", job.Failure},
+			{"on_abort", job.Abort},
+			{"ensure", job.Ensure},
+		}
+
+		for _, hook := range hooks {
+			if hook.plan != nil {
+				errorMessages = append(errorMessages, validatePlanContainerLimits(identifier+"."+hook.name, *hook.plan, max)...)
+			}
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
+func validatePlanContainerLimits(identifier string, plan atc.PlanConfig, max atc.ContainerLimits) []string {
+	errorMessages := []string{}
+
+	switch {
+	case plan.Do != nil:
+		for i, plan := range *plan.Do {
+			subIdentifier := fmt.Sprintf("%s[%d]", identifier, i)
+			errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, plan, max)...)
+		}
+
+	case plan.Aggregate != nil:
+		for i, plan := range *plan.Aggregate {
+			subIdentifier := fmt.Sprintf("%s.aggregate[%d]", identifier, i)
+			errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, plan, max)...)
+		}
+
+	case plan.Task != "":
+		if plan.TaskConfig != nil {
+			subIdentifier := fmt.Sprintf("%s.task.%s.config", identifier, plan.Task)
+			errorMessages = append(errorMessages, validateTaskContainerLimits(subIdentifier, *plan.TaskConfig, max)...)
+		}
+
+	case plan.Try != nil:
+		subIdentifier := fmt.Sprintf("%s.try", identifier)
+		errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, *plan.Try, max)...)
+	}
+
+	if plan.Ensure != nil {
+		subIdentifier := fmt.Sprintf("%s.ensure", identifier)
+		errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, *plan.Ensure, max)...)
+	}
+
+	if plan.Success != nil {
+		subIdentifier := fmt.Sprintf("%s.success", identifier)
+		errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, *plan.Success, max)...)
+	}
+
+	if plan.Failure != nil {
+		subIdentifier := fmt.Sprintf("%s.failure", identifier)
+		errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, *plan.Failure, max)...)
+	}
+
+	if plan.Abort != nil {
+		subIdentifier := fmt.Sprintf("%s.abort", identifier)
+		errorMessages = append(errorMessages, validatePlanContainerLimits(subIdentifier, *plan.Abort, max)...)
+	}
+
+	return errorMessages
+}
+
+func validateTaskContainerLimits(identifier string, config atc.TaskConfig, max atc.ContainerLimits) []string {
+	exceeding := config.ContainerLimits.Exceeding(max)
+	if len(exceeding) == 0 {
+		return []string{}
+	}
+
+	return []string{
+		fmt.Sprintf(
+			"%s.container_limits exceed the maximum: %s",
+			identifier,
+			strings.Join(exceeding, ", "),
+		),
+	}
+}
@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateContainerLimits", func() {
+	var (
+		config atc.Config
+		max    atc.ContainerLimits
+
+		validateErr error
+	)
+
+	BeforeEach(func() {
+		config = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Task: "some-task",
+							TaskConfig: &atc.TaskConfig{
+								ContainerLimits: atc.ContainerLimits{
+									Memory: 1024,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		max = atc.ContainerLimits{
+			Memory: 2048,
+			CPU:    512,
+		}
+	})
+
+	JustBeforeEach(func() {
+		validateErr = ValidateContainerLimits(config, max)
+	})
+
+	Context("when every task is within the maximum", func() {
+		It("returns no error", func() {
+			Ω(validateErr).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("when a task exceeds the maximum", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan = append(config.Jobs[0].Plan, atc.PlanConfig{
+				Aggregate: &atc.PlanSequence{
+					{
+						Task: "some-greedy-task",
+						TaskConfig: &atc.TaskConfig{
+							ContainerLimits: atc.ContainerLimits{
+								Memory: 4096,
+								CPU:    1024,
+							},
+						},
+					},
+				},
+			})
+		})
+
+		It("returns an error", func() {
+			Ω(validateErr).Should(HaveOccurred())
+			Ω(validateErr.Error()).Should(Equal(
+				"jobs.some-job.plan[1].aggregate[0].task.some-greedy-task.config.container_limits exceed the maximum: memory (4096 > 2048), cpu (1024 > 512)",
+			))
+		})
+	})
+
+	Context("when a task in a hook exceeds the maximum", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Failure = &atc.PlanConfig{
+				Task: "some-hook-task",
+				TaskConfig: &atc.TaskConfig{
+					ContainerLimits: atc.ContainerLimits{
+						CPU: 1024,
+					},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Ω(validateErr).Should(HaveOccurred())
+			Ω(validateErr.Error()).Should(ContainSubstring(
+				"jobs.some-job.plan[0].failure.task.some-hook-task.config.container_limits exceed the maximum: cpu (1024 > 512)",
+			))
+		})
+	})
+
+	Context("when a task in an abort hook exceeds the maximum", func() {
+		BeforeEach(func() {
+			config.Jobs[0].Plan[0].Abort = &atc.PlanConfig{
+				Task: "some-hook-task",
+				TaskConfig: &atc.TaskConfig{
+					ContainerLimits: atc.ContainerLimits{
+						CPU: 1024,
+					},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Ω(validateErr).Should(HaveOccurred())
+			Ω(validateErr.Error()).Should(ContainSubstring(
+				"jobs.some-job.plan[0].abort.task.some-hook-task.config.container_limits exceed the maximum: cpu (1024 > 512)",
+			))
+		})
+	})
+
+	Context("when a task in one of the job's hooks exceeds the maximum", func() {
+		hookTask := func() *atc.PlanConfig {
+			return &atc.PlanConfig{
+				Task: "some-hook-task",
+				TaskConfig: &atc.TaskConfig{
+					ContainerLimits: atc.ContainerLimits{
+						Memory: 4096,
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			config.Jobs[0].Success = hookTask()
+			config.Jobs[0].Failure = hookTask()
+			config.Jobs[0].Abort = hookTask()
+			config.Jobs[0].Ensure = hookTask()
+		})
+
+		It("returns an error for each of them", func() {
+			Ω(validateErr).Should(HaveOccurred())
+
+			for _, hook := range []string{"on_success", "on_failure", "on_abort", "ensure"} {
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"jobs.some-job." + hook + ".task.some-hook-task.config.container_limits exceed the maximum: memory (4096 > 2048)",
+				))
+			}
+		})
+	})
+
+	Context("when there is no maximum", func() {
+		BeforeEach(func() {
+			max = atc.ContainerLimits{}
+		})
+
+		It("returns no error", func() {
+			Ω(validateErr).ShouldNot(HaveOccurred())
+		})
+	})
+})
@@ -117,6 +117,24 @@ func validateResources(c atc.Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.CheckEvery != "" {
+			interval, err := time.ParseDuration(resource.CheckEvery)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".check_every refers to a duration that could not be parsed ('%s')", resource.CheckEvery))
+			} else if interval <= 0 {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".check_every must be a positive duration ('%s')", resource.CheckEvery))
+			}
+		}
+
+		if resource.CheckTimeout != "" {
+			timeout, err := time.ParseDuration(resource.CheckTimeout)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".check_timeout refers to a duration that could not be parsed ('%s')", resource.CheckTimeout))
+			} else if timeout <= 0 {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".check_timeout must be a positive duration ('%s')", resource.CheckTimeout))
+			}
+		}
 	}
 
 	return compositeErr(errorMessages)
@@ -148,18 +166,62 @@ func validateJobs(c atc.Config) error {
 			errorMessages = append(errorMessages, identifier+" has no name")
 		}
 
+		if job.RawMaxInFlight < 0 {
+			errorMessages = append(errorMessages, identifier+" has a negative max_in_flight")
+		}
+
+		if job.Serial && job.RawMaxInFlight > 1 {
+			errorMessages = append(errorMessages, identifier+" has serial: true and max_in_flight greater than 1, which conflict")
+		}
+
 		if job.Plan != nil && (job.TaskConfig != nil || len(job.TaskConfigPath) > 0 || len(job.InputConfigs) > 0 || len(job.OutputConfigs) > 0) {
 			errorMessages = append(errorMessages, identifier+" has both a plan and inputs/outputs/build config specified")
 		}
 
+		scope := &artifactScope{names: map[string]bool{}}
+
 		errorMessages = append(errorMessages, validateConditionals(identifier+".plan", job.Plan)...)
 		errorMessages = append(errorMessages, validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})...)
+		errorMessages = append(errorMessages, validateInputMappings(identifier+".plan", atc.PlanConfig{Do: &job.Plan}, scope)...)
+		errorMessages = append(errorMessages, validateJobHooks(c, identifier, job, scope)...)
 		errorMessages = append(errorMessages, validateInputOutputConfig(c, job, identifier)...)
 	}
 
 	return compositeErr(errorMessages)
 }
 
+// validateJobHooks validates the hooks run around the job's plan. They may map
+// any artifact produced by the plan, as with the hooks of a single step.
+func validateJobHooks(c atc.Config, identifier string, job atc.JobConfig, scope *artifactScope) []string {
+	errorMessages := []string{}
+
+	hooks := []struct {
+		name string
+		plan *atc.PlanConfig
+	}{
+		{"on_success", job.Success},
+		{"on_failure", job.Failure},
+		{"on_abort", job.Abort},
+		{"ensure", job.Ensure},
+	}
+
+	for _, hook := range hooks {
+		if hook.plan == nil {
+			continue
+		}
+
+		if hasConditionals(job.Plan) {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s.plan has conditions, which cannot be used with %s", identifier, hook.name))
+		}
+
+		subIdentifier := fmt.Sprintf("%s.%s", identifier, hook.name)
+		errorMessages = append(errorMessages, validatePlan(c, subIdentifier, *hook.plan)...)
+		errorMessages = append(errorMessages, validateInputMappings(subIdentifier, *hook.plan, scope.copy())...)
+	}
+
+	return errorMessages
+}
+
 func validateConditionals(identifier string, planSequence atc.PlanSequence) []string {
 	hasConditionals := hasConditionals(planSequence)
 	hasHooks := hasHooks(planSequence)
@@ -181,7 +243,7 @@ func hasConditionals(planSequence atc.PlanSequence) bool {
 
 func hasHooks(planSequence atc.PlanSequence) bool {
 	return doesAnyStepMatch(planSequence, func(step atc.PlanConfig) bool {
-		return step.Failure != nil || step.Ensure != nil || step.Success != nil
+		return step.Failure != nil || step.Ensure != nil || step.Success != nil || step.Abort != nil
 	})
 }
 
@@ -250,6 +312,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		foundTypes.Find("put")
 	}
 
+	if plan.Delete != "" {
+		foundTypes.Find("delete")
+	}
+
 	if plan.Task != "" {
 		foundTypes.Find("task")
 	}
@@ -272,6 +338,16 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 
 	errorMessages := []string{}
 
+	if plan.Aggregate == nil {
+		if plan.Limit != 0 {
+			errorMessages = append(errorMessages, identifier+" has a limit, which can only be used with aggregate")
+		}
+
+		if plan.FailFast {
+			errorMessages = append(errorMessages, identifier+" has fail_fast, which can only be used with aggregate")
+		}
+	}
+
 	switch {
 	case plan.Do != nil:
 		for i, plan := range *plan.Do {
@@ -280,6 +356,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		}
 
 	case plan.Aggregate != nil:
+		if plan.Limit < 0 {
+			errorMessages = append(errorMessages, identifier+".aggregate has a negative limit")
+		}
+
 		for i, plan := range *plan.Aggregate {
 			subIdentifier := fmt.Sprintf("%s.aggregate[%d]", identifier, i)
 			errorMessages = append(errorMessages, validatePlan(c, subIdentifier, plan)...)
@@ -289,7 +369,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		subIdentifier := fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "input_mapping", "output_mapping", "no_get"},
 			plan, subIdentifier)...,
 		)
 
@@ -318,6 +398,21 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 			}
 		}
 
+		switch plan.Version {
+		case "", atc.VersionLatest, atc.VersionEvery:
+		default:
+			if _, pinned := plan.Version.Pinned(); !pinned {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s has an invalid version ('%s'); must be 'every', 'latest', or a version",
+						subIdentifier,
+						plan.Version,
+					),
+				)
+			}
+		}
+
 		for _, job := range plan.Passed {
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
@@ -361,10 +456,14 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		subIdentifier := fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "version", "privileged", "config", "file", "input_mapping", "output_mapping"},
 			plan, subIdentifier)...,
 		)
 
+		if plan.NoGet && len(plan.GetParams) != 0 {
+			errorMessages = append(errorMessages, subIdentifier+" specifies get_params, but no_get is set")
+		}
+
 		if plan.Resource != "" {
 			_, found := c.Resources.Lookup(plan.Resource)
 			if !found {
@@ -390,6 +489,31 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 			}
 		}
 
+	case plan.Delete != "":
+		subIdentifier := fmt.Sprintf("%s.delete.%s", identifier, plan.Delete)
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"passed", "trigger", "version", "get_params", "no_get", "privileged", "config", "file", "input_mapping", "output_mapping"},
+			plan, subIdentifier)...,
+		)
+
+		resourceName := plan.Delete
+		if plan.Resource != "" {
+			resourceName = plan.Resource
+		}
+
+		_, found := c.Resources.Lookup(resourceName)
+		if !found {
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf(
+					"%s refers to a resource that does not exist ('%s')",
+					subIdentifier,
+					resourceName,
+				),
+			)
+		}
+
 	case plan.Task != "":
 		subIdentifier := fmt.Sprintf("%s.task.%s", identifier, plan.Task)
 
@@ -398,7 +522,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "version", "no_get"},
 			plan, subIdentifier)...,
 		)
 
@@ -426,6 +550,11 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		errorMessages = append(errorMessages, validatePlan(c, subIdentifier, *plan.Failure)...)
 	}
 
+	if plan.Abort != nil {
+		subIdentifier := fmt.Sprintf("%s.abort", identifier)
+		errorMessages = append(errorMessages, validatePlan(c, subIdentifier, *plan.Abort)...)
+	}
+
 	if plan.Timeout != "" {
 		_, err := time.ParseDuration(plan.Timeout)
 		if err != nil {
@@ -434,6 +563,144 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		}
 	}
 
+	if plan.Attempts < 0 {
+		subIdentifier := fmt.Sprintf("%s.attempts", identifier)
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" must be a positive number ('%d')", plan.Attempts))
+	}
+
+	return errorMessages
+}
+
+// artifactScope tracks the artifacts produced by the steps that have run so
+// far in a plan. If a task loads its config from a file, its outputs cannot be
+// known, and so neither can the scope.
+type artifactScope struct {
+	names   map[string]bool
+	unknown bool
+}
+
+func (scope *artifactScope) copy() *artifactScope {
+	names := map[string]bool{}
+	for name := range scope.names {
+		names[name] = true
+	}
+
+	return &artifactScope{
+		names:   names,
+		unknown: scope.unknown,
+	}
+}
+
+func (scope *artifactScope) merge(other *artifactScope) {
+	for name := range other.names {
+		scope.names[name] = true
+	}
+
+	scope.unknown = scope.unknown || other.unknown
+}
+
+func validateInputMappings(identifier string, plan atc.PlanConfig, scope *artifactScope) []string {
+	errorMessages := []string{}
+
+	switch {
+	case plan.Do != nil:
+		for i, plan := range *plan.Do {
+			subIdentifier := fmt.Sprintf("%s[%d]", identifier, i)
+			errorMessages = append(errorMessages, validateInputMappings(subIdentifier, plan, scope)...)
+		}
+
+	case plan.Aggregate != nil:
+		produced := scope.copy()
+
+		for i, plan := range *plan.Aggregate {
+			subIdentifier := fmt.Sprintf("%s.aggregate[%d]", identifier, i)
+
+			// steps within an aggregate run in parallel, so they can only see
+			// what was produced before the aggregate
+			stepScope := scope.copy()
+			errorMessages = append(errorMessages, validateInputMappings(subIdentifier, plan, stepScope)...)
+			produced.merge(stepScope)
+		}
+
+		scope.merge(produced)
+
+	case plan.Get != "":
+		scope.names[plan.Get] = true
+
+	case plan.Put != "":
+		if !plan.NoGet {
+			scope.names[plan.Put] = true
+		}
+
+	case plan.Task != "":
+		subIdentifier := fmt.Sprintf("%s.task.%s", identifier, plan.Task)
+
+		if !scope.unknown {
+			inputNames := make([]string, 0, len(plan.InputMapping))
+			for inputName := range plan.InputMapping {
+				inputNames = append(inputNames, inputName)
+			}
+
+			sort.Strings(inputNames)
+
+			for _, inputName := range inputNames {
+				sourceName := plan.InputMapping[inputName]
+				if !scope.names[sourceName] {
+					errorMessages = append(
+						errorMessages,
+						fmt.Sprintf(
+							"%s.input_mapping.%s refers to an artifact that is not produced by an earlier step ('%s')",
+							subIdentifier,
+							inputName,
+							sourceName,
+						),
+					)
+				}
+			}
+		}
+
+		scope.names[plan.Task] = true
+
+		if plan.TaskConfig != nil {
+			for _, output := range plan.TaskConfig.Outputs {
+				outputName := output.Name
+				if mappedName, found := plan.OutputMapping[output.Name]; found {
+					outputName = mappedName
+				}
+
+				scope.names[outputName] = true
+			}
+		}
+
+		if plan.TaskConfigPath != "" {
+			scope.unknown = true
+		}
+
+	case plan.Try != nil:
+		subIdentifier := fmt.Sprintf("%s.try", identifier)
+		errorMessages = append(errorMessages, validateInputMappings(subIdentifier, *plan.Try, scope)...)
+	}
+
+	if plan.Ensure != nil {
+		subIdentifier := fmt.Sprintf("%s.ensure", identifier)
+		errorMessages = append(errorMessages, validateInputMappings(subIdentifier, *plan.Ensure, scope)...)
+	}
+
+	if plan.Success != nil {
+		subIdentifier := fmt.Sprintf("%s.success", identifier)
+		errorMessages = append(errorMessages, validateInputMappings(subIdentifier, *plan.Success, scope)...)
+	}
+
+	if plan.Failure != nil {
+		subIdentifier := fmt.Sprintf("%s.failure", identifier)
+		errorMessages = append(errorMessages, validateInputMappings(subIdentifier, *plan.Failure, scope)...)
+	}
+
+	if plan.Abort != nil {
+		subIdentifier := fmt.Sprintf("%s.abort", identifier)
+		errorMessages = append(errorMessages, validateInputMappings(subIdentifier, *plan.Abort, scope)...)
+	}
+
 	return errorMessages
 }
 
@@ -467,6 +734,26 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "version":
+			if plan.Version != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "get_params":
+			if len(plan.GetParams) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "no_get":
+			if plan.NoGet {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "input_mapping":
+			if len(plan.InputMapping) > 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "output_mapping":
+			if len(plan.OutputMapping) > 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
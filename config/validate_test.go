@@ -173,6 +173,56 @@ var _ = Describe("ValidateConfig", func() {
 				))
 			})
 		})
+
+		Context("when a resource has a valid check interval and timeout", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "10m"
+				config.Resources[0].CheckTimeout = "1m30s"
+			})
+
+			It("returns no error", func() {
+				Ω(validateErr).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("when a resource's check interval cannot be parsed", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "nope"
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"resources.some-resource.check_every refers to a duration that could not be parsed ('nope')",
+				))
+			})
+		})
+
+		Context("when a resource's check interval is not positive", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "0s"
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"resources.some-resource.check_every must be a positive duration ('0s')",
+				))
+			})
+		})
+
+		Context("when a resource's check timeout cannot be parsed", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckTimeout = "nope"
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"resources.some-resource.check_timeout refers to a duration that could not be parsed ('nope')",
+				))
+			})
+		})
 	})
 
 	Describe("validating a job", func() {
@@ -334,6 +384,69 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when the job has hooks and its plan contains conditionals", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Conditions:     &atc.Conditions{atc.ConditionFailure},
+						Task:           "some-resource",
+						TaskConfigPath: "task.yml",
+					})
+
+					job.Ensure = &atc.PlanConfig{
+						Task:           "some-cleanup",
+						TaskConfigPath: "task.yml",
+					}
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan has conditions, which cannot be used with ensure",
+					))
+				})
+			})
+
+			Context("when a job hook is invalid", func() {
+				BeforeEach(func() {
+					job.Failure = &atc.PlanConfig{
+						Get: "some-nonexistent-resource",
+					}
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.on_failure.get.some-nonexistent-resource refers to a resource that does not exist",
+					))
+				})
+			})
+
+			Context("when a job hook maps an artifact produced by the plan", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+					})
+
+					job.Abort = &atc.PlanConfig{
+						Task: "some-cleanup",
+						TaskConfig: &atc.TaskConfig{
+							Run: atc.TaskRunConfig{Path: "cleanup"},
+						},
+						InputMapping: map[string]string{"state": "some-resource"},
+					}
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Ω(validateErr).ShouldNot(HaveOccurred())
+				})
+			})
+
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
 					BeforeEach(func() {
@@ -412,6 +525,139 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan has an invalid version", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:     "some-resource",
+						Version: "sometimes",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].get.some-resource has an invalid version ('sometimes'); must be 'every', 'latest', or a version",
+					))
+				})
+			})
+
+			Context("when a get plan has a valid version", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:     "some-resource",
+						Version: atc.VersionEvery,
+					}, atc.PlanConfig{
+						Get:     "some-resource",
+						Version: atc.VersionConfig(`{"ref":"abcdef"}`),
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Ω(validateErr).ShouldNot(HaveOccurred())
+				})
+			})
+
+			Context("when a put plan has a version", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put:     "some-resource",
+						Version: atc.VersionEvery,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].put.some-resource has invalid fields specified (version)",
+					))
+				})
+			})
+
+			Context("when a put plan skips its get but has get params", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put:       "some-resource",
+						NoGet:     true,
+						GetParams: atc.Params{"some": "params"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].put.some-resource specifies get_params, but no_get is set",
+					))
+				})
+			})
+
+			Context("when a get plan has no_get", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:   "some-resource",
+						NoGet: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (no_get)",
+					))
+				})
+			})
+
+			Context("when a step that is not an aggregate has a limit and fails fast", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:      "some-resource",
+						Limit:    2,
+						FailFast: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0] has a limit, which can only be used with aggregate",
+					))
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0] has fail_fast, which can only be used with aggregate",
+					))
+				})
+			})
+
+			Context("when an aggregate has a negative limit", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Aggregate: &atc.PlanSequence{
+							{Get: "some-resource"},
+						},
+						Limit: -1,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].aggregate has a negative limit",
+					))
+				})
+			})
+
 			Context("when a task plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -536,6 +782,42 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a delete plan refers to a resource that does not exist", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Delete: "some-nonexistent-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].delete.some-nonexistent-resource refers to a resource that does not exist ('some-nonexistent-resource')",
+					))
+				})
+			})
+
+			Context("when a delete plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Delete:    "some-resource",
+						Version:   atc.VersionEvery,
+						GetParams: atc.Params{"some": "params"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].delete.some-resource has invalid fields specified (version, get_params)",
+					))
+				})
+			})
+
 			Context("when a get plan has a custom name but refers to a resource that does exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -737,6 +1019,27 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid step within an abort", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+						Abort: &atc.PlanConfig{
+							Put:      "custom-name",
+							Resource: "some-missing-resource",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].abort.put.custom-name refers to a resource that does not exist ('some-missing-resource')",
+					))
+				})
+			})
+
 			Context("when a plan has an invalid step within a success", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -797,6 +1100,24 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has a negative number of attempts in a step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:      "some-resource",
+						Attempts: -1,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].attempts must be a positive number ('-1')",
+					))
+				})
+			})
+
 			Context("when a plan has an invalid step within a try", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -817,6 +1138,129 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task maps an input to an artifact produced by an earlier step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get: "some-resource",
+					}, atc.PlanConfig{
+						Task: "some-build",
+						TaskConfig: &atc.TaskConfig{
+							Outputs: []atc.TaskOutputConfig{
+								{Name: "built-binary"},
+							},
+						},
+						InputMapping:  map[string]string{"source-code": "some-resource"},
+						OutputMapping: map[string]string{"built-binary": "some-binary"},
+					}, atc.PlanConfig{
+						Task: "some-test",
+						TaskConfig: &atc.TaskConfig{
+							Run: atc.TaskRunConfig{Path: "test"},
+						},
+						InputMapping: map[string]string{"binary": "some-binary"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Ω(validateErr).ShouldNot(HaveOccurred())
+				})
+			})
+
+			Context("when a task maps an input to an artifact that is not produced by an earlier step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task: "some-test",
+						TaskConfig: &atc.TaskConfig{
+							Run: atc.TaskRunConfig{Path: "test"},
+						},
+						InputMapping: map[string]string{"source-code": "some-resource"},
+					}, atc.PlanConfig{
+						Get: "some-resource",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].task.some-test.input_mapping.source-code refers to an artifact that is not produced by an earlier step ('some-resource')",
+					))
+				})
+			})
+
+			Context("when a task maps an input to a put that skips its get", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Put:   "some-resource",
+						NoGet: true,
+					}, atc.PlanConfig{
+						Task: "some-test",
+						TaskConfig: &atc.TaskConfig{
+							Run: atc.TaskRunConfig{Path: "test"},
+						},
+						InputMapping: map[string]string{"source-code": "some-resource"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[1].task.some-test.input_mapping.source-code refers to an artifact that is not produced by an earlier step ('some-resource')",
+					))
+				})
+			})
+
+			Context("when a task maps an input to an artifact produced by a parallel step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Aggregate: &atc.PlanSequence{
+							{
+								Get: "some-resource",
+							},
+							{
+								Task: "some-test",
+								TaskConfig: &atc.TaskConfig{
+									Run: atc.TaskRunConfig{Path: "test"},
+								},
+								InputMapping: map[string]string{"source-code": "some-resource"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].aggregate[1].task.some-test.input_mapping.source-code refers to an artifact that is not produced by an earlier step ('some-resource')",
+					))
+				})
+			})
+
+			Context("when a get plan has input or output mappings", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:           "some-resource",
+						InputMapping:  map[string]string{"a": "b"},
+						OutputMapping: map[string]string{"c": "d"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (input_mapping, output_mapping)",
+					))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
@@ -930,6 +1374,50 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job is serial and has a max_in_flight greater than 1", func() {
+				BeforeEach(func() {
+					job.Serial = true
+					job.RawMaxInFlight = 3
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job has serial: true and max_in_flight greater than 1, which conflict",
+					))
+				})
+			})
+
+			Context("when a job has a negative max_in_flight", func() {
+				BeforeEach(func() {
+					job.RawMaxInFlight = -1
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job has a negative max_in_flight",
+					))
+				})
+			})
+
+			Context("when a job has serial groups and a max_in_flight", func() {
+				BeforeEach(func() {
+					job.SerialGroups = []string{"some-group"}
+					job.RawMaxInFlight = 3
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Ω(validateErr).ShouldNot(HaveOccurred())
+				})
+			})
+
 			Context("when a man, a plan, a canal, panama are specified", func() {
 				BeforeEach(func() {
 					job.TaskConfig = &atc.TaskConfig{
@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"encoding/json"
+
 	. "github.com/concourse/atc"
 	"gopkg.in/yaml.v2"
 
@@ -64,6 +66,76 @@ var _ = Describe("Config", func() {
 
 				Ω(jobConfig.GetSerialGroups()).Should(Equal([]string{}))
 			})
+
+			It("returns the job name if only max_in_flight is specified", func() {
+				jobConfig := JobConfig{
+					Name:           "some-job",
+					RawMaxInFlight: 3,
+				}
+
+				Ω(jobConfig.GetSerialGroups()).Should(Equal([]string{"some-job"}))
+			})
+		})
+
+		Describe("MaxInFlight", func() {
+			It("returns 1 if Serial is true", func() {
+				jobConfig := JobConfig{
+					Serial: true,
+				}
+
+				Ω(jobConfig.MaxInFlight()).Should(Equal(1))
+			})
+
+			It("returns the value if max_in_flight is specified", func() {
+				jobConfig := JobConfig{
+					SerialGroups:   []string{"one"},
+					RawMaxInFlight: 3,
+				}
+
+				Ω(jobConfig.MaxInFlight()).Should(Equal(3))
+			})
+
+			It("returns 1 if only SerialGroups are specified", func() {
+				jobConfig := JobConfig{
+					SerialGroups: []string{"one"},
+				}
+
+				Ω(jobConfig.MaxInFlight()).Should(Equal(1))
+			})
+
+			It("returns 0 if the job is unlimited", func() {
+				jobConfig := JobConfig{}
+
+				Ω(jobConfig.MaxInFlight()).Should(Equal(0))
+			})
+		})
+
+		Describe("Inputs and Outputs", func() {
+			It("includes the steps of the job's hooks after those of its plan", func() {
+				jobConfig := JobConfig{
+					Plan: PlanSequence{
+						{Get: "some-input"},
+						{Put: "some-output"},
+					},
+					Failure: &PlanConfig{
+						Put: "some-failure-output",
+					},
+					Ensure: &PlanConfig{
+						Get:      "some-ensure-input",
+						Resource: "some-resource",
+					},
+				}
+
+				Ω(jobConfig.Inputs()).Should(Equal([]JobInput{
+					{Name: "some-input", Resource: "some-input"},
+					{Name: "some-ensure-input", Resource: "some-resource"},
+				}))
+
+				Ω(jobConfig.Outputs()).Should(Equal([]JobOutput{
+					{Name: "some-output", Resource: "some-output"},
+					{Name: "some-failure-output", Resource: "some-failure-output"},
+				}))
+			})
 		})
 	})
 
@@ -117,4 +189,43 @@ var _ = Describe("Config", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Describe("VersionConfig", func() {
+		It("can be unmarshalled from YAML as the string 'every'", func() {
+			var version VersionConfig
+			err := yaml.Unmarshal([]byte("every"), &version)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(version.Every()).To(BeTrue())
+		})
+
+		It("can be unmarshalled from YAML as a specific version", func() {
+			var version VersionConfig
+			err := yaml.Unmarshal([]byte("{ref: abcdef, branch: master}"), &version)
+			Expect(err).ToNot(HaveOccurred())
+
+			pinnedVersion, pinned := version.Pinned()
+			Expect(pinned).To(BeTrue())
+			Expect(pinnedVersion).To(Equal(Version{"ref": "abcdef", "branch": "master"}))
+		})
+
+		It("can be round-tripped through JSON as a specific version", func() {
+			var version VersionConfig
+			err := json.Unmarshal([]byte(`{"ref":"abcdef"}`), &version)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload, err := json.Marshal(version)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(payload).To(MatchJSON(`{"ref":"abcdef"}`))
+		})
+
+		It("is not pinned when it is 'every' or 'latest'", func() {
+			_, pinned := VersionEvery.Pinned()
+			Expect(pinned).To(BeFalse())
+
+			_, pinned = VersionLatest.Pinned()
+			Expect(pinned).To(BeFalse())
+		})
+	})
 })
@@ -0,0 +1,256 @@
+package credentials
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/template"
+)
+
+//go:generate counterfeiter . CredentialManager
+
+// A CredentialManager looks up the value of a ((name)) reference. Lookups are
+// scoped by pipeline, so that two pipelines may have different values for the
+// same name.
+type CredentialManager interface {
+	Get(pipelineName string, name string) (interface{}, bool, error)
+}
+
+type UndefinedCredentialError struct {
+	PipelineName string
+	Name         string
+}
+
+func (err UndefinedCredentialError) Error() string {
+	return fmt.Sprintf("undefined credential '%s' for pipeline '%s'", err.Name, err.PipelineName)
+}
+
+var referenceRegexp = regexp.MustCompile(`\(\(([-\w\p{L}./]+)\)\)`)
+
+// An Evaluator resolves ((name)) references in the sources and params of a
+// single pipeline. If no Manager is configured, values are returned as-is.
+//
+// The values of every credential it resolves are remembered, so that they can
+// be redacted from build output.
+type Evaluator struct {
+	Manager      CredentialManager
+	PipelineName string
+
+	resolved []string
+}
+
+func (evaluator *Evaluator) Source(source atc.Source) (atc.Source, error) {
+	if evaluator.Manager == nil || source == nil {
+		return source, nil
+	}
+
+	evaluated, err := evaluator.evaluateMap(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(evaluated), nil
+}
+
+func (evaluator *Evaluator) Params(params atc.Params) (atc.Params, error) {
+	if evaluator.Manager == nil || params == nil {
+		return params, nil
+	}
+
+	evaluated, err := evaluator.evaluateMap(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(evaluated), nil
+}
+
+func (evaluator *Evaluator) TaskParams(params map[string]string) (map[string]string, error) {
+	if evaluator.Manager == nil || params == nil {
+		return params, nil
+	}
+
+	evaluated := make(map[string]string, len(params))
+
+	for key, val := range params {
+		evaluatedVal, err := evaluator.evaluateString(val)
+		if err != nil {
+			return nil, err
+		}
+
+		str, err := template.FormatValue(evaluatedVal)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[key] = str
+	}
+
+	return evaluated, nil
+}
+
+// Resolved returns the values of every credential resolved by the evaluator
+// so far, so that they can be redacted from build output.
+func (evaluator *Evaluator) Resolved() []string {
+	resolved := []string{}
+	seen := map[string]bool{}
+
+	for _, value := range evaluator.resolved {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			resolved = append(resolved, value)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	sort.Strings(resolved)
+
+	return resolved
+}
+
+func (evaluator *Evaluator) evaluateMap(m map[string]interface{}) (map[string]interface{}, error) {
+	evaluated := make(map[string]interface{}, len(m))
+
+	for key, val := range m {
+		evaluatedVal, err := evaluator.evaluate(val)
+		if err != nil {
+			return nil, err
+		}
+
+		evaluated[key] = evaluatedVal
+	}
+
+	return evaluated, nil
+}
+
+func (evaluator *Evaluator) evaluate(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return evaluator.evaluateMap(v)
+
+	case map[interface{}]interface{}:
+		evaluated := make(map[interface{}]interface{}, len(v))
+
+		for key, sub := range v {
+			evaluatedSub, err := evaluator.evaluate(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = evaluatedSub
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := make([]interface{}, len(v))
+
+		for i, sub := range v {
+			evaluatedSub, err := evaluator.evaluate(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[i] = evaluatedSub
+		}
+
+		return evaluated, nil
+
+	case string:
+		return evaluator.evaluateString(v)
+
+	default:
+		return val, nil
+	}
+}
+
+func (evaluator *Evaluator) evaluateString(str string) (interface{}, error) {
+	if match := referenceRegexp.FindStringSubmatch(str); match != nil && match[0] == str {
+		return evaluator.lookup(match[1])
+	}
+
+	var lookupErr error
+
+	evaluated := referenceRegexp.ReplaceAllStringFunc(str, func(reference string) string {
+		if lookupErr != nil {
+			return reference
+		}
+
+		value, err := evaluator.lookup(referenceRegexp.FindStringSubmatch(reference)[1])
+		if err != nil {
+			lookupErr = err
+			return reference
+		}
+
+		formatted, err := template.FormatValue(value)
+		if err != nil {
+			lookupErr = err
+			return reference
+		}
+
+		return formatted
+	})
+
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+
+	return evaluated, nil
+}
+
+func (evaluator *Evaluator) lookup(name string) (interface{}, error) {
+	value, found, err := evaluator.Manager.Get(evaluator.PipelineName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, UndefinedCredentialError{
+			PipelineName: evaluator.PipelineName,
+			Name:         name,
+		}
+	}
+
+	evaluator.resolved = append(evaluator.resolved, leafStrings(value)...)
+
+	return value, nil
+}
+
+func leafStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+
+	case map[string]interface{}:
+		strs := []string{}
+		for _, sub := range v {
+			strs = append(strs, leafStrings(sub)...)
+		}
+
+		return strs
+
+	case map[interface{}]interface{}:
+		strs := []string{}
+		for _, sub := range v {
+			strs = append(strs, leafStrings(sub)...)
+		}
+
+		return strs
+
+	case []interface{}:
+		strs := []string{}
+		for _, sub := range v {
+			strs = append(strs, leafStrings(sub)...)
+		}
+
+		return strs
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package credentials_test
+
+import (
+	"errors"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/credentials"
+	"github.com/concourse/atc/credentials/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evaluator", func() {
+	var (
+		fakeCredentialManager *fakes.FakeCredentialManager
+
+		evaluator Evaluator
+	)
+
+	BeforeEach(func() {
+		fakeCredentialManager = new(fakes.FakeCredentialManager)
+		fakeCredentialManager.GetStub = func(pipelineName string, name string) (interface{}, bool, error) {
+			creds := map[string]interface{}{
+				"some-pipeline/username": "some-user",
+				"some-pipeline/port":     5432,
+				"some-pipeline/keys": map[string]interface{}{
+					"public": "some-public-key",
+				},
+				"other-pipeline/username": "other-user",
+			}
+
+			value, found := creds[pipelineName+"/"+name]
+			return value, found, nil
+		}
+
+		evaluator = Evaluator{
+			Manager:      fakeCredentialManager,
+			PipelineName: "some-pipeline",
+		}
+	})
+
+	Describe("Source", func() {
+		It("replaces references with their values, preserving their type", func() {
+			source, err := evaluator.Source(atc.Source{
+				"username": "((username))",
+				"port":     "((port))",
+				"nested": map[string]interface{}{
+					"keys": []interface{}{"((keys))"},
+				},
+				"uri": "postgres://((username))@db:((port))",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(source).Should(Equal(atc.Source{
+				"username": "some-user",
+				"port":     5432,
+				"nested": map[string]interface{}{
+					"keys": []interface{}{
+						map[string]interface{}{"public": "some-public-key"},
+					},
+				},
+				"uri": "postgres://some-user@db:5432",
+			}))
+		})
+
+		It("does not modify the given source", func() {
+			given := atc.Source{"username": "((username))"}
+
+			_, err := evaluator.Source(given)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(given).Should(Equal(atc.Source{"username": "((username))"}))
+		})
+
+		It("looks up references within the pipeline", func() {
+			evaluator.PipelineName = "other-pipeline"
+
+			source, err := evaluator.Source(atc.Source{"username": "((username))"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(source).Should(Equal(atc.Source{"username": "other-user"}))
+		})
+
+		Context("when a reference is not defined", func() {
+			It("returns an error", func() {
+				_, err := evaluator.Source(atc.Source{"password": "((password))"})
+				Ω(err).Should(Equal(UndefinedCredentialError{
+					PipelineName: "some-pipeline",
+					Name:         "password",
+				}))
+			})
+		})
+
+		Context("when the lookup fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeCredentialManager.GetReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				_, err := evaluator.Source(atc.Source{"password": "((password))"})
+				Ω(err).Should(Equal(disaster))
+			})
+		})
+
+		Context("when no manager is configured", func() {
+			BeforeEach(func() {
+				evaluator.Manager = nil
+			})
+
+			It("returns the source as-is", func() {
+				source, err := evaluator.Source(atc.Source{"username": "((username))"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(source).Should(Equal(atc.Source{"username": "((username))"}))
+			})
+		})
+	})
+
+	Describe("TaskParams", func() {
+		It("formats values as strings", func() {
+			params, err := evaluator.TaskParams(map[string]string{
+				"USERNAME": "((username))",
+				"PORT":     "((port))",
+				"KEYS":     "((keys))",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(params).Should(Equal(map[string]string{
+				"USERNAME": "some-user",
+				"PORT":     "5432",
+				"KEYS":     `{"public":"some-public-key"}`,
+			}))
+		})
+	})
+
+	Describe("Resolved", func() {
+		It("returns the values of every credential resolved so far", func() {
+			_, err := evaluator.Source(atc.Source{
+				"username": "((username))",
+				"keys":     "((keys))",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = evaluator.Params(atc.Params{"user": "((username))"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(evaluator.Resolved()).Should(Equal([]string{"some-public-key", "some-user"}))
+		})
+
+		Context("when nothing has been resolved", func() {
+			It("returns nil", func() {
+				Ω(evaluator.Resolved()).Should(BeNil())
+			})
+		})
+	})
+})
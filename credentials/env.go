@@ -0,0 +1,66 @@
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var invalidEnvChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// EnvCredentialManager reads credentials from environment variables named
+// PREFIX_PIPELINE__NAME, e.g. CONCOURSE_MAIN__S3_ACCESS_KEY for
+// ((s3-access-key)) in the 'main' pipeline. Characters not valid in a variable
+// name are replaced with underscores.
+//
+// The pipeline and the name are separated by two underscores, so that e.g.
+// ((db-pass)) in 'prod' and ((pass)) in 'prod-db' are kept apart. Pipelines
+// whose names would make this ambiguous cannot use the environment.
+type EnvCredentialManager struct {
+	Prefix string
+}
+
+func NewEnvCredentialManager(prefix string) EnvCredentialManager {
+	return EnvCredentialManager{Prefix: prefix}
+}
+
+type AmbiguousPipelineNameError struct {
+	PipelineName string
+}
+
+func (err AmbiguousPipelineNameError) Error() string {
+	return fmt.Sprintf("pipeline name '%s' cannot be used unambiguously in environment variable names", err.PipelineName)
+}
+
+func (manager EnvCredentialManager) Get(pipelineName string, name string) (interface{}, bool, error) {
+	varName, err := manager.VarName(pipelineName, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, found := os.LookupEnv(varName)
+	if !found {
+		return nil, false, nil
+	}
+
+	return value, true, nil
+}
+
+func (manager EnvCredentialManager) VarName(pipelineName string, name string) (string, error) {
+	pipelineSegment := envSegment(pipelineName)
+	if strings.Contains(pipelineSegment, "__") || strings.HasSuffix(pipelineSegment, "_") {
+		return "", AmbiguousPipelineNameError{PipelineName: pipelineName}
+	}
+
+	varName := pipelineSegment + "__" + envSegment(name)
+	if manager.Prefix != "" {
+		varName = envSegment(manager.Prefix) + "_" + varName
+	}
+
+	return varName, nil
+}
+
+func envSegment(str string) string {
+	return strings.ToUpper(invalidEnvChars.ReplaceAllString(str, "_"))
+}
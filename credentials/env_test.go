@@ -0,0 +1,58 @@
+package credentials_test
+
+import (
+	"os"
+
+	. "github.com/concourse/atc/credentials"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvCredentialManager", func() {
+	var manager EnvCredentialManager
+
+	BeforeEach(func() {
+		manager = NewEnvCredentialManager("concourse")
+
+		os.Setenv("CONCOURSE_SOME_PIPELINE__S3_ACCESS_KEY", "some-access-key")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("CONCOURSE_SOME_PIPELINE__S3_ACCESS_KEY")
+	})
+
+	It("looks up credentials in the environment, scoped by pipeline", func() {
+		value, found, err := manager.Get("some-pipeline", "s3-access-key")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(found).Should(BeTrue())
+		Ω(value).Should(Equal("some-access-key"))
+	})
+
+	Context("when the variable is not set", func() {
+		It("is not found", func() {
+			_, found, err := manager.Get("other-pipeline", "s3-access-key")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+		})
+	})
+
+	Describe("VarName", func() {
+		It("keeps names that would otherwise run together apart", func() {
+			Ω(manager.VarName("prod", "db_pass")).Should(Equal("CONCOURSE_PROD__DB_PASS"))
+			Ω(manager.VarName("prod_db", "pass")).Should(Equal("CONCOURSE_PROD_DB__PASS"))
+		})
+
+		Context("when the pipeline name would be ambiguous", func() {
+			It("returns an error", func() {
+				for _, pipelineName := range []string{"prod__db", "prod-_db", "prod-"} {
+					_, err := manager.VarName(pipelineName, "pass")
+					Ω(err).Should(Equal(AmbiguousPipelineNameError{PipelineName: pipelineName}))
+
+					_, _, err = manager.Get(pipelineName, "pass")
+					Ω(err).Should(Equal(AmbiguousPipelineNameError{PipelineName: pipelineName}))
+				}
+			})
+		})
+	})
+})
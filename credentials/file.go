@@ -0,0 +1,78 @@
+package credentials
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// FileCredentialManager reads credentials from a directory containing one
+// YAML file per pipeline, e.g. main.yml. The file is read on every lookup so
+// that credentials can be rotated without restarting the ATC.
+type FileCredentialManager struct {
+	Dir string
+}
+
+func NewFileCredentialManager(dir string) FileCredentialManager {
+	return FileCredentialManager{Dir: dir}
+}
+
+func (manager FileCredentialManager) Get(pipelineName string, name string) (interface{}, bool, error) {
+	path := filepath.Join(manager.Dir, pipelineName+".yml")
+
+	// a name with path separators in it could read a file outside of the
+	// directory
+	if filepath.Dir(path) != filepath.Clean(manager.Dir) {
+		return nil, false, fmt.Errorf("invalid pipeline name for credentials: '%s'", pipelineName)
+	}
+
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	var creds map[string]interface{}
+	err = yaml.Unmarshal(payload, &creds)
+	if err != nil {
+		return nil, false, fmt.Errorf("malformed credentials for pipeline '%s': %s", pipelineName, err)
+	}
+
+	value, found := creds[name]
+	if !found {
+		return nil, false, nil
+	}
+
+	return sanitize(value), true, nil
+}
+
+func sanitize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		sanitized := make(map[string]interface{}, len(v))
+
+		for key, sub := range v {
+			sanitized[fmt.Sprintf("%v", key)] = sanitize(sub)
+		}
+
+		return sanitized
+
+	case []interface{}:
+		sanitized := make([]interface{}, len(v))
+
+		for i, sub := range v {
+			sanitized[i] = sanitize(sub)
+		}
+
+		return sanitized
+
+	default:
+		return value
+	}
+}
@@ -0,0 +1,98 @@
+package credentials_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/concourse/atc/credentials"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileCredentialManager", func() {
+	var (
+		dir string
+
+		manager FileCredentialManager
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "credentials")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(dir, "some-pipeline.yml"), []byte(`---
+username: some-user
+keys:
+  public: some-public-key
+`), 0644)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		manager = NewFileCredentialManager(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("looks up credentials in the pipeline's file", func() {
+		value, found, err := manager.Get("some-pipeline", "username")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(found).Should(BeTrue())
+		Ω(value).Should(Equal("some-user"))
+	})
+
+	It("returns nested values with string keys", func() {
+		value, found, err := manager.Get("some-pipeline", "keys")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(found).Should(BeTrue())
+		Ω(value).Should(Equal(map[string]interface{}{"public": "some-public-key"}))
+	})
+
+	Context("when the credential is not in the file", func() {
+		It("is not found", func() {
+			_, found, err := manager.Get("some-pipeline", "password")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+		})
+	})
+
+	Context("when the pipeline has no file", func() {
+		It("is not found", func() {
+			_, found, err := manager.Get("other-pipeline", "username")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+		})
+	})
+
+	Context("when the file is malformed", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(filepath.Join(dir, "some-pipeline.yml"), []byte("- not a map"), 0644)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-pipeline", "username")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when the pipeline name would lead outside of the directory", func() {
+		BeforeEach(func() {
+			err := os.Mkdir(filepath.Join(dir, "nested"), 0755)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			manager = NewFileCredentialManager(filepath.Join(dir, "nested"))
+		})
+
+		It("returns an error rather than reading the file", func() {
+			for _, pipelineName := range []string{"../some-pipeline", "nested/../../some-pipeline"} {
+				_, found, err := manager.Get(pipelineName, "username")
+				Ω(err).Should(HaveOccurred())
+				Ω(found).Should(BeFalse())
+			}
+		})
+	})
+})
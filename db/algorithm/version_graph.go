@@ -0,0 +1,430 @@
+package algorithm
+
+import "sort"
+
+// Version is a version of one of a pipeline's resources.
+type Version struct {
+	ID         int
+	ResourceID int
+
+	// Version is the version as JSON, as it is stored in the database.
+	Version string
+
+	// Usable is false if the version has been disabled or deleted.
+	Usable bool
+}
+
+// Build is a build of one of a pipeline's jobs, along with the versions it
+// used and produced.
+type Build struct {
+	ID        int
+	JobID     int
+	Succeeded bool
+
+	Inputs  []Input
+	Outputs []int
+}
+
+// Input is a version that a build used as one of its inputs.
+type Input struct {
+	Name      string
+	VersionID int
+}
+
+// BuildSet is a set of build IDs.
+type BuildSet map[int]struct{}
+
+func (set BuildSet) Intersect(other BuildSet) BuildSet {
+	smaller, larger := set, other
+	if len(larger) < len(smaller) {
+		smaller, larger = larger, smaller
+	}
+
+	intersection := BuildSet{}
+	for id := range smaller {
+		if _, found := larger[id]; found {
+			intersection[id] = struct{}{}
+		}
+	}
+
+	return intersection
+}
+
+// VersionGraph is an in-memory copy of a pipeline's versions and builds,
+// indexed by the versions each job's successful builds produced so that
+// inputs with passed constraints can be resolved without going to the
+// database.
+//
+// It is not safe for concurrent use.
+type VersionGraph struct {
+	versions         map[int]Version
+	resourceVersions map[int][]int
+
+	builds     map[int]Build
+	jobBuilds  map[int][]int
+	jobOutputs map[int]map[int]BuildSet
+}
+
+func NewVersionGraph() *VersionGraph {
+	return &VersionGraph{
+		versions:         map[int]Version{},
+		resourceVersions: map[int][]int{},
+
+		builds:     map[int]Build{},
+		jobBuilds:  map[int][]int{},
+		jobOutputs: map[int]map[int]BuildSet{},
+	}
+}
+
+// SaveVersion adds the version to the graph, or replaces it if it is already
+// present.
+func (graph *VersionGraph) SaveVersion(version Version) {
+	if _, found := graph.versions[version.ID]; !found {
+		graph.resourceVersions[version.ResourceID] = insertSorted(graph.resourceVersions[version.ResourceID], version.ID)
+	}
+
+	graph.versions[version.ID] = version
+}
+
+// SaveBuild adds the build to the graph, or replaces it if it is already
+// present. Its outputs only satisfy passed constraints once it has
+// succeeded.
+func (graph *VersionGraph) SaveBuild(build Build) {
+	if existing, found := graph.builds[build.ID]; found {
+		graph.removeOutputs(existing)
+	} else {
+		graph.jobBuilds[build.JobID] = insertSorted(graph.jobBuilds[build.JobID], build.ID)
+	}
+
+	graph.builds[build.ID] = build
+
+	if !build.Succeeded {
+		return
+	}
+
+	outputs, found := graph.jobOutputs[build.JobID]
+	if !found {
+		outputs = map[int]BuildSet{}
+		graph.jobOutputs[build.JobID] = outputs
+	}
+
+	for _, versionID := range build.Outputs {
+		builds, found := outputs[versionID]
+		if !found {
+			builds = BuildSet{}
+			outputs[versionID] = builds
+		}
+
+		builds[build.ID] = struct{}{}
+	}
+}
+
+func (graph *VersionGraph) removeOutputs(build Build) {
+	outputs := graph.jobOutputs[build.JobID]
+
+	for _, versionID := range build.Outputs {
+		delete(outputs[versionID], build.ID)
+
+		if len(outputs[versionID]) == 0 {
+			delete(outputs, versionID)
+		}
+	}
+}
+
+// InputConfig describes one of a job's inputs in terms of the graph.
+type InputConfig struct {
+	Name       string
+	ResourceID int
+
+	// Passed are the IDs of the jobs whose successful builds must have
+	// produced the version.
+	Passed []int
+
+	// PinnedVersion is the version JSON that the input is pinned to, if any.
+	PinnedVersion string
+
+	// PinnedVersionID is the ID of the version the resource is pinned to, if
+	// any.
+	PinnedVersionID int
+
+	// Every is true if the input should use every version in order rather
+	// than skipping to the latest.
+	Every bool
+}
+
+// Resolve determines the IDs of the versions to use for each of the job's
+// inputs, or returns false if there is no combination of versions that
+// satisfies them.
+//
+// Inputs whose passed constraints name the same job must all use versions
+// produced by a single build of that job. Otherwise each input uses its
+// latest usable version that is no older than the one used by the job's
+// latest build, or if it uses every version, the oldest one newer than any
+// the job has used.
+func (graph *VersionGraph) Resolve(jobID int, inputs []InputConfig) ([]int, bool) {
+	previous := graph.previousInputs(jobID, inputs)
+
+	candidates := make([][]int, len(inputs))
+	for i, input := range inputs {
+		candidates[i] = graph.candidates(input, previous[i])
+		if len(candidates[i]) == 0 {
+			return nil, false
+		}
+	}
+
+	r := resolver{
+		graph:      graph,
+		inputs:     inputs,
+		candidates: candidates,
+		chosen:     make([]int, len(inputs)),
+	}
+
+	// inputs that share no passed jobs can't constrain each other, so each
+	// group is resolved on its own rather than backtracking across all of them
+	for _, group := range groupByPassed(inputs) {
+		if !r.resolve(group, map[int]BuildSet{}) {
+			return nil, false
+		}
+	}
+
+	return r.chosen, true
+}
+
+// previousInputs returns the IDs of the versions used for each input by the
+// job's latest build that used all of them, or zeroes if there is no such
+// build.
+//
+// Inputs that use every version instead carry on from the latest version
+// used by any of the job's builds, as a rerun or a manually triggered build
+// may have gone back to an older one.
+func (graph *VersionGraph) previousInputs(jobID int, inputs []InputConfig) []int {
+	builds := graph.jobBuilds[jobID]
+
+	previous := make([]int, len(inputs))
+	for i := len(builds) - 1; i >= 0; i-- {
+		versionIDs, found := graph.buildInputs(graph.builds[builds[i]], inputs)
+		if found {
+			previous = versionIDs
+			break
+		}
+	}
+
+	for i, input := range inputs {
+		if !input.Every {
+			continue
+		}
+
+		for _, buildID := range builds {
+			for _, buildInput := range graph.builds[buildID].Inputs {
+				if buildInput.Name != input.Name {
+					continue
+				}
+
+				version, found := graph.versions[buildInput.VersionID]
+				if found && version.ResourceID == input.ResourceID && version.ID > previous[i] {
+					previous[i] = version.ID
+				}
+			}
+		}
+	}
+
+	return previous
+}
+
+func (graph *VersionGraph) buildInputs(build Build, inputs []InputConfig) ([]int, bool) {
+	versionIDs := make([]int, len(inputs))
+
+	for i, input := range inputs {
+		for _, buildInput := range build.Inputs {
+			if buildInput.Name != input.Name {
+				continue
+			}
+
+			version, found := graph.versions[buildInput.VersionID]
+			if !found || version.ResourceID != input.ResourceID {
+				continue
+			}
+
+			if versionIDs[i] == 0 || version.ID < versionIDs[i] {
+				versionIDs[i] = version.ID
+			}
+		}
+
+		if versionIDs[i] == 0 {
+			return nil, false
+		}
+	}
+
+	return versionIDs, true
+}
+
+// candidates returns the IDs of the versions that could be used for the
+// input, most preferred first.
+func (graph *VersionGraph) candidates(input InputConfig, previousID int) []int {
+	previousUsable := previousID != 0 && graph.versions[previousID].Usable
+
+	var newer, older []int
+
+	resourceVersions := graph.resourceVersions[input.ResourceID]
+	for i := len(resourceVersions) - 1; i >= 0; i-- {
+		version := graph.versions[resourceVersions[i]]
+		if !version.Usable {
+			continue
+		}
+
+		if input.PinnedVersion != "" {
+			if version.Version != input.PinnedVersion {
+				continue
+			}
+		} else if input.PinnedVersionID != 0 {
+			if version.ID != input.PinnedVersionID {
+				continue
+			}
+		} else if previousUsable && version.ID < previousID {
+			continue
+		}
+
+		if !graph.producedByAll(input.Passed, version.ID) {
+			continue
+		}
+
+		if input.Every && version.ID > previousID {
+			newer = append(newer, version.ID)
+		} else {
+			older = append(older, version.ID)
+		}
+	}
+
+	// newer versions are collected latest first, but every version is used
+	// oldest first
+	for i, j := 0, len(newer)-1; i < j; i, j = i+1, j-1 {
+		newer[i], newer[j] = newer[j], newer[i]
+	}
+
+	return append(newer, older...)
+}
+
+func (graph *VersionGraph) producedByAll(jobIDs []int, versionID int) bool {
+	for _, jobID := range jobIDs {
+		if len(graph.jobOutputs[jobID][versionID]) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+type resolver struct {
+	graph      *VersionGraph
+	inputs     []InputConfig
+	candidates [][]int
+	chosen     []int
+}
+
+// resolve chooses a version for each of the inputs, backtracking when a
+// choice leaves no build of a passed job that produced all of the versions
+// chosen from it. builds holds the builds of each passed job that are still
+// possible given the choices made so far.
+func (r *resolver) resolve(inputs []int, builds map[int]BuildSet) bool {
+	if len(inputs) == 0 {
+		return true
+	}
+
+	i := inputs[0]
+
+	for _, versionID := range r.candidates[i] {
+		narrowed, ok := r.narrow(builds, r.inputs[i].Passed, versionID)
+		if !ok {
+			continue
+		}
+
+		if r.resolve(inputs[1:], narrowed) {
+			r.chosen[i] = versionID
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *resolver) narrow(builds map[int]BuildSet, passed []int, versionID int) (map[int]BuildSet, bool) {
+	narrowed := make(map[int]BuildSet, len(builds)+len(passed))
+	for jobID, set := range builds {
+		narrowed[jobID] = set
+	}
+
+	for _, jobID := range passed {
+		outputs := r.graph.jobOutputs[jobID][versionID]
+
+		if set, constrained := narrowed[jobID]; constrained {
+			outputs = set.Intersect(outputs)
+		}
+
+		if len(outputs) == 0 {
+			return nil, false
+		}
+
+		narrowed[jobID] = outputs
+	}
+
+	return narrowed, true
+}
+
+// groupByPassed partitions the inputs' indices into groups whose inputs are
+// connected by the passed jobs they share, keeping them in order.
+func groupByPassed(inputs []InputConfig) [][]int {
+	group := make([]int, len(inputs))
+	for i := range group {
+		group[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if group[i] != i {
+			group[i] = find(group[i])
+		}
+
+		return group[i]
+	}
+
+	jobInputs := map[int]int{}
+	for i, input := range inputs {
+		for _, jobID := range input.Passed {
+			if j, found := jobInputs[jobID]; found {
+				group[find(i)] = find(j)
+			} else {
+				jobInputs[jobID] = i
+			}
+		}
+	}
+
+	groups := [][]int{}
+	groupIndices := map[int]int{}
+	for i := range inputs {
+		root := find(i)
+
+		index, found := groupIndices[root]
+		if !found {
+			index = len(groups)
+			groupIndices[root] = index
+			groups = append(groups, nil)
+		}
+
+		groups[index] = append(groups[index], i)
+	}
+
+	return groups
+}
+
+func insertSorted(ids []int, id int) []int {
+	i := sort.SearchInts(ids, id)
+	if i < len(ids) && ids[i] == id {
+		return ids
+	}
+
+	ids = append(ids, 0)
+	copy(ids[i+1:], ids[i:])
+	ids[i] = id
+
+	return ids
+}
@@ -0,0 +1,224 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionGraph", func() {
+	const (
+		resourceA = 1
+		resourceB = 2
+
+		jobID       = 10
+		upstreamJob = 11
+		otherJob    = 12
+	)
+
+	var graph *algorithm.VersionGraph
+
+	BeforeEach(func() {
+		graph = algorithm.NewVersionGraph()
+
+		for id := 1; id <= 3; id++ {
+			graph.SaveVersion(algorithm.Version{ID: id, ResourceID: resourceA, Version: "{}", Usable: true})
+		}
+
+		for id := 4; id <= 6; id++ {
+			graph.SaveVersion(algorithm.Version{ID: id, ResourceID: resourceB, Version: "{}", Usable: true})
+		}
+	})
+
+	Describe("Resolve", func() {
+		resolve := func(inputs []algorithm.InputConfig) []int {
+			versionIDs, found := graph.Resolve(jobID, inputs)
+			Ω(found).Should(BeTrue())
+			return versionIDs
+		}
+
+		It("chooses the latest version of each input", func() {
+			Ω(resolve([]algorithm.InputConfig{
+				{Name: "a", ResourceID: resourceA},
+				{Name: "b", ResourceID: resourceB},
+			})).Should(Equal([]int{3, 6}))
+		})
+
+		It("skips versions that are not usable", func() {
+			graph.SaveVersion(algorithm.Version{ID: 3, ResourceID: resourceA, Version: "{}", Usable: false})
+
+			Ω(resolve([]algorithm.InputConfig{
+				{Name: "a", ResourceID: resourceA},
+			})).Should(Equal([]int{2}))
+		})
+
+		It("returns false when a resource has no versions", func() {
+			_, found := graph.Resolve(jobID, []algorithm.InputConfig{
+				{Name: "a", ResourceID: 99},
+			})
+			Ω(found).Should(BeFalse())
+		})
+
+		Context("when the input is pinned to a version", func() {
+			BeforeEach(func() {
+				graph.SaveVersion(algorithm.Version{ID: 2, ResourceID: resourceA, Version: `{"ref":"abc"}`, Usable: true})
+			})
+
+			It("chooses that version", func() {
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, PinnedVersion: `{"ref":"abc"}`},
+				})).Should(Equal([]int{2}))
+			})
+		})
+
+		Context("when the resource is pinned to a version", func() {
+			It("chooses that version", func() {
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, PinnedVersionID: 1},
+				})).Should(Equal([]int{1}))
+			})
+		})
+
+		Context("when the job has used versions before", func() {
+			BeforeEach(func() {
+				graph.SaveBuild(algorithm.Build{
+					ID:     100,
+					JobID:  jobID,
+					Inputs: []algorithm.Input{{Name: "a", VersionID: 2}},
+				})
+
+				graph.SaveVersion(algorithm.Version{ID: 3, ResourceID: resourceA, Version: "{}", Usable: false})
+			})
+
+			It("does not go back to older versions", func() {
+				graph.SaveVersion(algorithm.Version{ID: 2, ResourceID: resourceA, Version: "{}", Usable: true})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA},
+				})).Should(Equal([]int{2}))
+			})
+
+			It("goes back to older versions if the previous one is no longer usable", func() {
+				graph.SaveVersion(algorithm.Version{ID: 2, ResourceID: resourceA, Version: "{}", Usable: false})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA},
+				})).Should(Equal([]int{1}))
+			})
+		})
+
+		Context("when the input uses every version", func() {
+			It("chooses the oldest version newer than the previous one", func() {
+				graph.SaveBuild(algorithm.Build{
+					ID:     100,
+					JobID:  jobID,
+					Inputs: []algorithm.Input{{Name: "a", VersionID: 1}},
+				})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Every: true},
+				})).Should(Equal([]int{2}))
+			})
+
+			It("carries on from the latest version used, even if a later build used an older one", func() {
+				graph.SaveBuild(algorithm.Build{
+					ID:     100,
+					JobID:  jobID,
+					Inputs: []algorithm.Input{{Name: "a", VersionID: 2}},
+				})
+
+				// e.g. a rerun of the first build
+				graph.SaveBuild(algorithm.Build{
+					ID:     101,
+					JobID:  jobID,
+					Inputs: []algorithm.Input{{Name: "a", VersionID: 1}},
+				})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Every: true},
+				})).Should(Equal([]int{3}))
+			})
+
+			It("chooses the latest version once they have all been used", func() {
+				graph.SaveBuild(algorithm.Build{
+					ID:     100,
+					JobID:  jobID,
+					Inputs: []algorithm.Input{{Name: "a", VersionID: 3}},
+				})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Every: true},
+				})).Should(Equal([]int{3}))
+			})
+		})
+
+		Context("with passed constraints", func() {
+			It("only chooses versions produced by successful builds of the passed jobs", func() {
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: true, Outputs: []int{2}})
+				graph.SaveBuild(algorithm.Build{ID: 101, JobID: upstreamJob, Succeeded: false, Outputs: []int{3}})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+				})).Should(Equal([]int{2}))
+			})
+
+			It("considers builds once they succeed", func() {
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: false, Outputs: []int{2}})
+
+				_, found := graph.Resolve(jobID, []algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+				})
+				Ω(found).Should(BeFalse())
+
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: true, Outputs: []int{2}})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+				})).Should(Equal([]int{2}))
+			})
+
+			It("chooses versions produced by the same build of a shared passed job", func() {
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: true, Outputs: []int{2, 5}})
+				graph.SaveBuild(algorithm.Build{ID: 101, JobID: upstreamJob, Succeeded: true, Outputs: []int{1, 6}})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+					{Name: "b", ResourceID: resourceB, Passed: []int{upstreamJob}},
+				})).Should(Equal([]int{2, 5}))
+			})
+
+			It("backtracks when the latest version can't be paired", func() {
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: true, Outputs: []int{2, 5}})
+				graph.SaveBuild(algorithm.Build{ID: 101, JobID: upstreamJob, Succeeded: true, Outputs: []int{3}})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+					{Name: "b", ResourceID: resourceB, Passed: []int{upstreamJob}},
+				})).Should(Equal([]int{2, 5}))
+			})
+
+			It("returns false when no combination satisfies them", func() {
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: true, Outputs: []int{2}})
+				graph.SaveBuild(algorithm.Build{ID: 101, JobID: upstreamJob, Succeeded: true, Outputs: []int{5}})
+
+				_, found := graph.Resolve(jobID, []algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+					{Name: "b", ResourceID: resourceB, Passed: []int{upstreamJob}},
+				})
+				Ω(found).Should(BeFalse())
+			})
+
+			It("resolves inputs with different passed jobs independently", func() {
+				graph.SaveBuild(algorithm.Build{ID: 100, JobID: upstreamJob, Succeeded: true, Outputs: []int{2}})
+				graph.SaveBuild(algorithm.Build{ID: 101, JobID: otherJob, Succeeded: true, Outputs: []int{5}})
+
+				Ω(resolve([]algorithm.InputConfig{
+					{Name: "a", ResourceID: resourceA, Passed: []int{upstreamJob}},
+					{Name: "b", ResourceID: resourceB, Passed: []int{otherJob}},
+					{Name: "c", ResourceID: resourceA},
+				})).Should(Equal([]int{2, 5, 3}))
+			})
+		})
+	})
+})
@@ -28,6 +28,13 @@ type Build struct {
 
 	StartTime time.Time
 	EndTime   time.Time
+
+	// set when the build was created by rerunning another build of the job
+	RerunOf int
+
+	// whether the versions of the build's inputs have been chosen; once they
+	// have been, they are never chosen again
+	InputsDetermined bool
 }
 
 func (b Build) OneOff() bool {
@@ -57,6 +64,10 @@ type SavedResource struct {
 	Paused       bool
 	PipelineName string
 	Resource
+
+	// set when the resource has been pinned to a version via the API
+	PinnedVersionID int
+	PinnedVersion   Version
 }
 
 func (r SavedResource) FailingToCheck() bool {
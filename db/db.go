@@ -40,6 +40,7 @@ type DB interface {
 
 	SaveBuildInput(buildID int, input BuildInput) (SavedVersionedResource, error)
 	SaveBuildOutput(buildID int, vr VersionedResource, explicit bool) (SavedVersionedResource, error)
+	MarkVersionedResourceDeleted(vr VersionedResource) error
 
 	GetBuildEvents(buildID int, from uint) (EventSource, error)
 	SaveBuildEvent(buildID int, event atc.Event) error
@@ -51,6 +52,9 @@ type DB interface {
 
 	SaveBuildEngineMetadata(buildID int, engineMetadata string) error
 
+	SaveImageResourceVersion(buildID int, locationID uint, version atc.Version) error
+	GetImageResourceVersion(buildID int, locationID uint) (atc.Version, bool, error)
+
 	AbortBuild(buildID int) error
 	AbortNotifier(buildID int) (Notifier, error)
 
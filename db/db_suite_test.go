@@ -17,6 +17,18 @@ func TestDB(t *testing.T) {
 	RunSpecs(t, "DB Suite")
 }
 
+// TestMain tears down the pipeline set up for the benchmarks, as it is
+// shared by all of them.
+func TestMain(m *testing.M) {
+	status := m.Run()
+
+	if largePipeline != nil {
+		largePipeline.tearDown()
+	}
+
+	os.Exit(status)
+}
+
 var postgresRunner postgresrunner.Runner
 var dbProcess ifrit.Process
 
@@ -3,6 +3,10 @@ package db
 import "errors"
 
 var ErrNoVersions = errors.New("no versions found")
+var ErrNoVersionedResource = errors.New("no versioned resource found")
 var ErrNoBuild = errors.New("no build found")
+var ErrNoSchedulingStatus = errors.New("no scheduling status found")
+var ErrInputsAlreadyDetermined = errors.New("build inputs have already been determined")
+var ErrInputsNotDetermined = errors.New("build inputs have not been determined")
 
 var ErrLockRowNotPresentOrAlreadyDeleted = errors.New("lock could not be acquired because it didn't exist or was already cleaned up")
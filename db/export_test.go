@@ -0,0 +1,12 @@
+package db
+
+// VersionGraphsKept is the number of pipelines whose version graphs the
+// factory is keeping.
+func VersionGraphsKept(factory PipelineDBFactory) int {
+	graphs := factory.(*pipelineDBFactory).versionGraphs
+
+	graphs.lock.Lock()
+	defer graphs.lock.Unlock()
+
+	return len(graphs.graphs)
+}
@@ -112,6 +112,23 @@ type FakePipelineDB struct {
 	unpauseResourceReturns struct {
 		result1 error
 	}
+	PinVersionedResourceStub        func(resourceName string, versionedResourceID int) error
+	pinVersionedResourceMutex       sync.RWMutex
+	pinVersionedResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	pinVersionedResourceReturns struct {
+		result1 error
+	}
+	UnpinResourceStub        func(resourceName string) error
+	unpinResourceMutex       sync.RWMutex
+	unpinResourceArgsForCall []struct {
+		resourceName string
+	}
+	unpinResourceReturns struct {
+		result1 error
+	}
 	SaveResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) error
 	saveResourceVersionsMutex       sync.RWMutex
 	saveResourceVersionsArgsForCall []struct {
@@ -121,6 +138,16 @@ type FakePipelineDB struct {
 	saveResourceVersionsReturns struct {
 		result1 error
 	}
+	SaveNewResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) ([]atc.Version, error)
+	saveNewResourceVersionsMutex       sync.RWMutex
+	saveNewResourceVersionsArgsForCall []struct {
+		arg1 atc.ResourceConfig
+		arg2 []atc.Version
+	}
+	saveNewResourceVersionsReturns struct {
+		result1 []atc.Version
+		result2 error
+	}
 	GetLatestVersionedResourceStub        func(resource db.SavedResource) (db.SavedVersionedResource, error)
 	getLatestVersionedResourceMutex       sync.RWMutex
 	getLatestVersionedResourceArgsForCall []struct {
@@ -130,6 +157,15 @@ type FakePipelineDB struct {
 		result1 db.SavedVersionedResource
 		result2 error
 	}
+	GetVersionedResourceStub        func(versionedResourceID int) (db.SavedVersionedResource, error)
+	getVersionedResourceMutex       sync.RWMutex
+	getVersionedResourceArgsForCall []struct {
+		versionedResourceID int
+	}
+	getVersionedResourceReturns struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}
 	EnableVersionedResourceStub        func(resourceID int) error
 	enableVersionedResourceMutex       sync.RWMutex
 	enableVersionedResourceArgsForCall []struct {
@@ -180,6 +216,24 @@ type FakePipelineDB struct {
 	unpauseJobReturns struct {
 		result1 error
 	}
+	SaveJobSchedulingStatusStub        func(job string, status db.SchedulingStatus) error
+	saveJobSchedulingStatusMutex       sync.RWMutex
+	saveJobSchedulingStatusArgsForCall []struct {
+		job    string
+		status db.SchedulingStatus
+	}
+	saveJobSchedulingStatusReturns struct {
+		result1 error
+	}
+	GetJobSchedulingStatusStub        func(job string) (db.SchedulingStatus, error)
+	getJobSchedulingStatusMutex       sync.RWMutex
+	getJobSchedulingStatusArgsForCall []struct {
+		job string
+	}
+	getJobSchedulingStatusReturns struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}
 	GetJobFinishedAndNextBuildStub        func(job string) (*db.Build, *db.Build, error)
 	getJobFinishedAndNextBuildMutex       sync.RWMutex
 	getJobFinishedAndNextBuildArgsForCall []struct {
@@ -218,6 +272,25 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateJobBuildWithInputsStub        func(job string, inputs []db.BuildInput) (db.Build, error)
+	createJobBuildWithInputsMutex       sync.RWMutex
+	createJobBuildWithInputsArgsForCall []struct {
+		job    string
+		inputs []db.BuildInput
+	}
+	createJobBuildWithInputsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateJobRerunBuildStub        func(buildID int) (db.Build, error)
+	createJobRerunBuildMutex       sync.RWMutex
+	createJobRerunBuildArgsForCall []struct {
+		buildID int
+	}
+	createJobRerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	CreateJobBuildForCandidateInputsStub        func(job string) (db.Build, bool, error)
 	createJobBuildForCandidateInputsMutex       sync.RWMutex
 	createJobBuildForCandidateInputsArgsForCall []struct {
@@ -326,6 +399,14 @@ type FakePipelineDB struct {
 		result1 db.SavedVersionedResource
 		result2 error
 	}
+	MarkVersionedResourceDeletedStub        func(vr db.VersionedResource) error
+	markVersionedResourceDeletedMutex       sync.RWMutex
+	markVersionedResourceDeletedArgsForCall []struct {
+		vr db.VersionedResource
+	}
+	markVersionedResourceDeletedReturns struct {
+		result1 error
+	}
 	GetBuildResourcesStub        func(buildID int) ([]db.BuildInput, []db.BuildOutput, error)
 	getBuildResourcesMutex       sync.RWMutex
 	getBuildResourcesArgsForCall []struct {
@@ -717,6 +798,71 @@ func (fake *FakePipelineDB) UnpauseResourceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) PinVersionedResource(resourceName string, versionedResourceID int) error {
+	fake.pinVersionedResourceMutex.Lock()
+	fake.pinVersionedResourceArgsForCall = append(fake.pinVersionedResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.pinVersionedResourceMutex.Unlock()
+	if fake.PinVersionedResourceStub != nil {
+		return fake.PinVersionedResourceStub(resourceName, versionedResourceID)
+	} else {
+		return fake.pinVersionedResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceCallCount() int {
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	return len(fake.pinVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceArgsForCall(i int) (string, int) {
+	fake.pinVersionedResourceMutex.RLock()
+	defer fake.pinVersionedResourceMutex.RUnlock()
+	return fake.pinVersionedResourceArgsForCall[i].resourceName, fake.pinVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) PinVersionedResourceReturns(result1 error) {
+	fake.PinVersionedResourceStub = nil
+	fake.pinVersionedResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinResource(resourceName string) error {
+	fake.unpinResourceMutex.Lock()
+	fake.unpinResourceArgsForCall = append(fake.unpinResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.unpinResourceMutex.Unlock()
+	if fake.UnpinResourceStub != nil {
+		return fake.UnpinResourceStub(resourceName)
+	} else {
+		return fake.unpinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceCallCount() int {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return len(fake.unpinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceArgsForCall(i int) string {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return fake.unpinResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) UnpinResourceReturns(result1 error) {
+	fake.UnpinResourceStub = nil
+	fake.unpinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) SaveResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) error {
 	fake.saveResourceVersionsMutex.Lock()
 	fake.saveResourceVersionsArgsForCall = append(fake.saveResourceVersionsArgsForCall, struct {
@@ -750,6 +896,40 @@ func (fake *FakePipelineDB) SaveResourceVersionsReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SaveNewResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) ([]atc.Version, error) {
+	fake.saveNewResourceVersionsMutex.Lock()
+	fake.saveNewResourceVersionsArgsForCall = append(fake.saveNewResourceVersionsArgsForCall, struct {
+		arg1 atc.ResourceConfig
+		arg2 []atc.Version
+	}{arg1, arg2})
+	fake.saveNewResourceVersionsMutex.Unlock()
+	if fake.SaveNewResourceVersionsStub != nil {
+		return fake.SaveNewResourceVersionsStub(arg1, arg2)
+	} else {
+		return fake.saveNewResourceVersionsReturns.result1, fake.saveNewResourceVersionsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) SaveNewResourceVersionsCallCount() int {
+	fake.saveNewResourceVersionsMutex.RLock()
+	defer fake.saveNewResourceVersionsMutex.RUnlock()
+	return len(fake.saveNewResourceVersionsArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveNewResourceVersionsArgsForCall(i int) (atc.ResourceConfig, []atc.Version) {
+	fake.saveNewResourceVersionsMutex.RLock()
+	defer fake.saveNewResourceVersionsMutex.RUnlock()
+	return fake.saveNewResourceVersionsArgsForCall[i].arg1, fake.saveNewResourceVersionsArgsForCall[i].arg2
+}
+
+func (fake *FakePipelineDB) SaveNewResourceVersionsReturns(result1 []atc.Version, result2 error) {
+	fake.SaveNewResourceVersionsStub = nil
+	fake.saveNewResourceVersionsReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) GetLatestVersionedResource(resource db.SavedResource) (db.SavedVersionedResource, error) {
 	fake.getLatestVersionedResourceMutex.Lock()
 	fake.getLatestVersionedResourceArgsForCall = append(fake.getLatestVersionedResourceArgsForCall, struct {
@@ -783,6 +963,39 @@ func (fake *FakePipelineDB) GetLatestVersionedResourceReturns(result1 db.SavedVe
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) GetVersionedResource(versionedResourceID int) (db.SavedVersionedResource, error) {
+	fake.getVersionedResourceMutex.Lock()
+	fake.getVersionedResourceArgsForCall = append(fake.getVersionedResourceArgsForCall, struct {
+		versionedResourceID int
+	}{versionedResourceID})
+	fake.getVersionedResourceMutex.Unlock()
+	if fake.GetVersionedResourceStub != nil {
+		return fake.GetVersionedResourceStub(versionedResourceID)
+	} else {
+		return fake.getVersionedResourceReturns.result1, fake.getVersionedResourceReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetVersionedResourceCallCount() int {
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
+	return len(fake.getVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetVersionedResourceArgsForCall(i int) int {
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
+	return fake.getVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) GetVersionedResourceReturns(result1 db.SavedVersionedResource, result2 error) {
+	fake.GetVersionedResourceStub = nil
+	fake.getVersionedResourceReturns = struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) EnableVersionedResource(resourceID int) error {
 	fake.enableVersionedResourceMutex.Lock()
 	fake.enableVersionedResourceArgsForCall = append(fake.enableVersionedResourceArgsForCall, struct {
@@ -977,6 +1190,72 @@ func (fake *FakePipelineDB) UnpauseJobReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SaveJobSchedulingStatus(job string, status db.SchedulingStatus) error {
+	fake.saveJobSchedulingStatusMutex.Lock()
+	fake.saveJobSchedulingStatusArgsForCall = append(fake.saveJobSchedulingStatusArgsForCall, struct {
+		job    string
+		status db.SchedulingStatus
+	}{job, status})
+	fake.saveJobSchedulingStatusMutex.Unlock()
+	if fake.SaveJobSchedulingStatusStub != nil {
+		return fake.SaveJobSchedulingStatusStub(job, status)
+	} else {
+		return fake.saveJobSchedulingStatusReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatusCallCount() int {
+	fake.saveJobSchedulingStatusMutex.RLock()
+	defer fake.saveJobSchedulingStatusMutex.RUnlock()
+	return len(fake.saveJobSchedulingStatusArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatusArgsForCall(i int) (string, db.SchedulingStatus) {
+	fake.saveJobSchedulingStatusMutex.RLock()
+	defer fake.saveJobSchedulingStatusMutex.RUnlock()
+	return fake.saveJobSchedulingStatusArgsForCall[i].job, fake.saveJobSchedulingStatusArgsForCall[i].status
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatusReturns(result1 error) {
+	fake.SaveJobSchedulingStatusStub = nil
+	fake.saveJobSchedulingStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetJobSchedulingStatus(job string) (db.SchedulingStatus, error) {
+	fake.getJobSchedulingStatusMutex.Lock()
+	fake.getJobSchedulingStatusArgsForCall = append(fake.getJobSchedulingStatusArgsForCall, struct {
+		job string
+	}{job})
+	fake.getJobSchedulingStatusMutex.Unlock()
+	if fake.GetJobSchedulingStatusStub != nil {
+		return fake.GetJobSchedulingStatusStub(job)
+	} else {
+		return fake.getJobSchedulingStatusReturns.result1, fake.getJobSchedulingStatusReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetJobSchedulingStatusCallCount() int {
+	fake.getJobSchedulingStatusMutex.RLock()
+	defer fake.getJobSchedulingStatusMutex.RUnlock()
+	return len(fake.getJobSchedulingStatusArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobSchedulingStatusArgsForCall(i int) string {
+	fake.getJobSchedulingStatusMutex.RLock()
+	defer fake.getJobSchedulingStatusMutex.RUnlock()
+	return fake.getJobSchedulingStatusArgsForCall[i].job
+}
+
+func (fake *FakePipelineDB) GetJobSchedulingStatusReturns(result1 db.SchedulingStatus, result2 error) {
+	fake.GetJobSchedulingStatusStub = nil
+	fake.getJobSchedulingStatusReturns = struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) GetJobFinishedAndNextBuild(job string) (*db.Build, *db.Build, error) {
 	fake.getJobFinishedAndNextBuildMutex.Lock()
 	fake.getJobFinishedAndNextBuildArgsForCall = append(fake.getJobFinishedAndNextBuildArgsForCall, struct {
@@ -1111,6 +1390,73 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) CreateJobBuildWithInputs(job string, inputs []db.BuildInput) (db.Build, error) {
+	fake.createJobBuildWithInputsMutex.Lock()
+	fake.createJobBuildWithInputsArgsForCall = append(fake.createJobBuildWithInputsArgsForCall, struct {
+		job    string
+		inputs []db.BuildInput
+	}{job, inputs})
+	fake.createJobBuildWithInputsMutex.Unlock()
+	if fake.CreateJobBuildWithInputsStub != nil {
+		return fake.CreateJobBuildWithInputsStub(job, inputs)
+	} else {
+		return fake.createJobBuildWithInputsReturns.result1, fake.createJobBuildWithInputsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputsCallCount() int {
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
+	return len(fake.createJobBuildWithInputsArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputsArgsForCall(i int) (string, []db.BuildInput) {
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
+	return fake.createJobBuildWithInputsArgsForCall[i].job, fake.createJobBuildWithInputsArgsForCall[i].inputs
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputsReturns(result1 db.Build, result2 error) {
+	fake.CreateJobBuildWithInputsStub = nil
+	fake.createJobBuildWithInputsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuild(buildID int) (db.Build, error) {
+	fake.createJobRerunBuildMutex.Lock()
+	fake.createJobRerunBuildArgsForCall = append(fake.createJobRerunBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.createJobRerunBuildMutex.Unlock()
+	if fake.CreateJobRerunBuildStub != nil {
+		return fake.CreateJobRerunBuildStub(buildID)
+	} else {
+		return fake.createJobRerunBuildReturns.result1, fake.createJobRerunBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuildCallCount() int {
+	fake.createJobRerunBuildMutex.RLock()
+	defer fake.createJobRerunBuildMutex.RUnlock()
+	return len(fake.createJobRerunBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuildArgsForCall(i int) int {
+	fake.createJobRerunBuildMutex.RLock()
+	defer fake.createJobRerunBuildMutex.RUnlock()
+	return fake.createJobRerunBuildArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateJobRerunBuildStub = nil
+	fake.createJobRerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputs(job string) (db.Build, bool, error) {
 	fake.createJobBuildForCandidateInputsMutex.Lock()
 	fake.createJobBuildForCandidateInputsArgsForCall = append(fake.createJobBuildForCandidateInputsArgsForCall, struct {
@@ -1483,6 +1829,38 @@ func (fake *FakePipelineDB) SaveBuildOutputReturns(result1 db.SavedVersionedReso
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) MarkVersionedResourceDeleted(vr db.VersionedResource) error {
+	fake.markVersionedResourceDeletedMutex.Lock()
+	fake.markVersionedResourceDeletedArgsForCall = append(fake.markVersionedResourceDeletedArgsForCall, struct {
+		vr db.VersionedResource
+	}{vr})
+	fake.markVersionedResourceDeletedMutex.Unlock()
+	if fake.MarkVersionedResourceDeletedStub != nil {
+		return fake.MarkVersionedResourceDeletedStub(vr)
+	} else {
+		return fake.markVersionedResourceDeletedReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) MarkVersionedResourceDeletedCallCount() int {
+	fake.markVersionedResourceDeletedMutex.RLock()
+	defer fake.markVersionedResourceDeletedMutex.RUnlock()
+	return len(fake.markVersionedResourceDeletedArgsForCall)
+}
+
+func (fake *FakePipelineDB) MarkVersionedResourceDeletedArgsForCall(i int) db.VersionedResource {
+	fake.markVersionedResourceDeletedMutex.RLock()
+	defer fake.markVersionedResourceDeletedMutex.RUnlock()
+	return fake.markVersionedResourceDeletedArgsForCall[i].vr
+}
+
+func (fake *FakePipelineDB) MarkVersionedResourceDeletedReturns(result1 error) {
+	fake.MarkVersionedResourceDeletedStub = nil
+	fake.markVersionedResourceDeletedReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) GetBuildResources(buildID int) ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.getBuildResourcesMutex.Lock()
 	fake.getBuildResourcesArgsForCall = append(fake.getBuildResourcesArgsForCall, struct {
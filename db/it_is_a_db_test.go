@@ -150,6 +150,62 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 			Ω(err).Should(Equal(db.ErrEndOfBuildEventStream))
 		})
 
+		Describe("aborting builds", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = database.CreateOneOffBuild()
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("aborts a pending build immediately", func() {
+				err := database.AbortBuild(build.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				abortedBuild, err := database.GetBuild(build.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(abortedBuild.Status).Should(Equal(db.StatusAborted))
+
+				started, err := database.StartBuild(build.ID, "engine", "metadata")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(started).Should(BeFalse())
+			})
+
+			Context("when the build has started", func() {
+				BeforeEach(func() {
+					started, err := database.StartBuild(build.ID, "engine", "metadata")
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(started).Should(BeTrue())
+				})
+
+				It("notifies whoever is tracking it, leaving it to finish the build", func() {
+					notifier, err := database.AbortNotifier(build.ID)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					defer notifier.Close()
+
+					Consistently(notifier.Notify()).ShouldNot(Receive())
+
+					err = database.AbortBuild(build.ID)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Eventually(notifier.Notify()).Should(Receive())
+
+					runningBuild, err := database.GetBuild(build.ID)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(runningBuild.Status).Should(Equal(db.StatusStarted))
+
+					err = database.FinishBuild(build.ID, db.StatusAborted)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					abortedBuild, err := database.GetBuild(build.ID)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(abortedBuild.Status).Should(Equal(db.StatusAborted))
+				})
+			})
+		})
+
 		It("can keep track of workers", func() {
 			Ω(database.Workers()).Should(BeEmpty())
 
@@ -271,6 +327,45 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 			})
 		})
 
+		Describe("image resource versions", func() {
+			It("records the version chosen for each task's location in the build", func() {
+				build, err := database.CreateOneOffBuild()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, found, err := database.GetImageResourceVersion(build.ID, 3)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeFalse())
+
+				err = database.SaveImageResourceVersion(build.ID, 3, atc.Version{"digest": "some-digest"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				version, found, err := database.GetImageResourceVersion(build.ID, 3)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeTrue())
+				Ω(version).Should(Equal(atc.Version{"digest": "some-digest"}))
+
+				_, found, err = database.GetImageResourceVersion(build.ID, 4)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeFalse())
+			})
+
+			It("copies them to reruns of the build", func() {
+				build, err := database.PipelineDB.CreateJobBuildWithInputs("some-job", nil)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = database.SaveImageResourceVersion(build.ID, 3, atc.Version{"digest": "some-digest"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				rerun, err := database.PipelineDB.CreateJobRerunBuild(build.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				version, found, err := database.GetImageResourceVersion(rerun.ID, 3)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeTrue())
+				Ω(version).Should(Equal(atc.Version{"digest": "some-digest"}))
+			})
+		})
+
 		Describe("locking", func() {
 			It("can be done generically with a unique name", func() {
 				lock, err := database.AcquireWriteLock([]db.NamedLock{db.ResourceCheckingLock("a-name")})
@@ -1,5 +1,10 @@
 package db
 
+import (
+	"fmt"
+	"time"
+)
+
 type Job struct {
 	Name string
 }
@@ -10,3 +15,75 @@ type SavedJob struct {
 	PipelineName string
 	Job
 }
+
+type SchedulingReason string
+
+const (
+	SchedulingReasonScheduled           SchedulingReason = "scheduled"
+	SchedulingReasonUpToDate            SchedulingReason = "up-to-date"
+	SchedulingReasonNoInputs            SchedulingReason = "no-inputs"
+	SchedulingReasonNoTriggerInputs     SchedulingReason = "no-trigger-inputs"
+	SchedulingReasonNoVersions          SchedulingReason = "no-versions"
+	SchedulingReasonResourcePaused      SchedulingReason = "resource-paused"
+	SchedulingReasonPassedUnsatisfied   SchedulingReason = "passed-unsatisfied"
+	SchedulingReasonPipelinePaused      SchedulingReason = "pipeline-paused"
+	SchedulingReasonJobPaused           SchedulingReason = "job-paused"
+	SchedulingReasonMaxInFlightReached  SchedulingReason = "max-in-flight-reached"
+	SchedulingReasonNotNextPending      SchedulingReason = "not-next-pending"
+	SchedulingReasonNoCompatibleWorkers SchedulingReason = "no-compatible-workers"
+)
+
+// SchedulingStatus explains the outcome of the last attempt to schedule a
+// job, i.e. why it did or did not start a build.
+type SchedulingStatus struct {
+	Reason SchedulingReason `json:"reason"`
+
+	// the input and resource that the reason concerns, if any
+	Input    string `json:"input,omitempty"`
+	Resource string `json:"resource,omitempty"`
+
+	// the upstream job that has not succeeded with a version satisfying the
+	// input's passed constraint
+	PassedJob string `json:"passed_job,omitempty"`
+
+	// the build that was scheduled, or that could not be
+	BuildName string `json:"build_name,omitempty"`
+
+	// set when the status is loaded
+	Time time.Time `json:"-"`
+}
+
+func (status SchedulingStatus) Description() string {
+	switch status.Reason {
+	case SchedulingReasonScheduled:
+		return fmt.Sprintf("build #%s was scheduled", status.BuildName)
+	case SchedulingReasonUpToDate:
+		return fmt.Sprintf("build #%s already ran with the latest versions of the trigger inputs", status.BuildName)
+	case SchedulingReasonNoInputs:
+		return "the job has no inputs, so it only runs when triggered manually"
+	case SchedulingReasonNoTriggerInputs:
+		return "none of the job's inputs trigger it, so it only runs when triggered manually"
+	case SchedulingReasonNoVersions:
+		if status.Input == "" {
+			return "no versions satisfy all of the inputs' constraints together"
+		}
+
+		return fmt.Sprintf("resource '%s' has no versions for input '%s'", status.Resource, status.Input)
+	case SchedulingReasonResourcePaused:
+		return fmt.Sprintf("resource '%s' of input '%s' is paused, so it is not checked for new versions", status.Resource, status.Input)
+	case SchedulingReasonPassedUnsatisfied:
+		return fmt.Sprintf("job '%s' has not succeeded with a version of resource '%s' for input '%s'", status.PassedJob, status.Resource, status.Input)
+	case SchedulingReasonPipelinePaused:
+		return fmt.Sprintf("build #%s is waiting for the pipeline to be unpaused", status.BuildName)
+	case SchedulingReasonJobPaused:
+		return fmt.Sprintf("build #%s is waiting for the job to be unpaused", status.BuildName)
+	case SchedulingReasonMaxInFlightReached:
+		return fmt.Sprintf("build #%s is waiting for other builds in its serial groups to finish", status.BuildName)
+	case SchedulingReasonNotNextPending:
+		return fmt.Sprintf("build #%s is waiting for earlier pending builds in its serial groups to start", status.BuildName)
+	case SchedulingReasonNoCompatibleWorkers:
+		return fmt.Sprintf("no workers can run resource '%s' of input '%s'", status.Resource, status.Input)
+	default:
+		return "the job has not been scheduled yet"
+	}
+}
@@ -38,13 +38,13 @@ func (s JobService) CanBuildBeScheduled(build Build) (bool, string, error) {
 		return false, "build-not-pending", nil
 	}
 
-	if s.JobConfig.IsSerial() {
+	if maxInFlight := s.JobConfig.MaxInFlight(); maxInFlight > 0 {
 		builds, err := s.DB.GetRunningBuildsBySerialGroup(s.DBJob.Name, s.JobConfig.GetSerialGroups())
 		if err != nil {
 			return false, "db-failed", err
 		}
 
-		if len(builds) > 0 {
+		if len(builds) >= maxInFlight {
 			return false, "other-builds-running", nil
 		}
 
@@ -199,6 +199,67 @@ func jobService(database *dbSharedBehaviorInput) func() {
 							})
 						})
 					})
+
+					Context("When the job has a max in flight", func() {
+						var service db.JobService
+						var dbBuild db.Build
+
+						BeforeEach(func() {
+							var err error
+							service, err = db.NewJobService(atc.JobConfig{
+								Name:           "some-job",
+								RawMaxInFlight: 2,
+							}, fakeDB)
+
+							Ω(err).ShouldNot(HaveOccurred())
+							dbBuild = db.Build{
+								Status: db.StatusPending,
+							}
+
+							fakeDB.GetNextPendingBuildBySerialGroupReturns(db.Build{ID: dbBuild.ID}, nil)
+						})
+
+						It("counts the running builds of the job", func() {
+							_, _, err := service.CanBuildBeScheduled(dbBuild)
+							Ω(err).ShouldNot(HaveOccurred())
+
+							Ω(fakeDB.GetRunningBuildsBySerialGroupCallCount()).Should(Equal(1))
+							jobName, serialGroups := fakeDB.GetRunningBuildsBySerialGroupArgsForCall(0)
+							Ω(jobName).Should(Equal("a-job"))
+							Ω(serialGroups).Should(Equal([]string{"some-job"}))
+						})
+
+						Context("When fewer builds than the max are running", func() {
+							BeforeEach(func() {
+								fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{
+									{Name: "some-build"},
+								}, nil)
+							})
+
+							It("returns true", func() {
+								canBuildBeScheduled, reason, err := service.CanBuildBeScheduled(dbBuild)
+								Ω(err).ShouldNot(HaveOccurred())
+								Ω(reason).Should(Equal("can-be-scheduled"))
+								Ω(canBuildBeScheduled).Should(BeTrue())
+							})
+						})
+
+						Context("When the max builds are running", func() {
+							BeforeEach(func() {
+								fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{
+									{Name: "some-build"},
+									{Name: "some-other-build"},
+								}, nil)
+							})
+
+							It("returns false", func() {
+								canBuildBeScheduled, reason, err := service.CanBuildBeScheduled(dbBuild)
+								Ω(err).ShouldNot(HaveOccurred())
+								Ω(reason).Should(Equal("other-builds-running"))
+								Ω(canBuildBeScheduled).Should(BeFalse())
+							})
+						})
+					})
 				})
 			})
 		})
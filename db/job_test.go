@@ -0,0 +1,44 @@
+package db_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("SchedulingStatus", func() {
+	Describe("Description", func() {
+		It("names the upstream job of an unsatisfied passed constraint", func() {
+			status := db.SchedulingStatus{
+				Reason:    db.SchedulingReasonPassedUnsatisfied,
+				Input:     "some-input",
+				Resource:  "some-resource",
+				PassedJob: "some-upstream-job",
+			}
+
+			Ω(status.Description()).Should(Equal("job 'some-upstream-job' has not succeeded with a version of resource 'some-resource' for input 'some-input'"))
+		})
+
+		It("names the build that is waiting to be scheduled", func() {
+			status := db.SchedulingStatus{
+				Reason:    db.SchedulingReasonMaxInFlightReached,
+				BuildName: "42",
+			}
+
+			Ω(status.Description()).Should(Equal("build #42 is waiting for other builds in its serial groups to finish"))
+		})
+
+		It("explains inputs that have no versions together", func() {
+			status := db.SchedulingStatus{
+				Reason: db.SchedulingReasonNoVersions,
+			}
+
+			Ω(status.Description()).Should(Equal("no versions satisfy all of the inputs' constraints together"))
+		})
+
+		It("has a description before the job has been scheduled", func() {
+			Ω(db.SchedulingStatus{}.Description()).Should(Equal("the job has not been scheduled yet"))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddDeletedToVersionedResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE versioned_resources
+		ADD COLUMN deleted boolean NOT NULL DEFAULT false
+	`)
+
+	return err
+}
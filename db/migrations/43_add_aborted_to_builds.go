@@ -0,0 +1,21 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddAbortedToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN aborted boolean NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET aborted = true
+		WHERE status = 'aborted'
+	`)
+
+	return err
+}
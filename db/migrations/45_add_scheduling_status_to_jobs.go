@@ -0,0 +1,13 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddSchedulingStatusToJobs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE jobs
+		ADD COLUMN scheduling_status text,
+		ADD COLUMN scheduling_status_time timestamp with time zone
+	`)
+
+	return err
+}
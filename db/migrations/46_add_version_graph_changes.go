@@ -0,0 +1,32 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddVersionGraphChanges(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN version_graph_revision integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE version_graph_changes (
+			pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+			revision integer NOT NULL,
+			versioned_resource_id integer REFERENCES versioned_resources (id) ON DELETE CASCADE,
+			build_id integer REFERENCES builds (id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX version_graph_changes_pipeline_id_revision
+		ON version_graph_changes (pipeline_id, revision)
+	`)
+
+	return err
+}
@@ -0,0 +1,16 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateImageResourceVersions(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE image_resource_versions (
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			location_id integer NOT NULL,
+			version text NOT NULL,
+			UNIQUE (build_id, location_id)
+		)
+	`)
+
+	return err
+}
@@ -43,4 +43,11 @@ var Migrations = []migration.Migrator{
 	AddOrderingToPipelines,
 	AddInputsDeterminedToBuilds,
 	AddExplicitToBuildOutputs,
+	AddPinnedVersionToResources,
+	AddDeletedToVersionedResources,
+	AddAbortedToBuilds,
+	AddRerunOfToBuilds,
+	AddSchedulingStatusToJobs,
+	AddVersionGraphChanges,
+	CreateImageResourceVersions,
 }
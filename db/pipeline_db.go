@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/lib/pq"
 	"github.com/pivotal-golang/lager"
 )
@@ -32,9 +33,13 @@ type PipelineDB interface {
 	GetResourceHistoryMaxID(resourceID int) (int, error)
 	PauseResource(resourceName string) error
 	UnpauseResource(resourceName string) error
+	PinVersionedResource(resourceName string, versionedResourceID int) error
+	UnpinResource(resourceName string) error
 
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
+	SaveNewResourceVersions(atc.ResourceConfig, []atc.Version) ([]atc.Version, error)
 	GetLatestVersionedResource(resource SavedResource) (SavedVersionedResource, error)
+	GetVersionedResource(versionedResourceID int) (SavedVersionedResource, error)
 	EnableVersionedResource(resourceID int) error
 	DisableVersionedResource(resourceID int) error
 	SetResourceCheckError(resource SavedResource, err error) error
@@ -43,11 +48,16 @@ type PipelineDB interface {
 	PauseJob(job string) error
 	UnpauseJob(job string) error
 
+	SaveJobSchedulingStatus(job string, status SchedulingStatus) error
+	GetJobSchedulingStatus(job string) (SchedulingStatus, error)
+
 	GetJobFinishedAndNextBuild(job string) (*Build, *Build, error)
 
 	GetAllJobBuilds(job string) ([]Build, error)
 	GetJobBuild(job string, build string) (Build, error)
 	CreateJobBuild(job string) (Build, error)
+	CreateJobBuildWithInputs(job string, inputs []BuildInput) (Build, error)
+	CreateJobRerunBuild(buildID int) (Build, error)
 	CreateJobBuildForCandidateInputs(job string) (Build, bool, error)
 
 	UseInputsForBuild(buildID int, inputs []BuildInput) error
@@ -63,6 +73,7 @@ type PipelineDB interface {
 	ScheduleBuild(buildID int, job atc.JobConfig) (bool, error)
 	SaveBuildInput(buildID int, input BuildInput) (SavedVersionedResource, error)
 	SaveBuildOutput(buildID int, vr VersionedResource, explicit bool) (SavedVersionedResource, error)
+	MarkVersionedResourceDeleted(vr VersionedResource) error
 	GetBuildResources(buildID int) ([]BuildInput, []BuildOutput, error)
 }
 
@@ -74,6 +85,8 @@ type pipelineDB struct {
 	conn Conn
 	bus  *notificationsBus
 
+	versionGraph *versionGraph
+
 	SavedPipeline
 }
 
@@ -194,7 +207,14 @@ func (pdb *pipelineDB) Destroy() error {
 		}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	pdb.versionGraph.evict()
+
+	return nil
 }
 
 func (pdb *pipelineDB) GetConfig() (atc.Config, ConfigVersion, error) {
@@ -455,14 +475,18 @@ func (pdb *pipelineDB) GetResourceHistoryMaxID(resourceID int) (int, error) {
 
 func (pdb *pipelineDB) getResource(tx *sql.Tx, name string) (SavedResource, error) {
 	var checkErr sql.NullString
+	var pinnedVersionID sql.NullInt64
+	var pinnedVersion sql.NullString
 	var resource SavedResource
 
 	err := tx.QueryRow(`
-			SELECT id, name, check_error, paused
-			FROM resources
-			WHERE name = $1
-				AND pipeline_id = $2
-		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused)
+			SELECT r.id, r.name, r.check_error, r.paused, r.pinned_version_id, vr.version
+			FROM resources r
+			LEFT JOIN versioned_resources vr
+				ON vr.id = r.pinned_version_id
+			WHERE r.name = $1
+				AND r.pipeline_id = $2
+		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused, &pinnedVersionID, &pinnedVersion)
 	if err != nil {
 		return SavedResource{}, err
 	}
@@ -471,6 +495,15 @@ func (pdb *pipelineDB) getResource(tx *sql.Tx, name string) (SavedResource, erro
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if pinnedVersionID.Valid && pinnedVersion.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+
+		err = json.Unmarshal([]byte(pinnedVersion.String), &resource.PinnedVersion)
+		if err != nil {
+			return SavedResource{}, err
+		}
+	}
+
 	resource.PipelineName = pdb.Name
 
 	return resource, nil
@@ -519,7 +552,7 @@ func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 	return tx.Commit()
 }
 
-func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
+func (pdb *pipelineDB) PinVersionedResource(resource string, versionedResourceID int) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
 		return err
@@ -527,68 +560,124 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 
 	defer tx.Rollback()
 
-	for _, version := range versions {
-		_, err := pdb.saveVersionedResource(tx, VersionedResource{
-			Resource: config.Name,
-			Type:     config.Type,
-			Source:   Source(config.Source),
-			Version:  Version(version),
-		})
-		if err != nil {
-			return err
-		}
-	}
-
-	err = tx.Commit()
+	err = pdb.registerResource(tx, resource)
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
-
-func (pdb *pipelineDB) DisableVersionedResource(resourceID int) error {
-	rows, err := pdb.conn.Exec(`
-		UPDATE versioned_resources
-		SET enabled = false
-		WHERE id = $1
-	`, resourceID)
+	result, err := tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = $1
+		WHERE name = $2
+			AND pipeline_id = $3
+			AND EXISTS (
+				SELECT 1
+				FROM versioned_resources
+				WHERE id = $1
+					AND resource_id = resources.id
+			)
+	`, versionedResourceID, resource, pdb.ID)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := rows.RowsAffected()
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	// the version does not exist, or is a version of another resource
+	if rowsAffected == 0 {
+		return ErrNoVersionedResource
+	}
+
 	if rowsAffected != 1 {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (pdb *pipelineDB) EnableVersionedResource(resourceID int) error {
-	rows, err := pdb.conn.Exec(`
-		UPDATE versioned_resources
-		SET enabled = true
-		WHERE id = $1
-	`, resourceID)
+func (pdb *pipelineDB) UnpinResource(resource string) error {
+	tx, err := pdb.conn.Begin()
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := rows.RowsAffected()
+	defer tx.Rollback()
+
+	err = pdb.registerResource(tx, resource)
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected != 1 {
-		return nonOneRowAffectedError{rowsAffected}
+	_, err = tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, resource, pdb.ID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
+	_, err := pdb.SaveNewResourceVersions(config, versions)
+	return err
+}
+
+// SaveNewResourceVersions saves the versions of the resource like
+// SaveResourceVersions, returning the ones that had not been saved before.
+func (pdb *pipelineDB) SaveNewResourceVersions(config atc.ResourceConfig, versions []atc.Version) ([]atc.Version, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	newVersions := []atc.Version{}
+	versionIDs := []int{}
+	for _, version := range versions {
+		svr, created, err := pdb.saveVersionedResource(tx, VersionedResource{
+			Resource: config.Name,
+			Type:     config.Type,
+			Source:   Source(config.Source),
+			Version:  Version(version),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if created {
+			newVersions = append(newVersions, version)
+		}
+
+		versionIDs = append(versionIDs, svr.ID)
+	}
+
+	err = saveVersionGraphChanges(tx, pdb.ID, versionIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return newVersions, nil
+}
+
+func (pdb *pipelineDB) DisableVersionedResource(resourceID int) error {
+	return pdb.setVersionedResourceEnabled(resourceID, false)
+}
+
+func (pdb *pipelineDB) EnableVersionedResource(resourceID int) error {
+	return pdb.setVersionedResourceEnabled(resourceID, true)
 }
 
 func (pdb *pipelineDB) GetLatestVersionedResource(resource SavedResource) (SavedVersionedResource, error) {
@@ -629,6 +718,50 @@ func (pdb *pipelineDB) GetLatestVersionedResource(resource SavedResource) (Saved
 	return svr, nil
 }
 
+// GetVersionedResource returns the version of one of the pipeline's resources
+// with the given ID, or ErrNoVersions if there is no such version.
+func (pdb *pipelineDB) GetVersionedResource(versionedResourceID int) (SavedVersionedResource, error) {
+	var sourceBytes, versionBytes, metadataBytes string
+
+	svr := SavedVersionedResource{
+		VersionedResource: VersionedResource{
+			PipelineName: pdb.Name,
+		},
+	}
+
+	err := pdb.conn.QueryRow(`
+		SELECT vr.id, vr.enabled, r.name, vr.type, vr.source, vr.version, vr.metadata
+		FROM versioned_resources vr, resources r
+		WHERE vr.id = $1
+			AND vr.resource_id = r.id
+			AND r.pipeline_id = $2
+	`, versionedResourceID, pdb.ID).Scan(&svr.ID, &svr.Enabled, &svr.Resource, &svr.Type, &sourceBytes, &versionBytes, &metadataBytes)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedVersionedResource{}, ErrNoVersions
+		}
+
+		return SavedVersionedResource{}, err
+	}
+
+	err = json.Unmarshal([]byte(sourceBytes), &svr.Source)
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
+	err = json.Unmarshal([]byte(versionBytes), &svr.Version)
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
+	err = json.Unmarshal([]byte(metadataBytes), &svr.Metadata)
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
+	return svr, nil
+}
+
 func (pdb *pipelineDB) SetResourceCheckError(resource SavedResource, cause error) error {
 	var err error
 
@@ -660,36 +793,73 @@ func (pdb *pipelineDB) registerResource(tx *sql.Tx, name string) error {
 	return err
 }
 
-func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (SavedVersionedResource, error) {
+func (pdb *pipelineDB) setVersionedResourceEnabled(versionedResourceID int, enabled bool) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Exec(`
+		UPDATE versioned_resources
+		SET enabled = $2
+		WHERE id = $1
+	`, versionedResourceID, enabled)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	err = saveVersionGraphChanges(tx, pdb.ID, []int{versionedResourceID}, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// saveVersionedResource saves the version of the resource, or updates its
+// source and metadata if it has already been saved, returning whether it was
+// new.
+func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (SavedVersionedResource, bool, error) {
 	err := pdb.registerResource(tx, vr.Resource)
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
 	}
 
 	savedResource, err := pdb.getResource(tx, vr.Resource)
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
 	}
 
 	versionJSON, err := json.Marshal(vr.Version)
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
 	}
 
 	sourceJSON, err := json.Marshal(vr.Source)
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
 	}
 
 	metadataJSON, err := json.Marshal(vr.Metadata)
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
 	}
 
 	var id int
 	var enabled bool
 
-	_, err = tx.Exec(`
+	result, err := tx.Exec(`
 		INSERT INTO versioned_resources (resource_id, type, version, source, metadata)
 		SELECT $1, $2, $3, $4, $5
 		WHERE NOT EXISTS (
@@ -701,7 +871,12 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 		)
 	`, savedResource.ID, vr.Type, string(versionJSON), string(sourceJSON), string(metadataJSON))
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return SavedVersionedResource{}, false, err
 	}
 
 	// separate from above, as it conditionally inserts (can't use RETURNING)
@@ -715,7 +890,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 	`, savedResource.ID, vr.Type, string(versionJSON), string(sourceJSON), string(metadataJSON)).Scan(&id, &enabled)
 
 	if err != nil {
-		return SavedVersionedResource{}, err
+		return SavedVersionedResource{}, false, err
 	}
 
 	return SavedVersionedResource{
@@ -723,7 +898,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 		Enabled: enabled,
 
 		VersionedResource: vr,
-	}, nil
+	}, inserted == 1, nil
 }
 
 func (pdb *pipelineDB) GetJob(jobName string) (SavedJob, error) {
@@ -833,17 +1008,24 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 
 	defer tx.Rollback()
 
-	for _, input := range inputs {
-		_, err := pdb.saveBuildInput(tx, buildID, input)
-		if err != nil {
-			return err
-		}
+	err = pdb.useInputsForBuild(tx, buildID, inputs)
+	if err != nil {
+		return err
 	}
 
+	return tx.Commit()
+}
+
+// useInputsForBuild saves the build's inputs and marks them as determined,
+// returning ErrInputsAlreadyDetermined if they already were. The build's row
+// stays locked until the transaction ends, so its inputs are only ever
+// determined once.
+func (pdb *pipelineDB) useInputsForBuild(tx *sql.Tx, buildID int, inputs []BuildInput) error {
 	result, err := tx.Exec(`
 		UPDATE builds b
 		SET inputs_determined = true
 		WHERE b.id = $1
+			AND b.inputs_determined = false
 	`, buildID)
 	if err != nil {
 		return err
@@ -854,11 +1036,25 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 		return err
 	}
 
+	if rows == 0 {
+		return ErrInputsAlreadyDetermined
+	}
+
 	if rows != 1 {
 		return errors.New("multiple rows affected but expected only one when determining inputs")
 	}
 
-	return tx.Commit()
+	versionIDs := []int{}
+	for _, input := range inputs {
+		svr, err := pdb.saveBuildInput(tx, buildID, input)
+		if err != nil {
+			return err
+		}
+
+		versionIDs = append(versionIDs, svr.ID)
+	}
+
+	return saveVersionGraphChanges(tx, pdb.ID, versionIDs, []int{buildID})
 }
 
 func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
@@ -882,6 +1078,118 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 	return build, nil
 }
 
+// CreateJobBuildWithInputs creates a build of the job whose inputs are already
+// determined, so that the scheduler never chooses others for it.
+func (pdb *pipelineDB) CreateJobBuildWithInputs(jobName string, inputs []BuildInput) (Build, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return Build{}, err
+	}
+
+	defer tx.Rollback()
+
+	build, err := pdb.createJobBuild(jobName, tx)
+	if err != nil {
+		return Build{}, err
+	}
+
+	err = pdb.useInputsForBuild(tx, build.ID, inputs)
+	if err != nil {
+		return Build{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Build{}, err
+	}
+
+	build.InputsDetermined = true
+
+	return build, nil
+}
+
+func (pdb *pipelineDB) CreateJobRerunBuild(buildID int) (Build, error) {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return Build{}, err
+	}
+
+	defer tx.Rollback()
+
+	var jobName string
+	var inputsDetermined bool
+	err = tx.QueryRow(`
+		SELECT j.name, b.inputs_determined
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		WHERE b.id = $1
+			AND j.pipeline_id = $2
+	`, buildID, pdb.ID).Scan(&jobName, &inputsDetermined)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Build{}, ErrNoBuild
+		}
+
+		return Build{}, err
+	}
+
+	// a build that errored before its inputs were determined has nothing to
+	// rerun with
+	if !inputsDetermined {
+		return Build{}, ErrInputsNotDetermined
+	}
+
+	build, err := pdb.createJobBuild(jobName, tx)
+	if err != nil {
+		return Build{}, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+		SELECT $1, versioned_resource_id, name
+		FROM build_inputs
+		WHERE build_id = $2
+	`, build.ID, buildID)
+	if err != nil {
+		return Build{}, err
+	}
+
+	// the images of its tasks are the same as well
+	_, err = tx.Exec(`
+		INSERT INTO image_resource_versions (build_id, location_id, version)
+		SELECT $1, location_id, version
+		FROM image_resource_versions
+		WHERE build_id = $2
+	`, build.ID, buildID)
+	if err != nil {
+		return Build{}, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET rerun_of = $2, inputs_determined = true
+		WHERE id = $1
+	`, build.ID, buildID)
+	if err != nil {
+		return Build{}, err
+	}
+
+	err = saveVersionGraphChanges(tx, pdb.ID, nil, []int{build.ID})
+	if err != nil {
+		return Build{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return Build{}, err
+	}
+
+	build.RerunOf = buildID
+	build.InputsDetermined = true
+
+	return build, nil
+}
+
 func (pdb *pipelineDB) createJobBuild(jobName string, tx *sql.Tx) (Build, error) {
 	err := pdb.registerJob(tx, jobName)
 	if err != nil {
@@ -948,6 +1256,11 @@ func (pdb *pipelineDB) SaveBuildInput(buildID int, input BuildInput) (SavedVersi
 		return SavedVersionedResource{}, err
 	}
 
+	err = saveVersionGraphChanges(tx, pdb.ID, []int{svr.ID}, []int{buildID})
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -957,7 +1270,7 @@ func (pdb *pipelineDB) SaveBuildInput(buildID int, input BuildInput) (SavedVersi
 }
 
 func (pdb *pipelineDB) saveBuildInput(tx *sql.Tx, buildID int, input BuildInput) (SavedVersionedResource, error) {
-	svr, err := pdb.saveVersionedResource(tx, input.VersionedResource)
+	svr, _, err := pdb.saveVersionedResource(tx, input.VersionedResource)
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -988,7 +1301,7 @@ func (pdb *pipelineDB) SaveBuildOutput(buildID int, vr VersionedResource, explic
 
 	defer tx.Rollback()
 
-	svr, err := pdb.saveVersionedResource(tx, vr)
+	svr, _, err := pdb.saveVersionedResource(tx, vr)
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -1001,6 +1314,11 @@ func (pdb *pipelineDB) SaveBuildOutput(buildID int, vr VersionedResource, explic
 		return SavedVersionedResource{}, err
 	}
 
+	err = saveVersionGraphChanges(tx, pdb.ID, []int{svr.ID}, []int{buildID})
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -1009,6 +1327,60 @@ func (pdb *pipelineDB) SaveBuildOutput(buildID int, vr VersionedResource, explic
 	return svr, nil
 }
 
+// MarkVersionedResourceDeleted records that a version no longer exists, e.g.
+// after a delete step, so that it is never again chosen as an input. Versions
+// which were never discovered are left alone.
+func (pdb *pipelineDB) MarkVersionedResourceDeleted(vr VersionedResource) error {
+	savedResource, err := pdb.GetResource(vr.Resource)
+	if err != nil {
+		return err
+	}
+
+	versionJSON, err := json.Marshal(vr.Version)
+	if err != nil {
+		return err
+	}
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		UPDATE versioned_resources
+		SET deleted = true
+		WHERE resource_id = $1
+		AND type = $2
+		AND version = $3
+		RETURNING id
+	`, savedResource.ID, vr.Type, string(versionJSON))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	versionIDs := []int{}
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		versionIDs = append(versionIDs, id)
+	}
+
+	err = saveVersionGraphChanges(tx, pdb.ID, versionIDs, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (pdb *pipelineDB) GetJobBuildForInputs(job string, inputs []BuildInput) (Build, error) {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -1283,6 +1655,7 @@ func (pdb *pipelineDB) GetNextPendingBuildBySerialGroup(jobName string, serialGr
 		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
 				AND jsg.serial_group IN (`+strings.Join(refs, ",")+`)
 		WHERE b.status = 'pending'
+			AND b.scheduled = false
 			AND j.pipeline_id = $1
 		ORDER BY b.id ASC
 		LIMIT 1
@@ -1361,17 +1734,20 @@ func (pdb *pipelineDB) ScheduleBuild(buildID int, jobConfig atc.JobConfig) (bool
 		return false, err
 	}
 
+	build, err := pdb.getBuild(buildID)
+	if err != nil {
+		return false, err
+	}
+
 	if pipelinePaused {
 		pdb.logger.Debug("build-did-not-schedule", lager.Data{
 			"reason":  "pipeline-paused",
 			"buildID": string(buildID),
 		})
-		return false, nil
-	}
 
-	build, err := pdb.getBuild(buildID)
-	if err != nil {
-		return false, err
+		pdb.saveBuildSchedulingStatus(jobConfig.Name, build, SchedulingReasonPipelinePaused)
+
+		return false, nil
 	}
 
 	// The function needs to be idempotent, that's why this isn't in CanBuildBeScheduled
@@ -1395,16 +1771,50 @@ func (pdb *pipelineDB) ScheduleBuild(buildID int, jobConfig atc.JobConfig) (bool
 			return false, err
 		}
 
+		if updated {
+			pdb.saveBuildSchedulingStatus(jobConfig.Name, build, SchedulingReasonScheduled)
+		}
+
 		return updated, nil
 	} else {
 		pdb.logger.Debug("build-did-not-schedule", lager.Data{
 			"reason":  reason,
 			"buildID": string(buildID),
 		})
+
+		if schedulingReason, found := jobServiceSchedulingReasons[reason]; found {
+			pdb.saveBuildSchedulingStatus(jobConfig.Name, build, schedulingReason)
+		}
+
 		return false, nil
 	}
 }
 
+// jobServiceSchedulingReasons maps the reasons JobService gives for not
+// scheduling a build to the ones recorded for the job. Reasons that don't
+// concern the job, e.g. that the build has already started, are omitted.
+var jobServiceSchedulingReasons = map[string]SchedulingReason{
+	"job-paused":            SchedulingReasonJobPaused,
+	"other-builds-running":  SchedulingReasonMaxInFlightReached,
+	"not-next-most-pending": SchedulingReasonNotNextPending,
+}
+
+// saveBuildSchedulingStatus records why a build of the job was or was not
+// scheduled. The status is only informational, so failing to record it does
+// not fail scheduling.
+func (pdb *pipelineDB) saveBuildSchedulingStatus(job string, build Build, reason SchedulingReason) {
+	err := pdb.SaveJobSchedulingStatus(job, SchedulingStatus{
+		Reason:    reason,
+		BuildName: build.Name,
+	})
+	if err != nil {
+		pdb.logger.Error("failed-to-save-scheduling-status", err, lager.Data{
+			"job":   job,
+			"build": build.ID,
+		})
+	}
+}
+
 func (pdb *pipelineDB) IsPaused() (bool, error) {
 	var paused bool
 
@@ -1496,27 +1906,17 @@ func (pdb *pipelineDB) GetLatestInputVersions(jobName string, inputs []atc.JobIn
 		return []BuildInput{}, err
 	}
 
-	scannedValues := []interface{}{}
-	for _ = range inputs {
-		var id int
-		scannedValues = append(scannedValues, &id)
-		var enabled bool
-		scannedValues = append(scannedValues, &enabled)
+	config, _, err := pdb.GetConfig()
+	if err != nil {
+		return []BuildInput{}, err
 	}
 
 	dbResources := map[string]SavedResource{}
+	pinnedVersionIDs := map[string]int{}
+	passedJobIDs := map[string]int{}
 
-	inputSelects := []string{}
-	inputFromAliases := []string{"builds b"}
-	inputWheres := []string{}
-	inputOrders := []string{"b.id DESC"}
-	inputParams := []interface{}{}
-
-	inputParams = append(inputParams, job.ID)
-	jobIdParam := len(inputParams)
-	inputWheres = append(inputWheres, fmt.Sprintf("b.job_id = $%d", jobIdParam))
-
-	for i, input := range inputs {
+	inputConfigs := []algorithm.InputConfig{}
+	for _, input := range inputs {
 		dbResource, found := dbResources[input.Resource]
 		if !found {
 			var err error
@@ -1526,146 +1926,66 @@ func (pdb *pipelineDB) GetLatestInputVersions(jobName string, inputs []atc.JobIn
 			}
 
 			dbResources[input.Resource] = dbResource
-		}
-
-		inputSelects = append(inputSelects, fmt.Sprintf("i%d.versioned_resource_id", i+1))
-		inputFromAliases = append(inputFromAliases, fmt.Sprintf("build_inputs i%d", i+1))
-		inputFromAliases = append(inputFromAliases, fmt.Sprintf("versioned_resources vr%d", i+1))
-		inputSelects = append(inputSelects, fmt.Sprintf("vr%d.enabled", i+1))
-		inputOrders = append(inputOrders, fmt.Sprintf("vr%d.id ASC", i+1))
-
-		inputParams = append(inputParams, input.Name)
-		inputNameParam := len(inputParams)
-		inputWheres = append(inputWheres, fmt.Sprintf("i%d.name = $%d", i+1, inputNameParam))
-
-		inputParams = append(inputParams, dbResource.ID)
-		resourceIDParam := len(inputParams)
-		inputWheres = append(inputWheres, fmt.Sprintf("vr%d.resource_id = $%d", i+1, resourceIDParam))
-
-		inputWheres = append(inputWheres, fmt.Sprintf("b.id = i%d.build_id", i+1))
-		inputWheres = append(inputWheres, fmt.Sprintf("vr%d.id = i%d.versioned_resource_id", i+1, i+1))
-	}
-
-	inputsQuery := fmt.Sprintf(
-		`
-			SELECT %s
-			FROM %s
-			WHERE %s
-			ORDER BY %s
-			LIMIT 1
-		`,
-		strings.Join(inputSelects, ", "),
-		strings.Join(inputFromAliases, ", "),
-		strings.Join(inputWheres, "\nAND "),
-		strings.Join(inputOrders, ", "),
-	)
-
-	err = pdb.conn.QueryRow(inputsQuery, inputParams...).Scan(scannedValues...)
-	if err != nil && err != sql.ErrNoRows {
-		return []BuildInput{}, err
-	}
-
-	// get latest build inputs (ensure all have same build id)
-	// append WHERE id >= existing_id for each input
-
-	selectIds := []string{}
-	orderBy := []string{}
-	fromAliases := []string{}
-	conditions := []string{}
-	params := []interface{}{}
-
-	passedJobs := map[string]int{}
-
-	for i, input := range inputs {
-		fromAliases = append(fromAliases, fmt.Sprintf("versioned_resources v%d", i+1))
 
-		if len(scannedValues) != 0 {
-			index := i * 2
+			pinnedVersionIDs[input.Resource], err = pdb.pinnedVersionID(config, dbResource)
+			if err != nil {
+				return []BuildInput{}, err
+			}
+		}
 
-			if *scannedValues[index+1].(*bool) {
-				params = append(params, *(scannedValues[index].(*int)))
-				paramIndex := len(params)
+		inputConfig := algorithm.InputConfig{
+			Name:            input.Name,
+			ResourceID:      dbResource.ID,
+			PinnedVersionID: pinnedVersionIDs[input.Resource],
+			Every:           input.Version.Every(),
+		}
 
-				conditions = append(conditions, fmt.Sprintf("v%d.id >= $%d", i+1, paramIndex))
+		if version, pinned := input.Version.Pinned(); pinned {
+			versionJSON, err := json.Marshal(version)
+			if err != nil {
+				return []BuildInput{}, err
 			}
-		}
 
-		params = append(params, dbResources[input.Resource].ID)
-		resourceIDParam := len(params)
-		conditions = append(conditions, fmt.Sprintf("v%d.resource_id = $%d", i+1, resourceIDParam))
+			inputConfig.PinnedVersion = string(versionJSON)
+		}
 
 		for _, name := range input.Passed {
-			idx, found := passedJobs[name]
+			jobID, found := passedJobIDs[name]
 			if !found {
-				idx = len(passedJobs)
-				passedJobs[name] = idx
-
-				fromAliases = append(fromAliases, fmt.Sprintf("builds b%d", idx+1))
-
 				dbJob, err := pdb.GetJob(name)
 				if err != nil {
 					return []BuildInput{}, err
 				}
 
-				params = append(params, dbJob.ID)
-				jobIDParam := len(params)
-				conditions = append(conditions, fmt.Sprintf("b%d.job_id = $%d", idx+1, jobIDParam))
-
-				params = append(params, string(StatusSucceeded))
-				buildStatusParam := len(params)
-				conditions = append(conditions, fmt.Sprintf("b%d.status = $%d", idx+1, buildStatusParam))
+				jobID = dbJob.ID
+				passedJobIDs[name] = jobID
 			}
 
-			fromAliases = append(fromAliases, fmt.Sprintf("build_outputs v%db%d", i+1, idx+1))
-
-			conditions = append(conditions, fmt.Sprintf("v%db%d.versioned_resource_id = v%d.id", i+1, idx+1, i+1))
-
-			conditions = append(conditions, fmt.Sprintf("v%db%d.build_id = b%d.id", i+1, idx+1, idx+1))
+			inputConfig.Passed = append(inputConfig.Passed, jobID)
 		}
 
-		conditions = append(conditions, fmt.Sprintf("v%d.enabled", i+1))
-
-		selectIds = append(selectIds, fmt.Sprintf("v%d.id", i+1))
-
-		orderBy = append(orderBy, fmt.Sprintf("v%d.id DESC", i+1))
+		inputConfigs = append(inputConfigs, inputConfig)
 	}
 
-	buildInputs := []BuildInput{}
-
-	ids := []interface{}{}
-	for _ = range inputs {
-		var id int
-		ids = append(ids, &id)
+	versionIDs, found, err := pdb.versionGraph.resolve(pdb.conn, job.ID, inputConfigs)
+	if err != nil {
+		return []BuildInput{}, err
 	}
-	qurey := fmt.Sprintf(
-		`
-				SELECT %s
-				FROM %s
-				WHERE %s
-				ORDER BY %s
-				LIMIT 1
-			`,
-		strings.Join(selectIds, ", "),
-		strings.Join(fromAliases, ", "),
-		strings.Join(conditions, "\nAND "),
-		strings.Join(orderBy, ", "),
-	)
 
-	err = pdb.conn.QueryRow(qurey, params...).Scan(ids...)
+	if !found {
+		return nil, ErrNoVersions
+	}
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNoVersions
-		}
+	return pdb.buildInputsForVersions(inputs, versionIDs)
+}
 
-		return nil, err
-	}
+func (pdb *pipelineDB) buildInputsForVersions(inputs []atc.JobInput, versionIDs []int) ([]BuildInput, error) {
+	buildInputs := []BuildInput{}
 
-	for i, idPtr := range ids {
-		id := *(idPtr.(*int))
+	for i, id := range versionIDs {
 		svr := SavedVersionedResource{
 			ID:      id,
-			Enabled: true, // this is inherent with the following query
+			Enabled: true, // only enabled versions are ever chosen
 		}
 
 		var source, version, metadata string
@@ -1704,6 +2024,40 @@ func (pdb *pipelineDB) GetLatestInputVersions(jobName string, inputs []atc.JobIn
 	return buildInputs, nil
 }
 
+// pinnedVersionID returns the ID of the version the resource is pinned to,
+// or 0 if it is not pinned. A version pinned in the pipeline config takes
+// precedence over one pinned via the API.
+func (pdb *pipelineDB) pinnedVersionID(config atc.Config, resource SavedResource) (int, error) {
+	resourceConfig, found := config.Resources.Lookup(resource.Name)
+	if !found || resourceConfig.Version == nil {
+		return resource.PinnedVersionID, nil
+	}
+
+	versionJSON, err := json.Marshal(resourceConfig.Version)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = pdb.conn.QueryRow(`
+		SELECT id
+		FROM versioned_resources
+		WHERE resource_id = $1
+			AND version = $2
+		ORDER BY id DESC
+		LIMIT 1
+	`, resource.ID, string(versionJSON)).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrNoVersions
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
 func (pdb *pipelineDB) PauseJob(job string) error {
 	return pdb.updatePausedJob(job, true)
 }
@@ -1874,8 +2228,10 @@ func (pdb *pipelineDB) scanBuild(row scannable) (Build, error) {
 	var engine, engineMetadata, jobName, pipelineName sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
+	var rerunOf sql.NullInt64
+	var inputsDetermined bool
 
-	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &jobName, &pipelineName)
+	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &rerunOf, &inputsDetermined, &jobName, &pipelineName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return Build{}, ErrNoBuild
@@ -1898,6 +2254,9 @@ func (pdb *pipelineDB) scanBuild(row scannable) (Build, error) {
 
 		StartTime: startTime.Time,
 		EndTime:   endTime.Time,
+
+		RerunOf:          int(rerunOf.Int64),
+		InputsDetermined: inputsDetermined,
 	}
 
 	if err != nil {
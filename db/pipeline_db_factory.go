@@ -20,6 +20,8 @@ type pipelineDBFactory struct {
 	conn        Conn
 	bus         *notificationsBus
 	pipelinesDB PipelinesDB
+
+	versionGraphs *versionGraphs
 }
 
 func NewPipelineDBFactory(
@@ -34,6 +36,8 @@ func NewPipelineDBFactory(
 		conn:        sqldbConnection,
 		bus:         bus,
 		pipelinesDB: pipelinesDB,
+
+		versionGraphs: newVersionGraphs(),
 	}
 }
 
@@ -53,6 +57,8 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
+		versionGraph: pdbf.versionGraphs.forPipeline(pipeline.ID),
+
 		SavedPipeline: pipeline,
 	}
 }
@@ -69,12 +75,5 @@ func (pdbf *pipelineDBFactory) BuildDefault() (PipelineDB, error) {
 		return nil, ErrNoPipelines
 	}
 
-	return &pipelineDB{
-		logger: pdbf.logger,
-
-		conn: pdbf.conn,
-		bus:  pdbf.bus,
-
-		SavedPipeline: orderedPipelines[0],
-	}, nil
+	return pdbf.Build(orderedPipelines[0]), nil
 }
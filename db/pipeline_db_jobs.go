@@ -1 +1,72 @@
 package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+)
+
+func (pdb *pipelineDB) SaveJobSchedulingStatus(job string, status SchedulingStatus) error {
+	statusJSON, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = pdb.registerJob(tx, job)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE jobs
+		SET scheduling_status = $1, scheduling_status_time = now()
+		WHERE name = $2
+			AND pipeline_id = $3
+	`, string(statusJSON), job, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) GetJobSchedulingStatus(job string) (SchedulingStatus, error) {
+	var statusJSON sql.NullString
+	var statusTime pq.NullTime
+
+	err := pdb.conn.QueryRow(`
+		SELECT scheduling_status, scheduling_status_time
+		FROM jobs
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, job, pdb.ID).Scan(&statusJSON, &statusTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SchedulingStatus{}, ErrNoSchedulingStatus
+		}
+
+		return SchedulingStatus{}, err
+	}
+
+	if !statusJSON.Valid {
+		return SchedulingStatus{}, ErrNoSchedulingStatus
+	}
+
+	var status SchedulingStatus
+	err = json.Unmarshal([]byte(statusJSON.String), &status)
+	if err != nil {
+		return SchedulingStatus{}, err
+	}
+
+	status.Time = statusTime.Time
+
+	return status, nil
+}
@@ -369,6 +369,87 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("saving new resource versions", func() {
+			resourceConfig := atc.ResourceConfig{
+				Name:   "some-resource",
+				Type:   "some-type",
+				Source: atc.Source{"some": "source"},
+			}
+
+			It("returns only the versions that had not been saved before", func() {
+				newVersions, err := pipelineDB.SaveNewResourceVersions(resourceConfig, []atc.Version{
+					{"version": "2"},
+					{"version": "3"},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(newVersions).Should(Equal([]atc.Version{{"version": "2"}, {"version": "3"}}))
+
+				newVersions, err = pipelineDB.SaveNewResourceVersions(resourceConfig, []atc.Version{
+					{"version": "1"},
+					{"version": "2"},
+					{"version": "3"},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(newVersions).Should(Equal([]atc.Version{{"version": "1"}}))
+			})
+
+			It("leaves the versions that were already saved where they were", func() {
+				_, err := pipelineDB.SaveNewResourceVersions(resourceConfig, []atc.Version{
+					{"version": "1"},
+					{"version": "2"},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = pipelineDB.SaveNewResourceVersions(resourceConfig, []atc.Version{
+					{"version": "1"},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				resource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVR, err := pipelineDB.GetLatestVersionedResource(resource)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedVR.Version).Should(Equal(db.Version{"version": "2"}))
+			})
+		})
+
+		Describe("getting a versioned resource by ID", func() {
+			It("returns the version, only within the pipeline", func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				resource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVR, err := pipelineDB.GetLatestVersionedResource(resource)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				foundVR, err := pipelineDB.GetVersionedResource(savedVR.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(foundVR.ID).Should(Equal(savedVR.ID))
+				Ω(foundVR.Enabled).Should(BeTrue())
+				Ω(foundVR.Resource).Should(Equal("some-resource"))
+				Ω(foundVR.Type).Should(Equal("some-type"))
+				Ω(foundVR.Source).Should(Equal(db.Source{"some": "source"}))
+				Ω(foundVR.Version).Should(Equal(db.Version{"version": "1"}))
+				Ω(foundVR.PipelineName).Should(Equal(pipelineDB.GetPipelineName()))
+
+				_, err = otherPipelineDB.GetVersionedResource(savedVR.ID)
+				Ω(err).Should(Equal(db.ErrNoVersions))
+			})
+
+			It("returns ErrNoVersions if the version does not exist", func() {
+				_, err := pipelineDB.GetVersionedResource(42)
+				Ω(err).Should(Equal(db.ErrNoVersions))
+			})
+		})
+
 		Describe("enabling and disabling versioned resources", func() {
 			var resource db.SavedResource
 
@@ -595,6 +676,373 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("marking versioned resources as deleted", func() {
+			var resource db.SavedResource
+
+			BeforeEach(func() {
+				var err error
+				resource, err = pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("prevents the resource version from being a candidate for build inputs", func() {
+				err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVR1, err := pipelineDB.GetLatestVersionedResource(resource)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "2"}})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVR2, err := pipelineDB.GetLatestVersionedResource(resource)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				jobBuildInputs := []atc.JobInput{
+					{
+						Name:     "some-input-name",
+						Resource: "some-resource",
+					},
+				}
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR2.VersionedResource,
+					},
+				}))
+
+				err = pipelineDB.MarkVersionedResourceDeleted(db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  db.Version{"version": "2"},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR1.VersionedResource,
+					},
+				}))
+
+				By("not being undone when the version is discovered again")
+
+				err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "2"}})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR1.VersionedResource,
+					},
+				}))
+			})
+
+			It("ignores versions that were never discovered", func() {
+				err := pipelineDB.MarkVersionedResourceDeleted(db.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  db.Version{"version": "unknown"},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Describe("pinning and unpinning resources", func() {
+			var resource db.SavedResource
+			var savedVR1, savedVR2 db.SavedVersionedResource
+
+			jobBuildInputs := []atc.JobInput{
+				{
+					Name:     "some-input-name",
+					Resource: "some-resource",
+				},
+			}
+
+			BeforeEach(func() {
+				var err error
+				resource, err = pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "1"}})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVR1, err = pipelineDB.GetLatestVersionedResource(resource)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"some": "source"},
+				}, []atc.Version{{"version": "2"}})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVR2, err = pipelineDB.GetLatestVersionedResource(resource)
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("starts out as unpinned", func() {
+				Ω(resource.PinnedVersionID).Should(BeZero())
+				Ω(resource.PinnedVersion).Should(BeNil())
+			})
+
+			It("returns ErrNoVersionedResource if the version is bogus", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", 42)
+				Ω(err).Should(Equal(db.ErrNoVersionedResource))
+			})
+
+			It("returns ErrNoVersionedResource if the version belongs to another resource", func() {
+				err := pipelineDB.PinVersionedResource("some-other-resource", savedVR1.ID)
+				Ω(err).Should(Equal(db.ErrNoVersionedResource))
+			})
+
+			It("can be pinned and unpinned", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", savedVR1.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				pinnedResource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(pinnedResource.PinnedVersionID).Should(Equal(savedVR1.ID))
+				Ω(pinnedResource.PinnedVersion).Should(Equal(db.Version{"version": "1"}))
+
+				err = pipelineDB.UnpinResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				unpinnedResource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(unpinnedResource.PinnedVersionID).Should(BeZero())
+				Ω(unpinnedResource.PinnedVersion).Should(BeNil())
+			})
+
+			It("restricts the build inputs to the pinned version until it is unpinned", func() {
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR2.VersionedResource,
+					},
+				}))
+
+				err := pipelineDB.PinVersionedResource("some-resource", savedVR1.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR1.VersionedResource,
+					},
+				}))
+
+				err = pipelineDB.UnpinResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR2.VersionedResource,
+					},
+				}))
+			})
+
+			It("does not yield the pinned version if it is disabled", func() {
+				err := pipelineDB.PinVersionedResource("some-resource", savedVR1.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = pipelineDB.DisableVersionedResource(savedVR1.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)
+				Ω(err).Should(Equal(db.ErrNoVersions))
+			})
+
+			Context("when the version is pinned in the pipeline config", func() {
+				BeforeEach(func() {
+					_, configVersion, err := pipelineDB.GetConfig()
+					Ω(err).ShouldNot(HaveOccurred())
+
+					pinnedConfig := config
+					pinnedConfig.Resources = atc.ResourceConfigs{
+						{
+							Name:    "some-resource",
+							Type:    "some-type",
+							Source:  atc.Source{"some": "source"},
+							Version: atc.Version{"version": "1"},
+						},
+					}
+
+					_, err = sqlDB.SaveConfig("a-pipeline-name", pinnedConfig, configVersion, db.PipelineNoChange)
+					Ω(err).ShouldNot(HaveOccurred())
+				})
+
+				It("restricts the build inputs to the configured version", func() {
+					Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+						{
+							Name:              "some-input-name",
+							VersionedResource: savedVR1.VersionedResource,
+						},
+					}))
+				})
+
+				It("takes precedence over a version pinned via the API", func() {
+					err := pipelineDB.PinVersionedResource("some-resource", savedVR2.ID)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+						{
+							Name:              "some-input-name",
+							VersionedResource: savedVR1.VersionedResource,
+						},
+					}))
+				})
+
+				Context("when the configured version does not exist", func() {
+					BeforeEach(func() {
+						_, configVersion, err := pipelineDB.GetConfig()
+						Ω(err).ShouldNot(HaveOccurred())
+
+						pinnedConfig := config
+						pinnedConfig.Resources = atc.ResourceConfigs{
+							{
+								Name:    "some-resource",
+								Type:    "some-type",
+								Source:  atc.Source{"some": "source"},
+								Version: atc.Version{"version": "bogus"},
+							},
+						}
+
+						_, err = sqlDB.SaveConfig("a-pipeline-name", pinnedConfig, configVersion, db.PipelineNoChange)
+						Ω(err).ShouldNot(HaveOccurred())
+					})
+
+					It("returns ErrNoVersions", func() {
+						_, err := pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)
+						Ω(err).Should(Equal(db.ErrNoVersions))
+					})
+				})
+			})
+		})
+
+		Describe("getting every version or a specific version of a resource", func() {
+			var savedVRs []db.SavedVersionedResource
+
+			BeforeEach(func() {
+				resource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedVRs = nil
+				for i := 1; i <= 3; i++ {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": fmt.Sprintf("%d", i)}})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					savedVR, err := pipelineDB.GetLatestVersionedResource(resource)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					savedVRs = append(savedVRs, savedVR)
+				}
+			})
+
+			It("yields each version in order when configured for every version", func() {
+				jobBuildInputs := []atc.JobInput{
+					{
+						Name:     "some-input-name",
+						Resource: "some-resource",
+						Version:  atc.VersionEvery,
+					},
+				}
+
+				for _, savedVR := range savedVRs {
+					Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+						{
+							Name:              "some-input-name",
+							VersionedResource: savedVR.VersionedResource,
+						},
+					}))
+
+					build, err := pipelineDB.CreateJobBuild("a-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+						Name:              "some-input-name",
+						VersionedResource: savedVR.VersionedResource,
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+				}
+
+				// once every version has been used, the latest is yielded
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVRs[2].VersionedResource,
+					},
+				}))
+			})
+
+			It("carries on from the latest version used when a rerun goes back to an older one", func() {
+				jobBuildInputs := []atc.JobInput{
+					{
+						Name:     "some-input-name",
+						Resource: "some-resource",
+						Version:  atc.VersionEvery,
+					},
+				}
+
+				for _, savedVR := range []db.SavedVersionedResource{savedVRs[0], savedVRs[1], savedVRs[0]} {
+					build, err := pipelineDB.CreateJobBuild("a-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+						Name:              "some-input-name",
+						VersionedResource: savedVR.VersionedResource,
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+				}
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVRs[2].VersionedResource,
+					},
+				}))
+			})
+
+			It("yields the specific version when configured with one", func() {
+				jobBuildInputs := []atc.JobInput{
+					{
+						Name:     "some-input-name",
+						Resource: "some-resource",
+						Version:  atc.VersionConfig(`{"version":"2"}`),
+					},
+				}
+
+				Ω(pipelineDB.GetLatestInputVersions("a-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVRs[1].VersionedResource,
+					},
+				}))
+			})
+		})
+
 		Describe("saving versioned resources", func() {
 			It("updates the latest versioned resource", func() {
 				err := pipelineDB.SaveResourceVersions(
@@ -880,6 +1328,141 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("job scheduling statuses", func() {
+			status := db.SchedulingStatus{
+				Reason:    db.SchedulingReasonPassedUnsatisfied,
+				Input:     "some-input",
+				Resource:  "some-resource",
+				PassedJob: "some-upstream-job",
+			}
+
+			It("returns ErrNoSchedulingStatus until one is saved", func() {
+				_, err := pipelineDB.GetJobSchedulingStatus("some-job")
+				Ω(err).Should(Equal(db.ErrNoSchedulingStatus))
+
+				err = pipelineDB.SaveJobSchedulingStatus("some-job", status)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedStatus, err := pipelineDB.GetJobSchedulingStatus("some-job")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedStatus.Time).ShouldNot(BeZero())
+
+				savedStatus.Time = time.Time{}
+				Ω(savedStatus).Should(Equal(status))
+			})
+
+			It("replaces the previously saved status", func() {
+				err := pipelineDB.SaveJobSchedulingStatus("some-job", status)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = pipelineDB.SaveJobSchedulingStatus("some-job", db.SchedulingStatus{
+					Reason: db.SchedulingReasonNoTriggerInputs,
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedStatus, err := pipelineDB.GetJobSchedulingStatus("some-job")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedStatus.Reason).Should(Equal(db.SchedulingReasonNoTriggerInputs))
+				Ω(savedStatus.PassedJob).Should(BeEmpty())
+			})
+
+			It("is scoped to the pipeline", func() {
+				err := pipelineDB.SaveJobSchedulingStatus("some-job", status)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = otherPipelineDB.GetJobSchedulingStatus("some-job")
+				Ω(err).Should(Equal(db.ErrNoSchedulingStatus))
+			})
+		})
+
+		Describe("CreateJobRerunBuild", func() {
+			var originalBuild db.Build
+
+			vr := db.VersionedResource{
+				PipelineName: "a-pipeline-name",
+				Resource:     "some-resource",
+				Type:         "some-type",
+				Source:       db.Source{"some": "source"},
+				Version:      db.Version{"ver": "1"},
+			}
+
+			BeforeEach(func() {
+				var err error
+				originalBuild, err = pipelineDB.CreateJobBuildWithInputs("some-job", []db.BuildInput{
+					{
+						Name:              "some-input",
+						VersionedResource: vr,
+					},
+				})
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("creates a new build of the job that is a rerun of the original", func() {
+				rerunBuild, err := pipelineDB.CreateJobRerunBuild(originalBuild.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(rerunBuild.ID).ShouldNot(Equal(originalBuild.ID))
+				Ω(rerunBuild.Name).Should(Equal("2"))
+				Ω(rerunBuild.JobName).Should(Equal("some-job"))
+				Ω(rerunBuild.Status).Should(Equal(db.StatusPending))
+				Ω(rerunBuild.RerunOf).Should(Equal(originalBuild.ID))
+				Ω(rerunBuild.InputsDetermined).Should(BeTrue())
+
+				foundBuild, err := pipelineDB.GetJobBuild("some-job", "2")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(foundBuild.RerunOf).Should(Equal(originalBuild.ID))
+
+				foundBuild, err = pipelineDB.GetJobBuild("some-job", "1")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(foundBuild.RerunOf).Should(BeZero())
+			})
+
+			It("copies the inputs of the original build", func() {
+				rerunBuild, err := pipelineDB.CreateJobRerunBuild(originalBuild.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				inputs, _, err := pipelineDB.GetBuildResources(rerunBuild.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(inputs).Should(ConsistOf([]db.BuildInput{
+					{Name: "some-input", VersionedResource: vr, FirstOccurrence: false},
+				}))
+			})
+
+			Context("when the build's inputs were never determined", func() {
+				It("returns ErrInputsNotDetermined without creating a build", func() {
+					undeterminedBuild, err := pipelineDB.CreateJobBuild("some-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.CreateJobRerunBuild(undeterminedBuild.ID)
+					Ω(err).Should(Equal(db.ErrInputsNotDetermined))
+
+					builds, err := pipelineDB.GetAllJobBuilds("some-job")
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(builds).Should(HaveLen(2))
+				})
+			})
+
+			Context("when the build belongs to another pipeline", func() {
+				It("returns ErrNoBuild", func() {
+					otherBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.CreateJobRerunBuild(otherBuild.ID)
+					Ω(err).Should(Equal(db.ErrNoBuild))
+				})
+			})
+
+			Context("when the build is a one-off build", func() {
+				It("returns ErrNoBuild", func() {
+					oneOff, err := sqlDB.CreateOneOffBuild()
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.CreateJobRerunBuild(oneOff.ID)
+					Ω(err).Should(Equal(db.ErrNoBuild))
+				})
+			})
+		})
+
 		Describe("saving builds for scheduling", func() {
 			buildMetadata := []db.MetadataField{
 				{
@@ -1034,7 +1617,44 @@ var _ = Describe("PipelineDB", func() {
 					input2,
 				})
 				Ω(err).ShouldNot(HaveOccurred())
+				Ω(foundBuild.ID).Should(Equal(build.ID))
+				Ω(foundBuild.InputsDetermined).Should(BeTrue())
+			})
+
+			It("does not determine a build's inputs twice", func() {
+				build, created, err := pipelineDB.CreateJobBuildForCandidateInputs("some-job")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(created).Should(BeTrue())
+
+				err = pipelineDB.UseInputsForBuild(build.ID, []db.BuildInput{input1})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = pipelineDB.UseInputsForBuild(build.ID, []db.BuildInput{input2})
+				Ω(err).Should(Equal(db.ErrInputsAlreadyDetermined))
+
+				buildInputs, _, err := pipelineDB.GetBuildResources(build.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(buildInputs).Should(HaveLen(1))
+				Ω(buildInputs[0].Name).Should(Equal("some-input"))
+			})
+
+			It("can create a build with its inputs already determined", func() {
+				build, err := pipelineDB.CreateJobBuildWithInputs("some-job", inputs)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(build.Name).Should(Equal("1"))
+				Ω(build.Status).Should(Equal(db.StatusPending))
+				Ω(build.InputsDetermined).Should(BeTrue())
+
+				foundBuild, err := pipelineDB.GetJobBuildForInputs("some-job", inputs)
+				Ω(err).ShouldNot(HaveOccurred())
 				Ω(foundBuild).Should(Equal(build))
+
+				_, created, err := pipelineDB.CreateJobBuildForCandidateInputs("some-job")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(created).Should(BeTrue())
+
+				err = pipelineDB.UseInputsForBuild(build.ID, inputs)
+				Ω(err).Should(Equal(db.ErrInputsAlreadyDetermined))
 			})
 		})
 
@@ -1363,6 +1983,16 @@ var _ = Describe("PipelineDB", func() {
 						Ω(err).ShouldNot(HaveOccurred())
 						Ω(scheduled).Should(BeFalse())
 					})
+
+					It("records that the pipeline is paused", func() {
+						_, err := pipelineDB.ScheduleBuild(firstBuild.ID, jobConfig)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						status, err := pipelineDB.GetJobSchedulingStatus(job.Name)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(status.Reason).Should(Equal(db.SchedulingReasonPipelinePaused))
+						Ω(status.BuildName).Should(Equal("1"))
+					})
 				})
 			})
 
@@ -1430,6 +2060,16 @@ var _ = Describe("PipelineDB", func() {
 						Ω(err).ShouldNot(HaveOccurred())
 						Ω(scheduled).Should(BeFalse())
 					})
+
+					It("records that the job is paused", func() {
+						_, err := pipelineDB.ScheduleBuild(firstBuild.ID, jobConfig)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						status, err := pipelineDB.GetJobSchedulingStatus(job.Name)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(status.Reason).Should(Equal(db.SchedulingReasonJobPaused))
+						Ω(status.BuildName).Should(Equal("1"))
+					})
 				})
 			})
 
@@ -1469,6 +2109,20 @@ var _ = Describe("PipelineDB", func() {
 					Ω(scheduled).Should(BeTrue())
 				})
 
+				It("records that the build was scheduled", func() {
+					_, err := pipelineDB.GetJobSchedulingStatus(job.Name)
+					Ω(err).Should(Equal(db.ErrNoSchedulingStatus))
+
+					_, err = pipelineDB.ScheduleBuild(firstBuild.ID, jobConfig)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					status, err := pipelineDB.GetJobSchedulingStatus(job.Name)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(status.Reason).Should(Equal(db.SchedulingReasonScheduled))
+					Ω(status.BuildName).Should(Equal("1"))
+					Ω(status.Time).ShouldNot(BeZero())
+				})
+
 				Describe("twice", func() {
 					It("succeeds idempotently", func() {
 						scheduled, err := pipelineDB.ScheduleBuild(firstBuild.ID, jobConfig)
@@ -1557,6 +2211,16 @@ var _ = Describe("PipelineDB", func() {
 								Ω(err).ShouldNot(HaveOccurred())
 								Ω(scheduled).Should(BeFalse())
 							})
+
+							It("records that the build is waiting for the earlier pending build", func() {
+								_, err := pipelineDB.ScheduleBuild(secondBuild.ID, serialJobConfig)
+								Ω(err).ShouldNot(HaveOccurred())
+
+								status, err := pipelineDB.GetJobSchedulingStatus(job.Name)
+								Ω(err).ShouldNot(HaveOccurred())
+								Ω(status.Reason).Should(Equal(db.SchedulingReasonNotNextPending))
+								Ω(status.BuildName).Should(Equal("2"))
+							})
 						})
 					})
 
@@ -1573,6 +2237,16 @@ var _ = Describe("PipelineDB", func() {
 								Ω(err).ShouldNot(HaveOccurred())
 								Ω(scheduled).Should(BeFalse())
 							})
+
+							It("records that the build is waiting for the running build", func() {
+								_, err := pipelineDB.ScheduleBuild(secondBuild.ID, serialJobConfig)
+								Ω(err).ShouldNot(HaveOccurred())
+
+								status, err := pipelineDB.GetJobSchedulingStatus(job.Name)
+								Ω(err).ShouldNot(HaveOccurred())
+								Ω(status.Reason).Should(Equal(db.SchedulingReasonMaxInFlightReached))
+								Ω(status.BuildName).Should(Equal("2"))
+							})
 						})
 
 						for _, s := range []db.Status{db.StatusSucceeded, db.StatusFailed, db.StatusErrored} {
@@ -1789,6 +2463,31 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("scheduling builds of a job with a max in flight", func() {
+			jobConfig := atc.JobConfig{
+				Name:           "some-job",
+				RawMaxInFlight: 2,
+			}
+
+			It("schedules builds until the max are running", func() {
+				var builds []db.Build
+				for i := 0; i < 3; i++ {
+					build, err := pipelineDB.CreateJobBuild(jobConfig.Name)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					builds = append(builds, build)
+				}
+
+				Ω(pipelineDB.ScheduleBuild(builds[0].ID, jobConfig)).Should(BeTrue())
+				Ω(pipelineDB.ScheduleBuild(builds[1].ID, jobConfig)).Should(BeTrue())
+				Ω(pipelineDB.ScheduleBuild(builds[2].ID, jobConfig)).Should(BeFalse())
+
+				Ω(sqlDB.FinishBuild(builds[0].ID, db.StatusSucceeded)).Should(Succeed())
+
+				Ω(pipelineDB.ScheduleBuild(builds[2].ID, jobConfig)).Should(BeTrue())
+			})
+		})
+
 		Context("when a build is created for a job", func() {
 			var build1 db.Build
 			var jobConfig atc.JobConfig
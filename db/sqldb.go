@@ -21,8 +21,8 @@ type SQLDB struct {
 	bus  *notificationsBus
 }
 
-const buildColumns = "id, name, job_id, status, scheduled, engine, engine_metadata, start_time, end_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, j.name as job_name, p.name as pipeline_name"
+const buildColumns = "id, name, job_id, status, scheduled, engine, engine_metadata, start_time, end_time, rerun_of, inputs_determined"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.rerun_of, b.inputs_determined, j.name as job_name, p.name as pipeline_name"
 
 func NewSQL(
 	logger lager.Logger,
@@ -502,6 +502,28 @@ func (db *SQLDB) FinishBuild(buildID int, status Status) error {
 		return err
 	}
 
+	if status == StatusSucceeded {
+		// the build's outputs can now satisfy passed constraints
+		var pipelineID int
+		err = tx.QueryRow(`
+			SELECT j.pipeline_id
+			FROM builds b
+			INNER JOIN jobs j ON b.job_id = j.id
+			WHERE b.id = $1
+		`, buildID).Scan(&pipelineID)
+		switch err {
+		case nil:
+			err = saveVersionGraphChanges(tx, pipelineID, nil, []int{buildID})
+			if err != nil {
+				return err
+			}
+		case sql.ErrNoRows:
+			// one-off builds don't belong to any pipeline
+		default:
+			return err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -547,6 +569,16 @@ func (db *SQLDB) SaveBuildOutput(buildID int, vr VersionedResource, explicit boo
 	return pipelineDB.SaveBuildOutput(buildID, vr, explicit)
 }
 
+func (db *SQLDB) MarkVersionedResourceDeleted(vr VersionedResource) error {
+	pipelineDBFactory := NewPipelineDBFactory(db.logger, db.conn, db.bus, db)
+	pipelineDB, err := pipelineDBFactory.BuildWithName(vr.PipelineName)
+	if err != nil {
+		return err
+	}
+
+	return pipelineDB.MarkVersionedResourceDeleted(vr)
+}
+
 func (db *SQLDB) SaveBuildEngineMetadata(buildID int, engineMetadata string) error {
 	_, err := db.conn.Exec(`
 		UPDATE builds
@@ -560,6 +592,47 @@ func (db *SQLDB) SaveBuildEngineMetadata(buildID int, engineMetadata string) err
 	return nil
 }
 
+// SaveImageResourceVersion records the version of the image fetched for the
+// task at the given location in the build's plan.
+func (db *SQLDB) SaveImageResourceVersion(buildID int, locationID uint, version atc.Version) error {
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO image_resource_versions (build_id, location_id, version)
+		VALUES ($1, $2, $3)
+	`, buildID, locationID, string(versionJSON))
+
+	return err
+}
+
+func (db *SQLDB) GetImageResourceVersion(buildID int, locationID uint) (atc.Version, bool, error) {
+	var versionJSON string
+	err := db.conn.QueryRow(`
+		SELECT version
+		FROM image_resource_versions
+		WHERE build_id = $1
+			AND location_id = $2
+	`, buildID, locationID).Scan(&versionJSON)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	var version atc.Version
+	err = json.Unmarshal([]byte(versionJSON), &version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return version, true, nil
+}
+
 func (db *SQLDB) GetBuildEvents(buildID int, from uint) (EventSource, error) {
 	notifier, err := newConditionNotifier(db.bus, buildEventsChannel(buildID), func() (bool, error) {
 		return true, nil
@@ -576,10 +649,13 @@ func (db *SQLDB) GetBuildEvents(buildID int, from uint) (EventSource, error) {
 	), nil
 }
 
+// AbortBuild marks the build as aborted. A build that has not yet started is
+// aborted immediately; otherwise its status is only set once it has finished
+// running any hooks, and it is up to whoever is tracking it to do so.
 func (db *SQLDB) AbortBuild(buildID int) error {
 	_, err := db.conn.Exec(`
 		UPDATE builds
-		SET status = 'aborted'
+		SET aborted = true, status = (CASE WHEN status = 'pending' THEN 'aborted' ELSE status END)
 		WHERE id = $1
 	`, buildID)
 	if err != nil {
@@ -598,7 +674,7 @@ func (db *SQLDB) AbortNotifier(buildID int) (Notifier, error) {
 	return newConditionNotifier(db.bus, buildAbortChannel(buildID), func() (bool, error) {
 		var aborted bool
 		err := db.conn.QueryRow(`
-			SELECT status = 'aborted'
+			SELECT aborted
 			FROM builds
 			WHERE id = $1
 		`, buildID).Scan(&aborted)
@@ -958,8 +1034,10 @@ func scanBuild(row scannable) (Build, error) {
 	var engine, engineMetadata, jobName, pipelineName sql.NullString
 	var startTime pq.NullTime
 	var endTime pq.NullTime
+	var rerunOf sql.NullInt64
+	var inputsDetermined bool
 
-	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &jobName, &pipelineName)
+	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &rerunOf, &inputsDetermined, &jobName, &pipelineName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return Build{}, ErrNoBuild
@@ -979,6 +1057,9 @@ func scanBuild(row scannable) (Build, error) {
 
 		StartTime: startTime.Time,
 		EndTime:   endTime.Time,
+
+		RerunOf:          int(rerunOf.Int64),
+		InputsDetermined: inputsDetermined,
 	}
 
 	if jobID.Valid {
@@ -0,0 +1,394 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/db/algorithm"
+)
+
+// versionGraphChangesKept is how many revisions of changes are kept for
+// bringing loaded version graphs up to date. Graphs that fall further behind
+// are loaded again from scratch.
+const versionGraphChangesKept = 1000
+
+// versionGraphIdleTimeout is how long a version graph is kept without being
+// used. Graphs of pipelines that are paused or destroyed, including those
+// destroyed through other ATCs, are forgotten once they have been idle for
+// this long.
+const versionGraphIdleTimeout = 10 * time.Minute
+
+// versionGraphs holds the version graph of each pipeline, so that they are
+// shared by all of the PipelineDBs built by a factory.
+type versionGraphs struct {
+	lock   sync.Mutex
+	graphs map[int]*versionGraph
+}
+
+func newVersionGraphs() *versionGraphs {
+	return &versionGraphs{
+		graphs: map[int]*versionGraph{},
+	}
+}
+
+func (vgs *versionGraphs) forPipeline(pipelineID int) *versionGraph {
+	vgs.lock.Lock()
+	defer vgs.lock.Unlock()
+
+	graph, found := vgs.graphs[pipelineID]
+	if !found {
+		graph = &versionGraph{
+			pipelineID: pipelineID,
+			owner:      vgs,
+			lastUsed:   time.Now(),
+		}
+
+		vgs.graphs[pipelineID] = graph
+	}
+
+	return graph
+}
+
+// used records that the graph has been used, and forgets the graphs that have
+// been idle for too long.
+func (vgs *versionGraphs) used(graph *versionGraph) {
+	vgs.lock.Lock()
+	defer vgs.lock.Unlock()
+
+	now := time.Now()
+
+	graph.lastUsed = now
+
+	if _, found := vgs.graphs[graph.pipelineID]; !found {
+		vgs.graphs[graph.pipelineID] = graph
+	}
+
+	for pipelineID, other := range vgs.graphs {
+		if now.Sub(other.lastUsed) > versionGraphIdleTimeout {
+			delete(vgs.graphs, pipelineID)
+		}
+	}
+}
+
+func (vgs *versionGraphs) evict(graph *versionGraph) {
+	vgs.lock.Lock()
+	defer vgs.lock.Unlock()
+
+	if vgs.graphs[graph.pipelineID] == graph {
+		delete(vgs.graphs, graph.pipelineID)
+	}
+}
+
+// versionGraph is a pipeline's version graph, loaded when first needed and
+// then kept up to date by replaying the changes recorded against the
+// pipeline's revision since it was loaded.
+type versionGraph struct {
+	pipelineID int
+	owner      *versionGraphs
+
+	// guarded by the owner's lock
+	lastUsed time.Time
+
+	lock     sync.Mutex
+	graph    *algorithm.VersionGraph
+	revision int
+}
+
+func (vg *versionGraph) resolve(conn Conn, jobID int, inputs []algorithm.InputConfig) ([]int, bool, error) {
+	vg.lock.Lock()
+	defer vg.lock.Unlock()
+
+	err := vg.sync(conn)
+	if err != nil {
+		return nil, false, err
+	}
+
+	vg.owner.used(vg)
+
+	versionIDs, found := vg.graph.Resolve(jobID, inputs)
+
+	return versionIDs, found, nil
+}
+
+// evict forgets the graph once its pipeline has been destroyed, so that it
+// is no longer kept in memory.
+func (vg *versionGraph) evict() {
+	vg.lock.Lock()
+	vg.graph = nil
+	vg.lock.Unlock()
+
+	vg.owner.evict(vg)
+}
+
+func (vg *versionGraph) sync(conn Conn) error {
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	// everything must be read from the same snapshot as the revision
+	_, err = tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ`)
+	if err != nil {
+		return err
+	}
+
+	var revision int
+	err = tx.QueryRow(`
+		SELECT version_graph_revision
+		FROM pipelines
+		WHERE id = $1
+	`, vg.pipelineID).Scan(&revision)
+	if err == sql.ErrNoRows {
+		// the pipeline was destroyed, possibly by another ATC
+		vg.graph = nil
+		vg.owner.evict(vg)
+		return ErrPipelineNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if vg.graph != nil {
+		replayed, err := vg.replayChanges(tx)
+		if err != nil {
+			return err
+		}
+
+		if replayed {
+			return tx.Commit()
+		}
+	}
+
+	err = vg.load(tx, revision)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (vg *versionGraph) load(tx *sql.Tx, revision int) error {
+	graph := algorithm.NewVersionGraph()
+
+	err := loadVersionGraph(tx, graph, versionGraphScope{
+		versions: `vr.resource_id IN (SELECT id FROM resources WHERE pipeline_id = $1)`,
+		builds:   `b.job_id IN (SELECT id FROM jobs WHERE pipeline_id = $1)`,
+		params:   []interface{}{vg.pipelineID},
+	})
+	if err != nil {
+		return err
+	}
+
+	vg.graph = graph
+	vg.revision = revision
+
+	return nil
+}
+
+// replayChanges reloads the versions and builds that have changed since the
+// graph's revision. It returns false if some of the changes are no longer
+// kept, in which case the graph must be loaded again.
+func (vg *versionGraph) replayChanges(tx *sql.Tx) (bool, error) {
+	var oldest, latest sql.NullInt64
+	err := tx.QueryRow(`
+		SELECT MIN(revision), MAX(revision)
+		FROM version_graph_changes
+		WHERE pipeline_id = $1
+			AND revision > $2
+	`, vg.pipelineID, vg.revision).Scan(&oldest, &latest)
+	if err != nil {
+		return false, err
+	}
+
+	if !latest.Valid {
+		return true, nil
+	}
+
+	if int(oldest.Int64) != vg.revision+1 {
+		return false, nil
+	}
+
+	err = loadVersionGraph(tx, vg.graph, versionGraphScope{
+		versions: `vr.id IN (
+			SELECT versioned_resource_id
+			FROM version_graph_changes
+			WHERE pipeline_id = $1
+				AND revision > $2
+		)`,
+		builds: `b.id IN (
+			SELECT build_id
+			FROM version_graph_changes
+			WHERE pipeline_id = $1
+				AND revision > $2
+		)`,
+		params: []interface{}{vg.pipelineID, vg.revision},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	vg.revision = int(latest.Int64)
+
+	return true, nil
+}
+
+// versionGraphScope limits the versions and builds loaded into a graph, as
+// conditions on versioned_resources vr and builds b.
+type versionGraphScope struct {
+	versions string
+	builds   string
+	params   []interface{}
+}
+
+func loadVersionGraph(tx *sql.Tx, graph *algorithm.VersionGraph, scope versionGraphScope) error {
+	rows, err := tx.Query(`
+		SELECT vr.id, vr.resource_id, vr.version, vr.enabled AND NOT vr.deleted
+		FROM versioned_resources vr
+		WHERE `+scope.versions, scope.params...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var version algorithm.Version
+		err := rows.Scan(&version.ID, &version.ResourceID, &version.Version, &version.Usable)
+		if err != nil {
+			return err
+		}
+
+		graph.SaveVersion(version)
+	}
+
+	builds := map[int]*algorithm.Build{}
+
+	rows, err = tx.Query(`
+		SELECT b.id, b.job_id, b.status
+		FROM builds b
+		WHERE `+scope.builds, scope.params...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var build algorithm.Build
+		var status string
+		err := rows.Scan(&build.ID, &build.JobID, &status)
+		if err != nil {
+			return err
+		}
+
+		build.Succeeded = Status(status) == StatusSucceeded
+		builds[build.ID] = &build
+	}
+
+	rows, err = tx.Query(`
+		SELECT bi.build_id, bi.name, bi.versioned_resource_id
+		FROM build_inputs bi, builds b
+		WHERE bi.build_id = b.id
+			AND `+scope.builds, scope.params...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var input algorithm.Input
+		err := rows.Scan(&buildID, &input.Name, &input.VersionID)
+		if err != nil {
+			return err
+		}
+
+		if build, found := builds[buildID]; found {
+			build.Inputs = append(build.Inputs, input)
+		}
+	}
+
+	rows, err = tx.Query(`
+		SELECT bo.build_id, bo.versioned_resource_id
+		FROM build_outputs bo, builds b
+		WHERE bo.build_id = b.id
+			AND b.status = 'succeeded'
+			AND `+scope.builds, scope.params...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID, versionID int
+		err := rows.Scan(&buildID, &versionID)
+		if err != nil {
+			return err
+		}
+
+		if build, found := builds[buildID]; found {
+			build.Outputs = append(build.Outputs, versionID)
+		}
+	}
+
+	for _, build := range builds {
+		graph.SaveBuild(*build)
+	}
+
+	return nil
+}
+
+// saveVersionGraphChanges records that the versions and builds have changed,
+// so that loaded version graphs reload them. Bumping the revision locks the
+// pipeline until the transaction ends, so revisions become visible in order;
+// it should be the last thing the transaction does.
+func saveVersionGraphChanges(tx *sql.Tx, pipelineID int, versionIDs []int, buildIDs []int) error {
+	if len(versionIDs) == 0 && len(buildIDs) == 0 {
+		return nil
+	}
+
+	var revision int
+	err := tx.QueryRow(`
+		UPDATE pipelines
+		SET version_graph_revision = version_graph_revision + 1
+		WHERE id = $1
+		RETURNING version_graph_revision
+	`, pipelineID).Scan(&revision)
+	if err != nil {
+		return err
+	}
+
+	for _, versionID := range versionIDs {
+		_, err := tx.Exec(`
+			INSERT INTO version_graph_changes (pipeline_id, revision, versioned_resource_id)
+			VALUES ($1, $2, $3)
+		`, pipelineID, revision, versionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, buildID := range buildIDs {
+		_, err := tx.Exec(`
+			INSERT INTO version_graph_changes (pipeline_id, revision, build_id)
+			VALUES ($1, $2, $3)
+		`, pipelineID, revision, buildID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM version_graph_changes
+		WHERE pipeline_id = $1
+			AND revision <= $2
+	`, pipelineID, revision-versionGraphChangesKept)
+
+	return err
+}
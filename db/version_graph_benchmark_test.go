@@ -0,0 +1,202 @@
+package db_test
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/postgresrunner"
+	"github.com/lib/pq"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/gomega"
+)
+
+// BenchmarkGetLatestInputVersions resolves the inputs of every job in a
+// pipeline of realistic size, as the scheduler does on every tick, with the
+// pipeline's version graph already loaded.
+func BenchmarkGetLatestInputVersions(b *testing.B) {
+	pipeline := setUpLargePipeline(b)
+
+	pipelineDB := pipeline.pipelineDB(b)
+	pipeline.resolveAll(b, pipelineDB)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pipeline.resolveAll(b, pipelineDB)
+	}
+}
+
+// BenchmarkGetLatestInputVersionsLoadingGraph is the same, but loads the
+// version graph each time, as after the ATC starts.
+func BenchmarkGetLatestInputVersionsLoadingGraph(b *testing.B) {
+	pipeline := setUpLargePipeline(b)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pipeline.resolveAll(b, pipeline.pipelineDB(b))
+	}
+}
+
+// largePipeline is set up by the first benchmark that needs it, as doing so
+// takes minutes, and torn down by TestMain.
+var largePipeline *benchmarkPipeline
+
+type benchmarkPipeline struct {
+	runner  postgresrunner.Runner
+	process ifrit.Process
+
+	dbConn   *sql.DB
+	listener *pq.Listener
+	sqlDB    *db.SQLDB
+
+	config atc.Config
+}
+
+func setUpLargePipeline(b *testing.B) *benchmarkPipeline {
+	// the postgres runner makes assertions
+	RegisterTestingT(b)
+
+	if largePipeline != nil {
+		return largePipeline
+	}
+
+	const (
+		resources    = 30
+		versions     = 1000
+		jobs         = 60
+		inputsPerJob = 5
+		builds       = 60
+	)
+
+	pipeline := &benchmarkPipeline{
+		// clear of the ports used by the suite's nodes
+		runner: postgresrunner.Runner{Port: 5533},
+	}
+
+	pipeline.process = ifrit.Invoke(pipeline.runner)
+	pipeline.runner.CreateTestDB()
+
+	pipeline.dbConn = pipeline.runner.Open()
+	pipeline.listener = pq.NewListener(pipeline.runner.DataSourceName(), time.Second, time.Minute, nil)
+	pipeline.sqlDB = db.NewSQL(lager.NewLogger("benchmark"), pipeline.dbConn, db.NewNotificationsBus(pipeline.listener))
+
+	largePipeline = pipeline
+
+	for r := 0; r < resources; r++ {
+		pipeline.config.Resources = append(pipeline.config.Resources, atc.ResourceConfig{
+			Name:   fmt.Sprintf("resource-%d", r),
+			Type:   "some-type",
+			Source: atc.Source{"some": "source"},
+		})
+	}
+
+	// each job gets a window of the resources, one along from the job before
+	// it, and takes those it shares with that job through it
+	for j := 0; j < jobs; j++ {
+		job := atc.JobConfig{Name: fmt.Sprintf("job-%d", j)}
+
+		for i := 0; i < inputsPerJob; i++ {
+			get := atc.PlanConfig{Get: pipeline.config.Resources[(j+i)%resources].Name}
+			if j > 0 && i < inputsPerJob-1 {
+				get.Passed = []string{fmt.Sprintf("job-%d", j-1)}
+			}
+
+			job.Plan = append(job.Plan, get)
+		}
+
+		pipeline.config.Jobs = append(pipeline.config.Jobs, job)
+	}
+
+	_, err := pipeline.sqlDB.SaveConfig("large-pipeline", pipeline.config, 0, db.PipelineUnpaused)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	pipelineDB := pipeline.pipelineDB(b)
+
+	for _, resource := range pipeline.config.Resources {
+		resourceVersions := []atc.Version{}
+		for v := 0; v < versions; v++ {
+			resourceVersions = append(resourceVersions, atc.Version{"version": fmt.Sprintf("%d", v)})
+		}
+
+		err := pipelineDB.SaveResourceVersions(resource, resourceVersions)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	// each build outputs one version of each of the job's resources, with each
+	// job getting through fewer of them than the job before it
+	for j, job := range pipeline.config.Jobs {
+		for n := 0; n < builds-j/2; n++ {
+			build, err := pipelineDB.CreateJobBuild(job.Name)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			for _, input := range job.Inputs() {
+				resource, _ := pipeline.config.Resources.Lookup(input.Resource)
+
+				_, err := pipelineDB.SaveBuildOutput(build.ID, db.VersionedResource{
+					Resource:     resource.Name,
+					Type:         resource.Type,
+					Source:       db.Source(resource.Source),
+					Version:      db.Version{"version": fmt.Sprintf("%d", n*versions/builds)},
+					PipelineName: "large-pipeline",
+				}, false)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			err = pipeline.sqlDB.FinishBuild(build.ID, db.StatusSucceeded)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	return pipeline
+}
+
+// pipelineDB builds the pipeline's PipelineDB from a new factory, so that its
+// version graph is not yet loaded.
+func (pipeline *benchmarkPipeline) pipelineDB(b *testing.B) db.PipelineDB {
+	bus := db.NewNotificationsBus(pipeline.listener)
+	factory := db.NewPipelineDBFactory(lager.NewLogger("benchmark"), pipeline.dbConn, bus, pipeline.sqlDB)
+
+	pipelineDB, err := factory.BuildWithName("large-pipeline")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return pipelineDB
+}
+
+func (pipeline *benchmarkPipeline) resolveAll(b *testing.B, pipelineDB db.PipelineDB) {
+	for _, job := range pipeline.config.Jobs {
+		_, err := pipelineDB.GetLatestInputVersions(job.Name, job.Inputs())
+		if err != nil && err != db.ErrNoVersions {
+			b.Fatal(err)
+		}
+	}
+}
+
+func (pipeline *benchmarkPipeline) tearDown() {
+	pipeline.dbConn.Close()
+	pipeline.listener.Close()
+
+	pipeline.runner.DropTestDB()
+
+	pipeline.process.Signal(os.Interrupt)
+	<-pipeline.process.Wait()
+}
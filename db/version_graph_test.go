@@ -0,0 +1,174 @@
+package db_test
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/lib/pq"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Version graph", func() {
+	var dbConn *sql.DB
+	var listener *pq.Listener
+
+	var sqlDB *db.SQLDB
+	var pipelineDBFactory db.PipelineDBFactory
+	var otherPipelineDBFactory db.PipelineDBFactory
+
+	BeforeEach(func() {
+		postgresRunner.CreateTestDB()
+
+		dbConn = postgresRunner.Open()
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener)
+
+		sqlDB = db.NewSQL(lagertest.NewTestLogger("test"), dbConn, bus)
+		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, sqlDB)
+
+		// as another ATC would have
+		otherPipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, sqlDB)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = listener.Close()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		postgresRunner.DropTestDB()
+	})
+
+	Describe("changes made through other PipelineDBs", func() {
+		var pipelineDB db.PipelineDB
+		var otherPipelineDB db.PipelineDB
+
+		resourceConfig := atc.ResourceConfig{
+			Name:   "some-resource",
+			Type:   "some-type",
+			Source: atc.Source{"some": "source"},
+		}
+
+		BeforeEach(func() {
+			_, err := sqlDB.SaveConfig("some-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{resourceConfig},
+				Jobs: atc.JobConfigs{
+					{Name: "upstream-job"},
+					{Name: "downstream-job"},
+				},
+			}, 0, db.PipelineUnpaused)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			pipelineDB, err = pipelineDBFactory.BuildWithName("some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			otherPipelineDB, err = otherPipelineDBFactory.BuildWithName("some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		latestVersion := func(inputs []atc.JobInput) (db.Version, error) {
+			buildInputs, err := pipelineDB.GetLatestInputVersions("downstream-job", inputs)
+			if err != nil {
+				return nil, err
+			}
+
+			return buildInputs[0].VersionedResource.Version, nil
+		}
+
+		It("sees versions that are saved", func() {
+			inputs := []atc.JobInput{{Name: "some-input", Resource: "some-resource"}}
+
+			Ω(latestVersion(inputs)).Should(Equal(db.Version{"version": "1"}))
+
+			err := otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(latestVersion(inputs)).Should(Equal(db.Version{"version": "2"}))
+		})
+
+		It("sees versions that are disabled or deleted", func() {
+			inputs := []atc.JobInput{{Name: "some-input", Resource: "some-resource"}}
+
+			err := otherPipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}, {"version": "3"}})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(latestVersion(inputs)).Should(Equal(db.Version{"version": "3"}))
+
+			resource, err := otherPipelineDB.GetResource("some-resource")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			savedVR, err := otherPipelineDB.GetLatestVersionedResource(resource)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = otherPipelineDB.DisableVersionedResource(savedVR.ID)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(latestVersion(inputs)).Should(Equal(db.Version{"version": "2"}))
+
+			err = sqlDB.MarkVersionedResourceDeleted(db.VersionedResource{
+				Resource:     "some-resource",
+				Type:         "some-type",
+				Version:      db.Version{"version": "2"},
+				PipelineName: "some-pipeline",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(latestVersion(inputs)).Should(Equal(db.Version{"version": "1"}))
+		})
+
+		It("sees the outputs of builds once they succeed", func() {
+			inputs := []atc.JobInput{
+				{
+					Name:     "some-input",
+					Resource: "some-resource",
+					Passed:   []string{"upstream-job"},
+				},
+			}
+
+			_, err := latestVersion(inputs)
+			Ω(err).Should(Equal(db.ErrNoVersions))
+
+			build, err := otherPipelineDB.CreateJobBuild("upstream-job")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = sqlDB.SaveBuildOutput(build.ID, db.VersionedResource{
+				Resource:     "some-resource",
+				Type:         "some-type",
+				Version:      db.Version{"version": "2"},
+				PipelineName: "some-pipeline",
+			}, true)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = latestVersion(inputs)
+			Ω(err).Should(Equal(db.ErrNoVersions))
+
+			err = sqlDB.FinishBuild(build.ID, db.StatusSucceeded)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(latestVersion(inputs)).Should(Equal(db.Version{"version": "2"}))
+		})
+
+		It("forgets the graph once the pipeline is destroyed", func() {
+			_, err := latestVersion([]atc.JobInput{{Name: "some-input", Resource: "some-resource"}})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(db.VersionGraphsKept(pipelineDBFactory)).Should(Equal(1))
+
+			err = pipelineDB.Destroy()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(db.VersionGraphsKept(pipelineDBFactory)).Should(BeZero())
+		})
+	})
+})
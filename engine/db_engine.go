@@ -216,16 +216,21 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 	done := make(chan struct{})
 	defer close(done)
 
+	// relay every abort, as aborting again while the build's abort hooks are
+	// running should interrupt them too
 	go func() {
-		select {
-		case <-aborts.Notify():
-			logger.Info("aborting")
-
-			err := engineBuild.Abort()
-			if err != nil {
-				logger.Error("failed-to-abort", err)
+		for {
+			select {
+			case <-aborts.Notify():
+				logger.Info("aborting")
+
+				err := engineBuild.Abort()
+				if err != nil {
+					logger.Error("failed-to-abort", err)
+				}
+			case <-done:
+				return
 			}
-		case <-done:
 		}
 	}()
 
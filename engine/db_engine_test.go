@@ -488,10 +488,9 @@ var _ = Describe("DBEngine", func() {
 									}
 
 									realBuild.ResumeStub = func(lager.Logger) {
+										abort <- struct{}{}
 										<-aborted
 									}
-
-									close(abort)
 								})
 
 								It("aborts the build", func() {
@@ -505,6 +504,29 @@ var _ = Describe("DBEngine", func() {
 								It("closes the notifier", func() {
 									Ω(notifier.CloseCallCount()).Should(Equal(1))
 								})
+
+								Context("and aborted again before it finishes", func() {
+									BeforeEach(func() {
+										aborted := make(chan error)
+
+										realBuild.AbortStub = func() error {
+											aborted <- errAborted
+											return nil
+										}
+
+										realBuild.ResumeStub = func(lager.Logger) {
+											abort <- struct{}{}
+											<-aborted
+
+											abort <- struct{}{}
+											<-aborted
+										}
+									})
+
+									It("aborts the build each time", func() {
+										Ω(realBuild.AbortCallCount()).Should(Equal(2))
+									})
+								})
 							})
 						})
 
@@ -28,8 +28,12 @@ type EngineDB interface {
 
 	SaveBuildEngineMetadata(buildID int, metadata string) error
 
+	SaveImageResourceVersion(buildID int, locationID uint, version atc.Version) error
+	GetImageResourceVersion(buildID int, locationID uint) (atc.Version, bool, error)
+
 	SaveBuildInput(buildID int, input db.BuildInput) (db.SavedVersionedResource, error)
 	SaveBuildOutput(buildID int, vr db.VersionedResource, explicit bool) (db.SavedVersionedResource, error)
+	MarkVersionedResourceDeleted(vr db.VersionedResource) error
 }
 
 //go:generate counterfeiter . Build
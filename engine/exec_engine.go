@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
@@ -24,13 +25,15 @@ type execEngine struct {
 	factory         exec.Factory
 	delegateFactory BuildDelegateFactory
 	db              EngineDB
+	credentials     credentials.CredentialManager
 }
 
-func NewExecEngine(factory exec.Factory, delegateFactory BuildDelegateFactory, db EngineDB) Engine {
+func NewExecEngine(factory exec.Factory, delegateFactory BuildDelegateFactory, db EngineDB, credentials credentials.CredentialManager) Engine {
 	return &execEngine{
 		factory:         factory,
 		delegateFactory: delegateFactory,
 		db:              db,
+		credentials:     credentials,
 	}
 }
 
@@ -40,10 +43,13 @@ func (engine *execEngine) Name() string {
 
 func (engine *execEngine) CreateBuild(model db.Build, plan atc.Plan) (Build, error) {
 	return &execBuild{
-		buildID:  model.ID,
-		db:       engine.db,
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(model.ID),
+		buildID:      model.ID,
+		pipelineName: model.PipelineName,
+		jobName:      model.JobName,
+		db:           engine.db,
+		factory:      engine.factory,
+		credentials:  engine.credentials,
+		delegate:     engine.delegateFactory.Delegate(model.ID),
 		metadata: execMetadata{
 			Plan: plan,
 		},
@@ -60,22 +66,28 @@ func (engine *execEngine) LookupBuild(model db.Build) (Build, error) {
 	}
 
 	return &execBuild{
-		buildID:  model.ID,
-		db:       engine.db,
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(model.ID),
-		metadata: metadata,
+		buildID:      model.ID,
+		pipelineName: model.PipelineName,
+		jobName:      model.JobName,
+		db:           engine.db,
+		factory:      engine.factory,
+		credentials:  engine.credentials,
+		delegate:     engine.delegateFactory.Delegate(model.ID),
+		metadata:     metadata,
 
 		signals: make(chan os.Signal, 1),
 	}, nil
 }
 
 type execBuild struct {
-	buildID int
-	db      EngineDB
+	buildID      int
+	pipelineName string
+	jobName      string
+	db           EngineDB
 
-	factory  exec.Factory
-	delegate BuildDelegate
+	factory     exec.Factory
+	delegate    BuildDelegate
+	credentials credentials.CredentialManager
 
 	signals chan os.Signal
 
@@ -91,8 +103,18 @@ func (build *execBuild) Metadata() string {
 	return string(payload)
 }
 
+// Abort interrupts the running steps, each of which gives its process the
+// factory's grace period to exit before killing it. Hooks that run on abort
+// are then run before the build finishes.
+//
+// An abort that arrives while another is still to be passed on, or once the
+// build has finished, has nothing more to do, so it does not wait.
 func (build *execBuild) Abort() error {
-	build.signals <- os.Kill
+	select {
+	case build.signals <- os.Interrupt:
+	default:
+	}
+
 	return nil
 }
 
@@ -126,10 +148,7 @@ func (build *execBuild) Resume(logger lager.Logger) {
 
 		case sig := <-build.signals:
 			process.Signal(sig)
-
-			if sig == os.Kill {
-				aborted = true
-			}
+			aborted = true
 		}
 	}
 }
@@ -139,15 +158,32 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 
 		logger = logger.Session("aggregate")
 
-		step := exec.Aggregate{}
+		steps := []exec.StepFactory{}
+		locations := []event.OriginLocation{}
 
 		for _, innerPlan := range *plan.Aggregate {
 			stepFactory := build.buildStepFactory(logger, innerPlan)
 
-			step = append(step, stepFactory)
+			steps = append(steps, stepFactory)
+
+			// steps wrapped in hooks or timeouts have no location of their own,
+			// so are reported against the aggregate
+			location := plan.Location
+			if innerPlan.Location != nil {
+				location = innerPlan.Location
+			}
+
+			var originLocation event.OriginLocation
+			if location != nil {
+				originLocation = event.OriginLocationFrom(*location)
+			}
+
+			locations = append(locations, originLocation)
 		}
 
-		return step
+		delegate := build.delegate.AggregateDelegate(logger, locations)
+
+		return exec.LimitedAggregate(steps, plan.AggregateLimit, plan.AggregateFailFast, delegate)
 	}
 
 	if plan.Timeout != nil {
@@ -160,6 +196,16 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return exec.Try(step)
 	}
 
+	if plan.Retry != nil {
+		attempts := []exec.StepFactory{}
+
+		for _, attemptPlan := range *plan.Retry {
+			attempts = append(attempts, build.buildStepFactory(logger, attemptPlan))
+		}
+
+		return exec.Retry(attempts...)
+	}
+
 	if plan.OnSuccess != nil {
 		step := build.buildStepFactory(logger, plan.OnSuccess.Step)
 		next := build.buildStepFactory(logger, plan.OnSuccess.Next)
@@ -172,6 +218,12 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return exec.OnFailure(step, next)
 	}
 
+	if plan.OnAbort != nil {
+		step := build.buildStepFactory(logger, plan.OnAbort.Step)
+		next := build.buildStepFactory(logger, plan.OnAbort.Next)
+		return exec.OnAbort(step, next)
+	}
+
 	if plan.Ensure != nil {
 		step := build.buildStepFactory(logger, plan.Ensure.Step)
 		next := build.buildStepFactory(logger, plan.Ensure.Next)
@@ -184,6 +236,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return exec.Compose(x, y)
 	}
 
+	if plan.Error != nil {
+		return exec.Error(errors.New(plan.Error.Message))
+	}
+
 	if plan.Conditional != nil {
 		logger = logger.Session("conditional", lager.Data{
 			"on": plan.Conditional.Conditions,
@@ -225,7 +281,13 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 			build.delegate.ExecutionDelegate(logger, *plan.Task, location),
 			exec.Privileged(plan.Task.Privileged),
 			plan.Task.Tags,
-			configSource,
+			evaluatedConfigSource{
+				configSource:    configSource,
+				sensitiveParams: plan.Task.SensitiveParams,
+				build:           build,
+			},
+			plan.Task.InputMapping,
+			plan.Task.OutputMapping,
 		)
 	}
 
@@ -239,16 +301,23 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 			location = event.OriginLocationFrom(*plan.Location)
 		}
 
+		delegate := build.delegate.InputDelegate(logger, *plan.Get, location)
+
+		source, params, err := build.evaluateResource(plan.Get.Source, plan.Get.Params, plan.Get.SensitiveParams)
+		if err != nil {
+			return failedStepFactory{err: err, delegate: delegate}
+		}
+
 		return build.factory.Get(
 			exec.SourceName(plan.Get.Name),
 			build.getIdentifier(plan.Get.Name, location),
-			build.delegate.InputDelegate(logger, *plan.Get, location),
+			delegate,
 			atc.ResourceConfig{
 				Name:   plan.Get.Resource,
 				Type:   plan.Get.Type,
-				Source: plan.Get.Source,
+				Source: source,
 			},
-			plan.Get.Params,
+			params,
 			plan.Get.Tags,
 			plan.Get.Version,
 		)
@@ -264,16 +333,53 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 			location = event.OriginLocationFrom(*plan.Location)
 		}
 
+		delegate := build.delegate.OutputDelegate(logger, *plan.Put, location)
+
+		source, params, err := build.evaluateResource(plan.Put.Source, plan.Put.Params, plan.Put.SensitiveParams)
+		if err != nil {
+			return failedStepFactory{err: err, delegate: delegate}
+		}
+
 		return build.factory.Put(
 			build.putIdentifier(plan.Put.Name, location),
-			build.delegate.OutputDelegate(logger, *plan.Put, location),
+			delegate,
 			atc.ResourceConfig{
 				Name:   plan.Put.Resource,
 				Type:   plan.Put.Type,
-				Source: plan.Put.Source,
+				Source: source,
 			},
 			plan.Put.Tags,
-			plan.Put.Params,
+			params,
+		)
+	}
+
+	if plan.Delete != nil {
+		logger = logger.Session("delete", lager.Data{
+			"name": plan.Delete.Name,
+		})
+
+		var location event.OriginLocation
+		if plan.Location != nil {
+			location = event.OriginLocationFrom(*plan.Location)
+		}
+
+		delegate := build.delegate.DeletionDelegate(logger, *plan.Delete, location)
+
+		source, params, err := build.evaluateResource(plan.Delete.Source, plan.Delete.Params, plan.Delete.SensitiveParams)
+		if err != nil {
+			return failedStepFactory{err: err, delegate: delegate}
+		}
+
+		return build.factory.Delete(
+			build.deleteIdentifier(plan.Delete.Name, location),
+			delegate,
+			atc.ResourceConfig{
+				Name:   plan.Delete.Resource,
+				Type:   plan.Delete.Type,
+				Source: source,
+			},
+			plan.Delete.Tags,
+			params,
 		)
 	}
 
@@ -288,48 +394,192 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		}
 
 		getPlan := plan.DependentGet.GetPlan()
+
+		delegate := build.delegate.InputDelegate(logger, getPlan, location)
+
+		source, params, err := build.evaluateResource(getPlan.Source, getPlan.Params, getPlan.SensitiveParams)
+		if err != nil {
+			return failedStepFactory{err: err, delegate: delegate}
+		}
+
 		return build.factory.DependentGet(
 			exec.SourceName(getPlan.Name),
 			build.getIdentifier(getPlan.Name, location),
-			build.delegate.InputDelegate(logger, getPlan, location),
+			delegate,
 			atc.ResourceConfig{
 				Name:   getPlan.Resource,
 				Type:   getPlan.Type,
-				Source: getPlan.Source,
+				Source: source,
 			},
 			getPlan.Tags,
-			getPlan.Params,
+			params,
 		)
 	}
 
 	return exec.Identity{}
 }
 
+func (build *execBuild) credentialEvaluator() *credentials.Evaluator {
+	return &credentials.Evaluator{
+		Manager:      build.credentials,
+		PipelineName: build.pipelineName,
+	}
+}
+
+// evaluateResource resolves the ((credential)) references in a resource
+// step's source and params. They are only ever resolved here, as the build
+// runs, so that their values are never stored with the build's plan.
+//
+// The resolved credentials and the values of the sensitive params are
+// redacted from the output of every step of the build from then on.
+func (build *execBuild) evaluateResource(source atc.Source, params atc.Params, sensitiveParams []string) (atc.Source, atc.Params, error) {
+	evaluator := build.credentialEvaluator()
+
+	source, err := evaluator.Source(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params, err = evaluator.Params(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redact := evaluator.Resolved()
+	for _, name := range sensitiveParams {
+		if val, found := params[name].(string); found {
+			redact = append(redact, val)
+		}
+	}
+
+	build.delegate.Redact(redact)
+
+	return source, params, nil
+}
+
+// evaluatedConfigSource resolves the ((credential)) references in the params
+// and image_resource source of a task's config once it is fetched, whether it
+// was configured in the pipeline or loaded from a file, and redacts them along
+// with the values of the sensitive params.
+type evaluatedConfigSource struct {
+	configSource    exec.TaskConfigSource
+	sensitiveParams []string
+	build           *execBuild
+}
+
+func (source evaluatedConfigSource) FetchConfig(repo *exec.SourceRepository) (atc.TaskConfig, error) {
+	config, err := source.configSource.FetchConfig(repo)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	evaluator := source.build.credentialEvaluator()
+
+	config.Params, err = evaluator.TaskParams(config.Params)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if config.ImageResource != nil {
+		imageSource, err := evaluator.Source(config.ImageResource.Source)
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		config.ImageResource = &atc.TaskImageConfig{
+			Type:   config.ImageResource.Type,
+			Source: imageSource,
+		}
+	}
+
+	redact := evaluator.Resolved()
+	for _, name := range source.sensitiveParams {
+		if val, found := config.Params[name]; found {
+			redact = append(redact, val)
+		}
+	}
+
+	source.build.delegate.Redact(redact)
+
+	return config, nil
+}
+
+// failedStepFactory stands in for a step whose credentials could not be
+// resolved. It reports the error through the step's delegate once the step
+// would have run.
+type failedStepFactory struct {
+	err      error
+	delegate interface {
+		Failed(error)
+	}
+}
+
+func (factory failedStepFactory) Using(prev exec.Step, repo *exec.SourceRepository) exec.Step {
+	return &failedStep{
+		Step:    exec.Error(factory.err).Using(prev, repo),
+		factory: factory,
+	}
+}
+
+type failedStep struct {
+	exec.Step
+
+	factory failedStepFactory
+}
+
+func (step *failedStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	step.factory.delegate.Failed(step.factory.err)
+	return step.Step.Run(signals, ready)
+}
+
 func (build *execBuild) taskIdentifier(name string, location event.OriginLocation) worker.Identifier {
 	return worker.Identifier{
-		BuildID: build.buildID,
+		BuildID:      build.buildID,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
 
 		Type:         "task",
 		Name:         name,
 		StepLocation: location.ID,
+		Attempt:      location.Attempt,
 	}
 }
 
 func (build *execBuild) getIdentifier(name string, location event.OriginLocation) worker.Identifier {
 	return worker.Identifier{
 		BuildID:      build.buildID,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
+
 		Type:         "get",
 		Name:         name,
 		StepLocation: location.ID,
+		Attempt:      location.Attempt,
 	}
 }
 
 func (build *execBuild) putIdentifier(name string, location event.OriginLocation) worker.Identifier {
 	return worker.Identifier{
-		BuildID: build.buildID,
+		BuildID:      build.buildID,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
 
 		Type:         "put",
 		Name:         name,
 		StepLocation: location.ID,
+		Attempt:      location.Attempt,
+	}
+}
+
+func (build *execBuild) deleteIdentifier(name string, location event.OriginLocation) worker.Identifier {
+	return worker.Identifier{
+		BuildID:      build.buildID,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
+
+		Type:         worker.ContainerTypeDelete,
+		Name:         name,
+		StepLocation: location.ID,
+		Attempt:      location.Attempt,
 	}
 }
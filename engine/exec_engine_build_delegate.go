@@ -2,6 +2,7 @@ package engine
 
 import (
 	"io"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +26,15 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginLocation) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginLocation) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginLocation) exec.PutDelegate
+	DeletionDelegate(lager.Logger, atc.DeletePlan, event.OriginLocation) exec.DeleteDelegate
+
+	// AggregateDelegate reports the steps of an aggregate that are interrupted
+	// or skipped by fail_fast, given the location of each of its steps.
+	AggregateDelegate(lager.Logger, []event.OriginLocation) exec.AggregateDelegate
+
+	// Redact adds values to be redacted from the output of every step of the
+	// build from then on, e.g. the values of the credentials it resolves.
+	Redact([]string)
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -55,6 +65,10 @@ type delegate struct {
 	implicitOutputs map[string]implicitOutput
 
 	lock sync.Mutex
+
+	redactor     redactor
+	redactValues []string
+	redactLock   sync.RWMutex
 }
 
 func newBuildDelegate(db EngineDB, buildID int) BuildDelegate {
@@ -73,6 +87,20 @@ func (delegate *delegate) InputDelegate(logger lager.Logger, plan atc.GetPlan, l
 		plan:     plan,
 		location: location,
 		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypeGet,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStdout,
+			Location: location,
+		}),
+
+		stderr: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypeGet,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStderr,
+			Location: location,
+		}),
 	}
 }
 
@@ -82,6 +110,43 @@ func (delegate *delegate) OutputDelegate(logger lager.Logger, plan atc.PutPlan,
 		plan:     plan,
 		location: location,
 		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypePut,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStdout,
+			Location: location,
+		}),
+
+		stderr: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypePut,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStderr,
+			Location: location,
+		}),
+	}
+}
+
+func (delegate *delegate) DeletionDelegate(logger lager.Logger, plan atc.DeletePlan, location event.OriginLocation) exec.DeleteDelegate {
+	return &deletionDelegate{
+		logger:   logger,
+		plan:     plan,
+		location: location,
+		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypeDelete,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStdout,
+			Location: location,
+		}),
+
+		stderr: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypeDelete,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStderr,
+			Location: location,
+		}),
 	}
 }
 
@@ -91,6 +156,28 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 		plan:     plan,
 		location: location,
 		delegate: delegate,
+
+		stdout: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypeTask,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStdout,
+			Location: location,
+		}),
+
+		stderr: delegate.eventWriter(event.Origin{
+			Type:     event.OriginTypeTask,
+			Name:     plan.Name,
+			Source:   event.OriginSourceStderr,
+			Location: location,
+		}),
+	}
+}
+
+func (delegate *delegate) AggregateDelegate(logger lager.Logger, locations []event.OriginLocation) exec.AggregateDelegate {
+	return &aggregateDelegate{
+		logger:    logger,
+		locations: locations,
+		delegate:  delegate,
 	}
 }
 
@@ -136,10 +223,21 @@ func (delegate *delegate) unregisterImplicitOutput(resource string) {
 	delegate.lock.Unlock()
 }
 
-func (delegate *delegate) saveInitialize(logger lager.Logger, taskConfig atc.TaskConfig, origin event.Origin) {
+func (delegate *delegate) unregisterImplicitOutputVersion(resource string, version atc.Version) {
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	output, found := delegate.implicitOutputs[resource]
+	if found && reflect.DeepEqual(output.info.Version, version) {
+		delete(delegate.implicitOutputs, resource)
+	}
+}
+
+func (delegate *delegate) saveInitialize(logger lager.Logger, taskConfig atc.TaskConfig, imageVersion atc.Version, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.InitializeTask{
-		TaskConfig: event.ShadowTaskConfig(taskConfig),
-		Origin:     origin,
+		TaskConfig:   event.ShadowTaskConfig(taskConfig),
+		Origin:       origin,
+		ImageVersion: imageVersion,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-initialize-event", err)
@@ -174,9 +272,9 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 	}
 }
 
-func (delegate *delegate) saveErr(logger lager.Logger, errVal error, origin event.Origin) {
+func (delegate *delegate) saveErr(logger lager.Logger, message string, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.Error{
-		Message: errVal.Error(),
+		Message: delegate.currentRedactor().Redact(message),
 		Origin:  origin,
 	})
 	if err != nil {
@@ -256,6 +354,45 @@ func (delegate *delegate) saveOutput(logger lager.Logger, status exec.ExitStatus
 	}
 }
 
+func (delegate *delegate) saveDeletion(logger lager.Logger, status exec.ExitStatus, plan atc.DeletePlan, info *exec.VersionInfo, origin event.Origin) {
+	var version atc.Version
+	var metadata []atc.MetadataField
+
+	if info != nil {
+		version = info.Version
+		metadata = info.Metadata
+	}
+
+	ev := event.FinishDelete{
+		Origin: origin,
+		Plan: event.DeletePlan{
+			Name:     plan.Name,
+			Resource: plan.Resource,
+			Type:     plan.Type,
+		},
+		ExitStatus:      int(status),
+		DeletedVersion:  version,
+		DeletedMetadata: metadata,
+	}
+
+	err := delegate.db.SaveBuildEvent(delegate.buildID, ev)
+	if err != nil {
+		logger.Error("failed-to-save-deletion-event", err)
+	}
+
+	if info != nil {
+		err = delegate.db.MarkVersionedResourceDeleted(db.VersionedResource{
+			Resource:     plan.Resource,
+			PipelineName: plan.Pipeline,
+			Type:         plan.Type,
+			Version:      db.Version(version),
+		})
+		if err != nil {
+			logger.Error("failed-to-mark-version-deleted", err)
+		}
+	}
+}
+
 func (delegate *delegate) saveImplicitOutput(logger lager.Logger, plan atc.GetPlan, info exec.VersionInfo) {
 	metadata := make([]db.MetadataField, len(info.Metadata))
 	for i, md := range info.Metadata {
@@ -281,11 +418,27 @@ func (delegate *delegate) saveImplicitOutput(logger lager.Logger, plan atc.GetPl
 	logger.Info("saved", lager.Data{"resource": plan.Resource})
 }
 
-func (delegate *delegate) eventWriter(origin event.Origin) io.Writer {
+func (delegate *delegate) Redact(values []string) {
+	delegate.redactLock.Lock()
+	defer delegate.redactLock.Unlock()
+
+	delegate.redactValues = append(delegate.redactValues, values...)
+	delegate.redactor = newRedactor(delegate.redactValues)
+}
+
+func (delegate *delegate) currentRedactor() redactor {
+	delegate.redactLock.RLock()
+	defer delegate.redactLock.RUnlock()
+
+	return delegate.redactor
+}
+
+func (delegate *delegate) eventWriter(origin event.Origin) *dbEventWriter {
 	return &dbEventWriter{
-		db:      delegate.db,
-		buildID: delegate.buildID,
-		origin:  origin,
+		db:       delegate.db,
+		buildID:  delegate.buildID,
+		origin:   origin,
+		redactor: delegate.currentRedactor,
 	}
 }
 
@@ -295,9 +448,15 @@ type inputDelegate struct {
 	plan     atc.GetPlan
 	location event.OriginLocation
 	delegate *delegate
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
 }
 
 func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	input.stdout.Flush()
+	input.stderr.Flush()
+
 	input.delegate.saveInput(input.logger, status, input.plan, info, event.Origin{
 		Type:     event.OriginTypeGet,
 		Name:     input.plan.Name,
@@ -312,7 +471,10 @@ func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.Version
 }
 
 func (input *inputDelegate) Failed(err error) {
-	input.delegate.saveErr(input.logger, err, event.Origin{
+	input.stdout.Flush()
+	input.stderr.Flush()
+
+	input.delegate.saveErr(input.logger, err.Error(), event.Origin{
 		Type:     event.OriginTypeGet,
 		Name:     input.plan.Name,
 		Location: input.location,
@@ -322,21 +484,11 @@ func (input *inputDelegate) Failed(err error) {
 }
 
 func (input *inputDelegate) Stdout() io.Writer {
-	return input.delegate.eventWriter(event.Origin{
-		Type:     event.OriginTypeGet,
-		Name:     input.plan.Name,
-		Source:   event.OriginSourceStdout,
-		Location: input.location,
-	})
+	return input.stdout
 }
 
 func (input *inputDelegate) Stderr() io.Writer {
-	return input.delegate.eventWriter(event.Origin{
-		Type:     event.OriginTypeGet,
-		Name:     input.plan.Name,
-		Source:   event.OriginSourceStderr,
-		Location: input.location,
-	})
+	return input.stderr
 }
 
 type outputDelegate struct {
@@ -347,9 +499,15 @@ type outputDelegate struct {
 
 	delegate *delegate
 	hook     string
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
 }
 
 func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	output.stdout.Flush()
+	output.stderr.Flush()
+
 	output.delegate.unregisterImplicitOutput(output.plan.Resource)
 	output.delegate.saveOutput(output.logger, status, output.plan, info, event.Origin{
 		Type:     event.OriginTypePut,
@@ -361,7 +519,10 @@ func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.Versi
 }
 
 func (output *outputDelegate) Failed(err error) {
-	output.delegate.saveErr(output.logger, err, event.Origin{
+	output.stdout.Flush()
+	output.stderr.Flush()
+
+	output.delegate.saveErr(output.logger, err.Error(), event.Origin{
 		Type:     event.OriginTypePut,
 		Name:     output.plan.Name,
 		Location: output.location,
@@ -371,21 +532,61 @@ func (output *outputDelegate) Failed(err error) {
 }
 
 func (output *outputDelegate) Stdout() io.Writer {
-	return output.delegate.eventWriter(event.Origin{
-		Type:     event.OriginTypePut,
-		Name:     output.plan.Name,
-		Source:   event.OriginSourceStdout,
-		Location: output.location,
-	})
+	return output.stdout
 }
 
 func (output *outputDelegate) Stderr() io.Writer {
-	return output.delegate.eventWriter(event.Origin{
-		Type:     event.OriginTypePut,
-		Name:     output.plan.Name,
-		Source:   event.OriginSourceStderr,
-		Location: output.location,
+	return output.stderr
+}
+
+type deletionDelegate struct {
+	logger lager.Logger
+
+	plan     atc.DeletePlan
+	location event.OriginLocation
+
+	delegate *delegate
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
+}
+
+func (deletion *deletionDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	deletion.stdout.Flush()
+	deletion.stderr.Flush()
+
+	if info != nil {
+		deletion.delegate.unregisterImplicitOutputVersion(deletion.plan.Resource, info.Version)
+	}
+
+	deletion.delegate.saveDeletion(deletion.logger, status, deletion.plan, info, event.Origin{
+		Type:     event.OriginTypeDelete,
+		Name:     deletion.plan.Name,
+		Location: deletion.location,
+	})
+
+	deletion.logger.Info("finished", lager.Data{"version-info": info})
+}
+
+func (deletion *deletionDelegate) Failed(err error) {
+	deletion.stdout.Flush()
+	deletion.stderr.Flush()
+
+	deletion.delegate.saveErr(deletion.logger, err.Error(), event.Origin{
+		Type:     event.OriginTypeDelete,
+		Name:     deletion.plan.Name,
+		Location: deletion.location,
 	})
+
+	deletion.logger.Error("errored", err)
+}
+
+func (deletion *deletionDelegate) Stdout() io.Writer {
+	return deletion.stdout
+}
+
+func (deletion *deletionDelegate) Stderr() io.Writer {
+	return deletion.stderr
 }
 
 type executionDelegate struct {
@@ -397,10 +598,21 @@ type executionDelegate struct {
 	delegate *delegate
 
 	hook string
+
+	stdout *dbEventWriter
+	stderr *dbEventWriter
+}
+
+func (execution *executionDelegate) ImageVersion() (atc.Version, bool, error) {
+	return execution.delegate.db.GetImageResourceVersion(execution.delegate.buildID, execution.location.ID)
+}
+
+func (execution *executionDelegate) ImageVersionChosen(version atc.Version) error {
+	return execution.delegate.db.SaveImageResourceVersion(execution.delegate.buildID, execution.location.ID, version)
 }
 
-func (execution *executionDelegate) Initializing(config atc.TaskConfig) {
-	execution.delegate.saveInitialize(execution.logger, config, event.Origin{
+func (execution *executionDelegate) Initializing(config atc.TaskConfig, imageVersion atc.Version) {
+	execution.delegate.saveInitialize(execution.logger, config, imageVersion, event.Origin{
 		Type:     event.OriginTypeTask,
 		Name:     execution.plan.Name,
 		Location: execution.location,
@@ -418,6 +630,9 @@ func (execution *executionDelegate) Started() {
 }
 
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
+	execution.stdout.Flush()
+	execution.stderr.Flush()
+
 	execution.delegate.saveFinish(execution.logger, status, event.Origin{
 		Type:     event.OriginTypeTask,
 		Name:     execution.plan.Name,
@@ -426,7 +641,10 @@ func (execution *executionDelegate) Finished(status exec.ExitStatus) {
 }
 
 func (execution *executionDelegate) Failed(err error) {
-	execution.delegate.saveErr(execution.logger, err, event.Origin{
+	execution.stdout.Flush()
+	execution.stderr.Flush()
+
+	execution.delegate.saveErr(execution.logger, err.Error(), event.Origin{
 		Type:     event.OriginTypeTask,
 		Name:     execution.plan.Name,
 		Location: execution.location,
@@ -435,21 +653,34 @@ func (execution *executionDelegate) Failed(err error) {
 }
 
 func (execution *executionDelegate) Stdout() io.Writer {
-	return execution.delegate.eventWriter(event.Origin{
-		Type:     event.OriginTypeTask,
-		Name:     execution.plan.Name,
-		Source:   event.OriginSourceStdout,
-		Location: execution.location,
-	})
+	return execution.stdout
 }
 
 func (execution *executionDelegate) Stderr() io.Writer {
-	return execution.delegate.eventWriter(event.Origin{
-		Type:     event.OriginTypeTask,
-		Name:     execution.plan.Name,
-		Source:   event.OriginSourceStderr,
-		Location: execution.location,
+	return execution.stderr
+}
+
+type aggregateDelegate struct {
+	logger    lager.Logger
+	locations []event.OriginLocation
+
+	delegate *delegate
+}
+
+func (aggregate *aggregateDelegate) Interrupted(index int) {
+	aggregate.delegate.saveErr(aggregate.logger, "interrupted by fail_fast, as another step of the aggregate failed", event.Origin{
+		Location: aggregate.locations[index],
 	})
+
+	aggregate.logger.Info("interrupted-by-fail-fast", lager.Data{"index": index})
+}
+
+func (aggregate *aggregateDelegate) Skipped(index int) {
+	aggregate.delegate.saveErr(aggregate.logger, "skipped by fail_fast, as another step of the aggregate failed", event.Origin{
+		Location: aggregate.locations[index],
+	})
+
+	aggregate.logger.Info("skipped-by-fail-fast", lager.Data{"index": index})
 }
 
 type dbEventWriter struct {
@@ -458,10 +689,19 @@ type dbEventWriter struct {
 
 	origin event.Origin
 
+	// redactor returns the build's redactor as of each write, as values to
+	// redact may be added while the step is running
+	redactor func() redactor
+
 	dangling []byte
+
+	lock sync.Mutex
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
@@ -470,14 +710,39 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 		return len(data), nil
 	}
 
+	// a sensitive value may straddle two writes, so hold back anything that
+	// may be the start of one until the rest arrives
+	redacted, held := writer.redactor().redactStream(string(text))
+
+	writer.dangling = []byte(held)
+
+	if len(redacted) > 0 {
+		writer.saveLog(redacted)
+	}
+
+	return len(data), nil
+}
+
+// Flush saves any output held back by Write, once no more is expected.
+func (writer *dbEventWriter) Flush() {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	checkEncoding, _ := utf8.DecodeLastRune(writer.dangling)
+	if len(writer.dangling) == 0 || checkEncoding == utf8.RuneError {
+		return
+	}
+
+	writer.saveLog(writer.redactor().Redact(string(writer.dangling)))
+
 	writer.dangling = nil
+}
 
+func (writer *dbEventWriter) saveLog(payload string) {
 	writer.db.SaveBuildEvent(writer.buildID, event.Log{
-		Payload: string(text),
+		Payload: payload,
 		Origin:  writer.origin,
 	})
-
-	return len(data), nil
 }
 
 func vrFromInput(pipelineName string, got event.FinishGet) db.VersionedResource {
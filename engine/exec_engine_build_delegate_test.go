@@ -355,6 +355,46 @@ var _ = Describe("BuildDelegate", func() {
 							})
 						})
 					})
+
+					Context("when the fetched version is then deleted", func() {
+						JustBeforeEach(func() {
+							deletionDelegate := delegate.DeletionDelegate(logger, atc.DeletePlan{
+								Pipeline: "some-pipeline",
+								Resource: "some-input-resource",
+								Type:     "some-type",
+							}, location)
+
+							deletionDelegate.Completed(exec.ExitStatus(0), &exec.VersionInfo{
+								Version: atc.Version{"result": "version"},
+							})
+						})
+
+						It("does not save the input as an implicit output", func() {
+							delegate.Finish(logger, nil, true, false)
+
+							Ω(fakeDB.SaveBuildOutputCallCount()).Should(BeZero())
+						})
+					})
+
+					Context("when another version of the resource is deleted", func() {
+						JustBeforeEach(func() {
+							deletionDelegate := delegate.DeletionDelegate(logger, atc.DeletePlan{
+								Pipeline: "some-pipeline",
+								Resource: "some-input-resource",
+								Type:     "some-type",
+							}, location)
+
+							deletionDelegate.Completed(exec.ExitStatus(0), &exec.VersionInfo{
+								Version: atc.Version{"other": "version"},
+							})
+						})
+
+						It("still saves the input as an implicit output", func() {
+							delegate.Finish(logger, nil, true, false)
+
+							Ω(fakeDB.SaveBuildOutputCallCount()).Should(Equal(1))
+						})
+					})
 				})
 			})
 		})
@@ -455,8 +495,48 @@ var _ = Describe("BuildDelegate", func() {
 			executionDelegate = delegate.ExecutionDelegate(logger, taskPlan, location)
 		})
 
+		Describe("ImageVersion", func() {
+			It("looks up the version chosen for the task's location in the build", func() {
+				fakeDB.GetImageResourceVersionReturns(atc.Version{"digest": "some-digest"}, true, nil)
+
+				version, found, err := executionDelegate.ImageVersion()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(found).Should(BeTrue())
+				Ω(version).Should(Equal(atc.Version{"digest": "some-digest"}))
+
+				Ω(fakeDB.GetImageResourceVersionCallCount()).Should(Equal(1))
+				buildID, locationID := fakeDB.GetImageResourceVersionArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(locationID).Should(Equal(uint(3)))
+			})
+		})
+
+		Describe("ImageVersionChosen", func() {
+			It("saves the version for the task's location in the build", func() {
+				err := executionDelegate.ImageVersionChosen(atc.Version{"digest": "some-digest"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(fakeDB.SaveImageResourceVersionCallCount()).Should(Equal(1))
+				buildID, locationID, version := fakeDB.SaveImageResourceVersionArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(locationID).Should(Equal(uint(3)))
+				Ω(version).Should(Equal(atc.Version{"digest": "some-digest"}))
+			})
+
+			Context("when saving the version fails", func() {
+				It("returns the error", func() {
+					disaster := errors.New("nope")
+					fakeDB.SaveImageResourceVersionReturns(disaster)
+
+					err := executionDelegate.ImageVersionChosen(atc.Version{"digest": "some-digest"})
+					Ω(err).Should(Equal(disaster))
+				})
+			})
+		})
+
 		Describe("Initializing", func() {
 			var taskConfig atc.TaskConfig
+			var imageVersion atc.Version
 
 			BeforeEach(func() {
 				taskConfig = atc.TaskConfig{
@@ -464,10 +544,12 @@ var _ = Describe("BuildDelegate", func() {
 						Path: "ls",
 					},
 				}
+
+				imageVersion = nil
 			})
 
 			JustBeforeEach(func() {
-				executionDelegate.Initializing(taskConfig)
+				executionDelegate.Initializing(taskConfig, imageVersion)
 			})
 
 			It("saves an initialize event", func() {
@@ -488,6 +570,31 @@ var _ = Describe("BuildDelegate", func() {
 					},
 				}))
 			})
+
+			Context("when the task's image was fetched from a resource", func() {
+				BeforeEach(func() {
+					imageVersion = atc.Version{"digest": "some-digest"}
+				})
+
+				It("records the image's version in the initialize event", func() {
+					Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+					_, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+					Ω(savedEvent).Should(Equal(event.InitializeTask{
+						TaskConfig: event.TaskConfig{
+							Run: event.TaskRunConfig{
+								Path: "ls",
+							},
+						},
+						Origin: event.Origin{
+							Type:     event.OriginTypeTask,
+							Name:     "some-task",
+							Location: location,
+						},
+						ImageVersion: atc.Version{"digest": "some-digest"},
+					}))
+				})
+			})
 		})
 
 		Describe("Started", func() {
@@ -683,6 +790,118 @@ var _ = Describe("BuildDelegate", func() {
 				}))
 			})
 		})
+
+		Context("when the build has values to redact", func() {
+			var (
+				writer io.Writer
+				origin event.Origin
+			)
+
+			BeforeEach(func() {
+				delegate.Redact([]string{"s3cr3t", "s3cr3t-longer"})
+
+				executionDelegate = delegate.ExecutionDelegate(logger, taskPlan, location)
+
+				writer = executionDelegate.Stdout()
+
+				origin = event.Origin{
+					Type:     event.OriginTypeTask,
+					Name:     "some-task",
+					Source:   event.OriginSourceStdout,
+					Location: location,
+				}
+			})
+
+			savedPayloads := func() string {
+				payloads := ""
+				for i := 0; i < fakeDB.SaveBuildEventCallCount(); i++ {
+					_, savedEvent := fakeDB.SaveBuildEventArgsForCall(i)
+					if log, ok := savedEvent.(event.Log); ok {
+						payloads += log.Payload
+					}
+				}
+
+				return payloads
+			}
+
+			It("replaces the values in log events", func() {
+				_, err := writer.Write([]byte("the password is s3cr3t, ok?"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				_, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(savedEvent).Should(Equal(event.Log{
+					Origin:  origin,
+					Payload: "the password is ((redacted)), ok?",
+				}))
+			})
+
+			It("prefers the longest matching value", func() {
+				_, err := writer.Write([]byte("s3cr3t-longer!"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(savedPayloads()).Should(Equal("((redacted))!"))
+			})
+
+			It("redacts values split across writes", func() {
+				_, err := writer.Write([]byte("the password is s3c"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(savedPayloads()).Should(Equal("the password is "))
+
+				_, err = writer.Write([]byte("r3t, ok?"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(savedPayloads()).Should(Equal("the password is ((redacted)), ok?"))
+			})
+
+			It("saves held back output once the step finishes", func() {
+				_, err := writer.Write([]byte("ends with s3c"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				executionDelegate.Finished(0)
+
+				Ω(savedPayloads()).Should(Equal("ends with s3c"))
+
+				_, savedEvent := fakeDB.SaveBuildEventArgsForCall(fakeDB.SaveBuildEventCallCount() - 1)
+				Ω(savedEvent).Should(BeAssignableToTypeOf(event.FinishTask{}))
+			})
+
+			It("redacts the values from error events", func() {
+				executionDelegate.Failed(errors.New("failed to use s3cr3t"))
+
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				_, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(savedEvent).Should(Equal(event.Error{
+					Message: "failed to use ((redacted))",
+					Origin: event.Origin{
+						Type:     event.OriginTypeTask,
+						Name:     "some-task",
+						Location: location,
+					},
+				}))
+			})
+
+			It("redacts values added after the step started", func() {
+				delegate.Redact([]string{"other-s3cr3t"})
+
+				_, err := writer.Write([]byte("the token is other-s3cr3t"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(savedPayloads()).Should(Equal("the token is ((redacted))"))
+			})
+
+			It("redacts the values from the output of the build's other steps", func() {
+				inputDelegate := delegate.InputDelegate(logger, atc.GetPlan{Name: "some-input"}, location)
+
+				_, err := inputDelegate.Stdout().Write([]byte("the password is s3cr3t"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(savedPayloads()).Should(Equal("the password is ((redacted))"))
+			})
+		})
 	})
 
 	Describe("OutputDelegate", func() {
@@ -962,6 +1181,204 @@ var _ = Describe("BuildDelegate", func() {
 		})
 	})
 
+	Describe("DeletionDelegate", func() {
+		var (
+			deletePlan atc.DeletePlan
+
+			deletionDelegate exec.DeleteDelegate
+		)
+
+		BeforeEach(func() {
+			deletePlan = atc.DeletePlan{
+				Name:     "some-deletion-name",
+				Resource: "some-deleted-resource",
+				Pipeline: "some-other-pipeline",
+				Type:     "some-type",
+				Source:   atc.Source{"some": "source"},
+				Params:   atc.Params{"some": "params"},
+			}
+
+			deletionDelegate = delegate.DeletionDelegate(logger, deletePlan, location)
+		})
+
+		Describe("Completed", func() {
+			Context("when the version info is nil", func() {
+				JustBeforeEach(func() {
+					deletionDelegate.Completed(exec.ExitStatus(1), nil)
+				})
+
+				It("does not mark any version as deleted", func() {
+					Ω(fakeDB.MarkVersionedResourceDeletedCallCount()).Should(BeZero())
+				})
+
+				It("saves a finish-delete event", func() {
+					Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+					Ω(buildID).Should(Equal(42))
+					Ω(savedEvent).Should(Equal(event.FinishDelete{
+						Origin: event.Origin{
+							Type:     event.OriginTypeDelete,
+							Name:     "some-deletion-name",
+							Location: location,
+						},
+						Plan: event.DeletePlan{
+							Name:     "some-deletion-name",
+							Resource: "some-deleted-resource",
+							Type:     "some-type",
+						},
+						ExitStatus: 1,
+					}))
+				})
+			})
+
+			Context("when the deleted version is reported", func() {
+				var versionInfo *exec.VersionInfo
+
+				BeforeEach(func() {
+					versionInfo = &exec.VersionInfo{
+						Version:  atc.Version{"result": "version"},
+						Metadata: []atc.MetadataField{{"result", "metadata"}},
+					}
+				})
+
+				JustBeforeEach(func() {
+					deletionDelegate.Completed(exec.ExitStatus(0), versionInfo)
+				})
+
+				It("marks the version as deleted", func() {
+					Ω(fakeDB.MarkVersionedResourceDeletedCallCount()).Should(Equal(1))
+					Ω(fakeDB.MarkVersionedResourceDeletedArgsForCall(0)).Should(Equal(db.VersionedResource{
+						PipelineName: "some-other-pipeline",
+						Resource:     "some-deleted-resource",
+						Type:         "some-type",
+						Version:      db.Version{"result": "version"},
+					}))
+				})
+
+				It("saves a finish-delete event", func() {
+					Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+					buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+					Ω(buildID).Should(Equal(42))
+					Ω(savedEvent).Should(Equal(event.FinishDelete{
+						Origin: event.Origin{
+							Type:     event.OriginTypeDelete,
+							Name:     "some-deletion-name",
+							Location: location,
+						},
+						Plan: event.DeletePlan{
+							Name:     "some-deletion-name",
+							Resource: "some-deleted-resource",
+							Type:     "some-type",
+						},
+						DeletedVersion:  versionInfo.Version,
+						DeletedMetadata: versionInfo.Metadata,
+						ExitStatus:      0,
+					}))
+				})
+
+				It("does not save a build output", func() {
+					Ω(fakeDB.SaveBuildOutputCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Describe("Failed", func() {
+			JustBeforeEach(func() {
+				deletionDelegate.Failed(errors.New("nope"))
+			})
+
+			It("does not mark any version as deleted", func() {
+				Ω(fakeDB.MarkVersionedResourceDeletedCallCount()).Should(BeZero())
+			})
+
+			It("saves an error event", func() {
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(savedEvent).Should(Equal(event.Error{
+					Origin: event.Origin{
+						Type:     event.OriginTypeDelete,
+						Name:     "some-deletion-name",
+						Location: location,
+					},
+					Message: "nope",
+				}))
+			})
+		})
+
+		Describe("Stdout", func() {
+			It("saves log events with the deletion's origin", func() {
+				_, err := deletionDelegate.Stdout().Write([]byte("some stdout"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				savedBuildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(savedBuildID).Should(Equal(buildID))
+				Ω(savedEvent).Should(Equal(event.Log{
+					Origin: event.Origin{
+						Type:     event.OriginTypeDelete,
+						Name:     "some-deletion-name",
+						Source:   event.OriginSourceStdout,
+						Location: location,
+					},
+					Payload: "some stdout",
+				}))
+			})
+		})
+	})
+
+	Describe("AggregateDelegate", func() {
+		var (
+			otherLocation event.OriginLocation
+
+			aggregateDelegate exec.AggregateDelegate
+		)
+
+		BeforeEach(func() {
+			otherLocation = event.OriginLocation{
+				ParentID:      0,
+				ID:            4,
+				ParallelGroup: 1,
+			}
+
+			aggregateDelegate = delegate.AggregateDelegate(logger, []event.OriginLocation{location, otherLocation})
+		})
+
+		Describe("Interrupted", func() {
+			It("saves an error event naming fail_fast for the step", func() {
+				aggregateDelegate.Interrupted(1)
+
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(savedEvent).Should(Equal(event.Error{
+					Origin:  event.Origin{Location: otherLocation},
+					Message: "interrupted by fail_fast, as another step of the aggregate failed",
+				}))
+			})
+		})
+
+		Describe("Skipped", func() {
+			It("saves an error event naming fail_fast for the step", func() {
+				aggregateDelegate.Skipped(0)
+
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(savedEvent).Should(Equal(event.Error{
+					Origin:  event.Origin{Location: location},
+					Message: "skipped by fail_fast, as another step of the aggregate failed",
+				}))
+			})
+		})
+	})
+
 	Describe("Aborted", func() {
 		var aborted bool
 
@@ -0,0 +1,243 @@
+package engine_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
+	cfakes "github.com/concourse/atc/credentials/fakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/engine/fakes"
+	"github.com/concourse/atc/exec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/lager/lagertest"
+	"gopkg.in/yaml.v2"
+
+	execfakes "github.com/concourse/atc/exec/fakes"
+)
+
+var _ = Describe("Exec Engine with credentials", func() {
+	var (
+		fakeFactory           *execfakes.FakeFactory
+		fakeDelegateFactory   *fakes.FakeBuildDelegateFactory
+		fakeDB                *fakes.FakeEngineDB
+		fakeCredentialManager *cfakes.FakeCredentialManager
+
+		execEngine engine.Engine
+
+		buildModel db.Build
+		logger     *lagertest.TestLogger
+
+		fakeDelegate      *fakes.FakeBuildDelegate
+		fakeInputDelegate *execfakes.FakeGetDelegate
+
+		plan atc.Plan
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeFactory = new(execfakes.FakeFactory)
+		fakeDelegateFactory = new(fakes.FakeBuildDelegateFactory)
+		fakeDB = new(fakes.FakeEngineDB)
+
+		fakeCredentialManager = new(cfakes.FakeCredentialManager)
+		fakeCredentialManager.GetStub = func(pipelineName string, name string) (interface{}, bool, error) {
+			creds := map[string]interface{}{
+				"some-pipeline/private-key": "some-private-key",
+				"some-pipeline/token":       "some-token",
+			}
+
+			value, found := creds[pipelineName+"/"+name]
+			return value, found, nil
+		}
+
+		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, fakeCredentialManager)
+
+		fakeDelegate = new(fakes.FakeBuildDelegate)
+		fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+		fakeInputDelegate = new(execfakes.FakeGetDelegate)
+		fakeDelegate.InputDelegateReturns(fakeInputDelegate)
+
+		inputStepFactory := new(execfakes.FakeStepFactory)
+		inputStep := new(execfakes.FakeStep)
+		inputStep.ResultStub = successResult(true)
+		inputStepFactory.UsingReturns(inputStep)
+		fakeFactory.GetReturns(inputStepFactory)
+
+		buildModel = db.Build{
+			ID:           42,
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+		}
+
+		plan = atc.Plan{
+			Location: &atc.Location{ID: 1},
+			Get: &atc.GetPlan{
+				Name:     "some-input",
+				Resource: "some-resource",
+				Type:     "some-type",
+				Source:   atc.Source{"private_key": "((private-key))"},
+				Params:   atc.Params{"token": "((token))"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		build, err := execEngine.CreateBuild(buildModel, plan)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		build.Resume(logger)
+	})
+
+	It("resolves the references in the step's source and params", func() {
+		Ω(fakeFactory.GetCallCount()).Should(Equal(1))
+
+		_, _, _, resourceConfig, params, _, _ := fakeFactory.GetArgsForCall(0)
+		Ω(resourceConfig.Source).Should(Equal(atc.Source{"private_key": "some-private-key"}))
+		Ω(params).Should(Equal(atc.Params{"token": "some-token"}))
+	})
+
+	It("redacts the resolved values from the build's output", func() {
+		Ω(fakeDelegate.RedactCallCount()).Should(Equal(1))
+		Ω(fakeDelegate.RedactArgsForCall(0)).Should(ConsistOf("some-private-key", "some-token"))
+	})
+
+	It("keeps the references in the build's plan", func() {
+		_, getPlan, _ := fakeDelegate.InputDelegateArgsForCall(0)
+		Ω(getPlan.Source).Should(Equal(atc.Source{"private_key": "((private-key))"}))
+		Ω(getPlan.Params).Should(Equal(atc.Params{"token": "((token))"}))
+	})
+
+	Context("when the step marks params as sensitive", func() {
+		BeforeEach(func() {
+			plan.Get.Params = atc.Params{
+				"password": "hunter2",
+				"token":    "((token))",
+				"user":     "some-user",
+			}
+			plan.Get.SensitiveParams = []string{"password", "token"}
+		})
+
+		It("redacts their resolved values from the build's output", func() {
+			Ω(fakeDelegate.RedactCallCount()).Should(Equal(1))
+			Ω(fakeDelegate.RedactArgsForCall(0)).Should(ConsistOf("some-private-key", "some-token", "hunter2", "some-token"))
+		})
+	})
+
+	Context("when a task's config is loaded from a file", func() {
+		var configSource exec.TaskConfigSource
+
+		BeforeEach(func() {
+			taskStepFactory := new(execfakes.FakeStepFactory)
+			taskStepFactory.UsingReturns(new(execfakes.FakeStep))
+			fakeFactory.TaskReturns(taskStepFactory)
+
+			plan = atc.Plan{
+				Location: &atc.Location{ID: 1},
+				Task: &atc.TaskPlan{
+					Name:            "some-task",
+					ConfigPath:      "some-input/build.yml",
+					SensitiveParams: []string{"PASSWORD"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Ω(fakeFactory.TaskCallCount()).Should(Equal(1))
+			_, _, _, _, _, configSource, _, _ = fakeFactory.TaskArgsForCall(0)
+		})
+
+		It("resolves its params once it is fetched, and redacts them along with the sensitive params", func() {
+			marshalled, err := yaml.Marshal(atc.TaskConfig{
+				Platform: "some-platform",
+				Run:      atc.TaskRunConfig{Path: "some-path"},
+				Params: map[string]string{
+					"PASSWORD": "hunter2",
+					"TOKEN":    "((token))",
+				},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fakeArtifactSource := new(execfakes.FakeArtifactSource)
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes(marshalled), nil)
+
+			repo := exec.NewSourceRepository()
+			repo.RegisterSource("some-input", fakeArtifactSource)
+
+			Ω(fakeDelegate.RedactCallCount()).Should(BeZero())
+
+			config, err := configSource.FetchConfig(repo)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(config.Params).Should(Equal(map[string]string{
+				"PASSWORD": "hunter2",
+				"TOKEN":    "some-token",
+			}))
+
+			Ω(fakeDelegate.RedactCallCount()).Should(Equal(1))
+			Ω(fakeDelegate.RedactArgsForCall(0)).Should(ConsistOf("some-token", "hunter2"))
+		})
+
+		It("resolves the source of its image resource", func() {
+			marshalled, err := yaml.Marshal(atc.TaskConfig{
+				Platform: "some-platform",
+				Run:      atc.TaskRunConfig{Path: "some-path"},
+				ImageResource: &atc.TaskImageConfig{
+					Type:   "docker-image",
+					Source: atc.Source{"password": "((token))"},
+				},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fakeArtifactSource := new(execfakes.FakeArtifactSource)
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes(marshalled), nil)
+
+			repo := exec.NewSourceRepository()
+			repo.RegisterSource("some-input", fakeArtifactSource)
+
+			config, err := configSource.FetchConfig(repo)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(config.ImageResource).Should(Equal(&atc.TaskImageConfig{
+				Type:   "docker-image",
+				Source: atc.Source{"password": "some-token"},
+			}))
+
+			Ω(fakeDelegate.RedactCallCount()).Should(Equal(1))
+			Ω(fakeDelegate.RedactArgsForCall(0)).Should(ConsistOf("some-token"))
+		})
+	})
+
+	Context("when a credential is not defined", func() {
+		BeforeEach(func() {
+			plan.Get.Params = atc.Params{"token": "((bogus))"}
+		})
+
+		It("does not construct the step", func() {
+			Ω(fakeFactory.GetCallCount()).Should(BeZero())
+		})
+
+		It("reports the error through the step's delegate", func() {
+			Ω(fakeInputDelegate.FailedCallCount()).Should(Equal(1))
+			Ω(fakeInputDelegate.FailedArgsForCall(0)).Should(Equal(credentials.UndefinedCredentialError{
+				PipelineName: "some-pipeline",
+				Name:         "bogus",
+			}))
+		})
+
+		It("finishes the build with the error", func() {
+			Ω(fakeDelegate.FinishCallCount()).Should(Equal(1))
+
+			_, err, succeeded, aborted := fakeDelegate.FinishArgsForCall(0)
+			Ω(err).Should(Equal(credentials.UndefinedCredentialError{
+				PipelineName: "some-pipeline",
+				Name:         "bogus",
+			}))
+			Ω(succeeded).Should(Equal(exec.Success(false)))
+			Ω(aborted).Should(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/engine/fakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	execfakes "github.com/concourse/atc/exec/fakes"
+)
+
+var _ = Describe("Exec Engine with Delete", func() {
+
+	var (
+		fakeFactory         *execfakes.FakeFactory
+		fakeDelegateFactory *fakes.FakeBuildDelegateFactory
+		fakeDB              *fakes.FakeEngineDB
+
+		execEngine engine.Engine
+
+		buildModel db.Build
+		logger     *lagertest.TestLogger
+
+		fakeDelegate *fakes.FakeBuildDelegate
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeFactory = new(execfakes.FakeFactory)
+		fakeDelegateFactory = new(fakes.FakeBuildDelegateFactory)
+		fakeDB = new(fakes.FakeEngineDB)
+
+		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, nil)
+
+		fakeDelegate = new(fakes.FakeBuildDelegate)
+		fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+		buildModel = db.Build{
+			ID:           84,
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+		}
+	})
+
+	Context("running a delete step", func() {
+		var (
+			deleteStepFactory *execfakes.FakeStepFactory
+			deleteStep        *execfakes.FakeStep
+
+			fakeDeleteDelegate *execfakes.FakeDeleteDelegate
+
+			plan atc.Plan
+		)
+
+		BeforeEach(func() {
+			deleteStepFactory = new(execfakes.FakeStepFactory)
+			deleteStep = new(execfakes.FakeStep)
+			deleteStep.ResultStub = successResult(true)
+			deleteStepFactory.UsingReturns(deleteStep)
+			fakeFactory.DeleteReturns(deleteStepFactory)
+
+			fakeDeleteDelegate = new(execfakes.FakeDeleteDelegate)
+			fakeDelegate.DeletionDelegateReturns(fakeDeleteDelegate)
+
+			plan = atc.Plan{
+				Location: &atc.Location{ID: 1},
+				Delete: &atc.DeletePlan{
+					Name:     "some-delete",
+					Resource: "some-resource",
+					Type:     "some-type",
+					Source:   atc.Source{"some": "source"},
+					Params:   atc.Params{"some": "params"},
+					Tags:     atc.Tags{"some", "tags"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			build, err := execEngine.CreateBuild(buildModel, plan)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			build.Resume(logger)
+		})
+
+		It("constructs the delete correctly", func() {
+			Ω(fakeFactory.DeleteCallCount()).Should(Equal(1))
+
+			workerID, delegate, resourceConfig, tags, params := fakeFactory.DeleteArgsForCall(0)
+			Ω(workerID).Should(Equal(worker.Identifier{
+				BuildID:      84,
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				Type:         worker.ContainerTypeDelete,
+				Name:         "some-delete",
+				StepLocation: 1,
+			}))
+			Ω(delegate).Should(Equal(fakeDeleteDelegate))
+			Ω(resourceConfig).Should(Equal(atc.ResourceConfig{
+				Name:   "some-resource",
+				Type:   "some-type",
+				Source: atc.Source{"some": "source"},
+			}))
+			Ω(tags).Should(Equal(atc.Tags{"some", "tags"}))
+			Ω(params).Should(Equal(atc.Params{"some": "params"}))
+		})
+
+		It("constructs the deletion delegate with the plan and location", func() {
+			Ω(fakeDelegate.DeletionDelegateCallCount()).Should(Equal(1))
+
+			_, deletePlan, location := fakeDelegate.DeletionDelegateArgsForCall(0)
+			Ω(deletePlan).Should(Equal(*plan.Delete))
+			Ω(location).Should(Equal(event.OriginLocation{ID: 1}))
+		})
+
+		It("runs the step", func() {
+			Ω(deleteStep.RunCallCount()).Should(Equal(1))
+		})
+	})
+})
@@ -38,7 +38,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 		fakeDelegateFactory = new(fakes.FakeBuildDelegateFactory)
 		fakeDB = new(fakes.FakeEngineDB)
 
-		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB)
+		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, nil)
 
 		fakeDelegate = new(fakes.FakeBuildDelegate)
 		fakeDelegateFactory.DelegateReturns(fakeDelegate)
@@ -186,7 +186,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the steps correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(3))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task-1")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -211,7 +211,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					_, _, location = fakeDelegate.ExecutionDelegateArgsForCall(0)
 					Ω(location).ShouldNot(BeNil())
 
-					sourceName, workerID, delegate, _, _, _ = fakeFactory.TaskArgsForCall(1)
+					sourceName, workerID, delegate, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task-2")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -223,7 +223,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					_, _, location = fakeDelegate.ExecutionDelegateArgsForCall(1)
 					Ω(location).ShouldNot(BeNil())
 
-					sourceName, workerID, delegate, _, _, _ = fakeFactory.TaskArgsForCall(2)
+					sourceName, workerID, delegate, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task-3")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -319,7 +319,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the completion hook correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(2)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-completion-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -334,7 +334,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the failure hook correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-failure-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -349,7 +349,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the success hook correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(1)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -364,7 +364,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the next step correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(3)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-next-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID: 84,
@@ -667,5 +667,95 @@ var _ = Describe("Exec Engine With Hooks", func() {
 				Ω(outputStep.RunCallCount()).Should(Equal(0))
 			})
 		})
+
+		Context("when the build is aborted while a step with an abort hook is running", func() {
+			BeforeEach(func() {
+				inputStep.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					close(ready)
+					<-signals
+					return exec.ErrInterrupted
+				}
+			})
+
+			It("runs the abort hook before finishing the build as aborted", func() {
+				plan := atc.Plan{
+					OnAbort: &atc.OnAbortPlan{
+						Step: atc.Plan{
+							Location: &atc.Location{},
+							Get: &atc.GetPlan{
+								Name: "some-input",
+							},
+						},
+						Next: atc.Plan{
+							Location: &atc.Location{
+								Hook: "abort",
+							},
+							Task: &atc.TaskPlan{
+								Name:   "some-cleanup",
+								Config: &atc.TaskConfig{},
+							},
+						},
+					},
+				}
+
+				build, err := execEngine.CreateBuild(buildModel, plan)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				go build.Resume(logger)
+
+				Eventually(inputStep.RunCallCount).Should(Equal(1))
+
+				err = build.Abort()
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Eventually(fakeDelegate.FinishCallCount).Should(Equal(1))
+
+				Ω(taskStep.RunCallCount()).Should(Equal(1))
+
+				_, _, successful, aborted := fakeDelegate.FinishArgsForCall(0)
+				Ω(successful).Should(Equal(exec.Success(false)))
+				Ω(aborted).Should(BeTrue())
+			})
+		})
+
+		Context("when the plan ends in an error", func() {
+			It("runs the preceding hooks and finishes the build with the error", func() {
+				plan := atc.Plan{
+					Compose: &atc.ComposePlan{
+						A: atc.Plan{
+							Try: &atc.TryPlan{
+								Step: atc.Plan{
+									Location: &atc.Location{
+										Hook: "failure",
+									},
+									Task: &atc.TaskPlan{
+										Name:   "some-failure",
+										Config: &atc.TaskConfig{},
+									},
+								},
+							},
+						},
+						B: atc.Plan{
+							Error: &atc.ErrorPlan{
+								Message: "nope",
+							},
+						},
+					},
+				}
+
+				build, err := execEngine.CreateBuild(buildModel, plan)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				build.Resume(logger)
+
+				Ω(taskStep.RunCallCount()).Should(Equal(1))
+
+				Ω(fakeDelegate.FinishCallCount()).Should(Equal(1))
+				_, cbErr, successful, aborted := fakeDelegate.FinishArgsForCall(0)
+				Ω(cbErr).Should(MatchError("nope"))
+				Ω(successful).Should(Equal(exec.Success(false)))
+				Ω(aborted).Should(BeFalse())
+			})
+		})
 	})
 })
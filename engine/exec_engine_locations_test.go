@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/engine/fakes"
+	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
 	. "github.com/onsi/ginkgo"
@@ -36,7 +37,7 @@ var _ = Describe("Exec Engine Locations", func() {
 		fakeDelegateFactory = new(fakes.FakeBuildDelegateFactory)
 		fakeDB = new(fakes.FakeEngineDB)
 
-		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB)
+		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, nil)
 
 		fakeDelegate = new(fakes.FakeBuildDelegate)
 		fakeDelegateFactory.DelegateReturns(fakeDelegate)
@@ -187,7 +188,7 @@ var _ = Describe("Exec Engine Locations", func() {
 				build.Resume(logger)
 
 				Ω(fakeFactory.TaskCallCount()).Should(Equal(1))
-				sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Ω(sourceName).Should(Equal(exec.SourceName("some task")))
 				Ω(workerID).Should(Equal(worker.Identifier{
 					BuildID: 84,
@@ -252,6 +253,50 @@ var _ = Describe("Exec Engine Locations", func() {
 				Ω(location).ShouldNot(BeNil())
 			})
 		})
+
+		Context("aggregate", func() {
+			var plan atc.Plan
+
+			BeforeEach(func() {
+				getStepFactory := new(execfakes.FakeStepFactory)
+				getStep := new(execfakes.FakeStep)
+				getStep.ResultStub = successResult(true)
+				getStepFactory.UsingReturns(getStep)
+				fakeFactory.GetReturns(getStepFactory)
+
+				plan = atc.Plan{
+					Location: &atc.Location{ID: 1},
+					Aggregate: &atc.AggregatePlan{
+						{
+							Location: &atc.Location{ParentID: 1, ParallelGroup: 1, ID: 2},
+							Get:      &atc.GetPlan{Name: "some-input"},
+						},
+						{
+							Try: &atc.TryPlan{
+								Step: atc.Plan{
+									Location: &atc.Location{ParentID: 1, ParallelGroup: 1, ID: 3},
+									Get:      &atc.GetPlan{Name: "some-other-input"},
+								},
+							},
+						},
+					},
+					AggregateFailFast: true,
+				}
+			})
+
+			It("reports steps that fail fast at their own location, or else the aggregate's", func() {
+				build, err := execEngine.CreateBuild(buildModel, plan)
+				Ω(err).ShouldNot(HaveOccurred())
+				build.Resume(logger)
+
+				Ω(fakeDelegate.AggregateDelegateCallCount()).Should(Equal(1))
+				_, locations := fakeDelegate.AggregateDelegateArgsForCall(0)
+				Ω(locations).Should(Equal([]event.OriginLocation{
+					{ParentID: 1, ParallelGroup: 1, ID: 2},
+					{ID: 1},
+				}))
+			})
+		})
 	})
 
 })
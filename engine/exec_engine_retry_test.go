@@ -0,0 +1,142 @@
+package engine_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/engine/fakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/worker"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	execfakes "github.com/concourse/atc/exec/fakes"
+)
+
+var _ = Describe("Exec Engine with Retry", func() {
+
+	var (
+		fakeFactory         *execfakes.FakeFactory
+		fakeDelegateFactory *fakes.FakeBuildDelegateFactory
+		fakeDB              *fakes.FakeEngineDB
+
+		execEngine engine.Engine
+
+		buildModel db.Build
+		logger     *lagertest.TestLogger
+
+		fakeDelegate *fakes.FakeBuildDelegate
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeFactory = new(execfakes.FakeFactory)
+		fakeDelegateFactory = new(fakes.FakeBuildDelegateFactory)
+		fakeDB = new(fakes.FakeEngineDB)
+
+		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, nil)
+
+		fakeDelegate = new(fakes.FakeBuildDelegate)
+		fakeDelegateFactory.DelegateReturns(fakeDelegate)
+
+		buildModel = db.Build{ID: 84}
+	})
+
+	Context("running retry steps", func() {
+		var (
+			inputStepFactory *execfakes.FakeStepFactory
+			inputStep        *execfakes.FakeStep
+
+			fakeInputDelegate *execfakes.FakeGetDelegate
+
+			plan atc.Plan
+		)
+
+		BeforeEach(func() {
+			inputStepFactory = new(execfakes.FakeStepFactory)
+			inputStep = new(execfakes.FakeStep)
+			inputStep.ResultStub = successResult(true)
+			inputStepFactory.UsingReturns(inputStep)
+			fakeFactory.GetReturns(inputStepFactory)
+
+			fakeInputDelegate = new(execfakes.FakeGetDelegate)
+			fakeDelegate.InputDelegateReturns(fakeInputDelegate)
+
+			plan = atc.Plan{
+				Retry: &atc.RetryPlan{
+					{
+						Location: &atc.Location{ID: 1, Attempt: 1},
+						Get: &atc.GetPlan{
+							Name: "some-input",
+						},
+					},
+					{
+						Location: &atc.Location{ID: 1, Attempt: 2},
+						Get: &atc.GetPlan{
+							Name: "some-input",
+						},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			build, err := execEngine.CreateBuild(buildModel, plan)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			build.Resume(logger)
+		})
+
+		It("constructs each attempt with its own identifier", func() {
+			Ω(fakeFactory.GetCallCount()).Should(Equal(2))
+
+			_, workerID, _, _, _, _, _ := fakeFactory.GetArgsForCall(0)
+			Ω(workerID).Should(Equal(worker.Identifier{
+				BuildID:      84,
+				Type:         worker.ContainerTypeGet,
+				Name:         "some-input",
+				StepLocation: 1,
+				Attempt:      1,
+			}))
+
+			_, workerID, _, _, _, _, _ = fakeFactory.GetArgsForCall(1)
+			Ω(workerID).Should(Equal(worker.Identifier{
+				BuildID:      84,
+				Type:         worker.ContainerTypeGet,
+				Name:         "some-input",
+				StepLocation: 1,
+				Attempt:      2,
+			}))
+
+			_, _, location := fakeDelegate.InputDelegateArgsForCall(0)
+			Ω(location).Should(Equal(event.OriginLocation{
+				ID:      1,
+				Attempt: 1,
+			}))
+
+			_, _, location = fakeDelegate.InputDelegateArgsForCall(1)
+			Ω(location).Should(Equal(event.OriginLocation{
+				ID:      1,
+				Attempt: 2,
+			}))
+		})
+
+		Context("when the first attempt succeeds", func() {
+			It("does not run the second attempt", func() {
+				Ω(inputStep.RunCallCount()).Should(Equal(1))
+			})
+		})
+
+		Context("when the first attempt fails", func() {
+			BeforeEach(func() {
+				inputStep.ResultStub = successResult(false)
+			})
+
+			It("runs the second attempt", func() {
+				Ω(inputStep.RunCallCount()).Should(Equal(2))
+			})
+		})
+	})
+})
@@ -30,7 +30,7 @@ var _ = Describe("ExecEngine", func() {
 		fakeDelegateFactory = new(fakes.FakeBuildDelegateFactory)
 		fakeDB = new(fakes.FakeEngineDB)
 
-		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB)
+		execEngine = engine.NewExecEngine(fakeFactory, fakeDelegateFactory, fakeDB, nil)
 	})
 
 	Describe("Resume", func() {
@@ -69,7 +69,11 @@ var _ = Describe("ExecEngine", func() {
 		BeforeEach(func() {
 			logger = lagertest.NewTestLogger("test")
 
-			buildModel = db.Build{ID: 42}
+			buildModel = db.Build{
+				ID:           42,
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+			}
 
 			taskConfig = &atc.TaskConfig{
 				Image:  "some-image",
@@ -195,6 +199,9 @@ var _ = Describe("ExecEngine", func() {
 
 											Config:     taskConfig,
 											ConfigPath: taskConfigPath,
+
+											InputMapping:  map[string]string{"some-task-input": "some-input"},
+											OutputMapping: map[string]string{"some-task-output": "some-output"},
 										},
 									},
 									B: atc.Plan{
@@ -299,9 +306,11 @@ var _ = Describe("ExecEngine", func() {
 
 					workerID, delegate, resourceConfig, tags, params := fakeFactory.PutArgsForCall(0)
 					Ω(workerID).Should(Equal(worker.Identifier{
-						BuildID: 42,
-						Type:    worker.ContainerTypePut,
-						Name:    "some-put",
+						BuildID:      42,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Type:         worker.ContainerTypePut,
+						Name:         "some-put",
 					}))
 					Ω(tags).Should(BeEmpty())
 					Ω(delegate).Should(Equal(fakeOutputDelegate))
@@ -312,9 +321,11 @@ var _ = Describe("ExecEngine", func() {
 
 					workerID, delegate, resourceConfig, tags, params = fakeFactory.PutArgsForCall(1)
 					Ω(workerID).Should(Equal(worker.Identifier{
-						BuildID: 42,
-						Type:    worker.ContainerTypePut,
-						Name:    "some-put-2",
+						BuildID:      42,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Type:         worker.ContainerTypePut,
+						Name:         "some-put-2",
 					}))
 					Ω(tags).Should(BeEmpty())
 					Ω(delegate).Should(Equal(fakeOutputDelegate))
@@ -329,9 +340,11 @@ var _ = Describe("ExecEngine", func() {
 
 					sourceName, workerID, delegate, resourceConfig, tags, params := fakeFactory.DependentGetArgsForCall(0)
 					Ω(workerID).Should(Equal(worker.Identifier{
-						BuildID: 42,
-						Type:    worker.ContainerTypeGet,
-						Name:    "some-put",
+						BuildID:      42,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Type:         worker.ContainerTypeGet,
+						Name:         "some-put",
 					}))
 
 					Ω(tags).Should(BeEmpty())
@@ -348,9 +361,11 @@ var _ = Describe("ExecEngine", func() {
 
 					sourceName, workerID, delegate, resourceConfig, tags, params = fakeFactory.DependentGetArgsForCall(1)
 					Ω(workerID).Should(Equal(worker.Identifier{
-						BuildID: 42,
-						Type:    worker.ContainerTypeGet,
-						Name:    "some-put-2",
+						BuildID:      42,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Type:         worker.ContainerTypeGet,
+						Name:         "some-put-2",
 					}))
 
 					Ω(tags).Should(BeEmpty())
@@ -374,9 +389,11 @@ var _ = Describe("ExecEngine", func() {
 			sourceName, workerID, delegate, resourceConfig, params, tags, version := fakeFactory.GetArgsForCall(0)
 			Ω(sourceName).Should(Equal(exec.SourceName("some-input")))
 			Ω(workerID).Should(Equal(worker.Identifier{
-				BuildID: 42,
-				Type:    worker.ContainerTypeGet,
-				Name:    "some-input",
+				BuildID:      42,
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				Type:         worker.ContainerTypeGet,
+				Name:         "some-input",
 			}))
 			Ω(tags).Should(ConsistOf("some", "get", "tags"))
 
@@ -395,17 +412,21 @@ var _ = Describe("ExecEngine", func() {
 		It("constructs tasks correctly", func() {
 			Ω(fakeFactory.TaskCallCount()).Should(Equal(1))
 
-			sourceName, workerID, delegate, privileged, tags, configSource := fakeFactory.TaskArgsForCall(0)
+			sourceName, workerID, delegate, privileged, tags, configSource, inputMapping, outputMapping := fakeFactory.TaskArgsForCall(0)
 			Ω(sourceName).Should(Equal(exec.SourceName("some-task")))
 			Ω(workerID).Should(Equal(worker.Identifier{
-				BuildID: 42,
-				Type:    worker.ContainerTypeTask,
-				Name:    "some-task",
+				BuildID:      42,
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				Type:         worker.ContainerTypeTask,
+				Name:         "some-task",
 			}))
 			Ω(delegate).Should(Equal(fakeExecutionDelegate))
 			Ω(privileged).Should(Equal(exec.Privileged(false)))
 			Ω(tags).Should(BeEmpty())
 			Ω(configSource).ShouldNot(BeNil())
+			Ω(inputMapping).Should(Equal(map[string]string{"some-task-input": "some-input"}))
+			Ω(outputMapping).Should(Equal(map[string]string{"some-task-output": "some-output"}))
 		})
 
 		Context("constructing outputs", func() {
@@ -414,9 +435,11 @@ var _ = Describe("ExecEngine", func() {
 
 				workerID, delegate, resourceConfig, tags, params := fakeFactory.PutArgsForCall(0)
 				Ω(workerID).Should(Equal(worker.Identifier{
-					BuildID: 42,
-					Type:    worker.ContainerTypePut,
-					Name:    "some-put",
+					BuildID:      42,
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					Type:         worker.ContainerTypePut,
+					Name:         "some-put",
 				}))
 				Ω(delegate).Should(Equal(fakeOutputDelegate))
 				Ω(resourceConfig.Name).Should(Equal("some-output-resource"))
@@ -431,9 +454,11 @@ var _ = Describe("ExecEngine", func() {
 
 				sourceName, workerID, delegate, resourceConfig, tags, params := fakeFactory.DependentGetArgsForCall(0)
 				Ω(workerID).Should(Equal(worker.Identifier{
-					BuildID: 42,
-					Type:    worker.ContainerTypeGet,
-					Name:    "some-put",
+					BuildID:      42,
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
+					Type:         worker.ContainerTypeGet,
+					Name:         "some-put",
 				}))
 				Ω(tags).Should(ConsistOf("some", "putget", "tags"))
 				Ω(sourceName).Should(Equal(exec.SourceName("some-put")))
@@ -468,6 +493,23 @@ var _ = Describe("ExecEngine", func() {
 				Ω(taskStep.ReleaseCallCount()).Should(Equal(1))
 				Ω(outputStep.ReleaseCallCount()).Should(BeNumerically(">", 0))
 			})
+
+			Context("and the build is aborted afterwards, more than once", func() {
+				It("does not block", func() {
+					aborted := make(chan struct{})
+
+					go func() {
+						defer GinkgoRecover()
+
+						Ω(build.Abort()).Should(Succeed())
+						Ω(build.Abort()).Should(Succeed())
+
+						close(aborted)
+					}()
+
+					Eventually(aborted).Should(BeClosed())
+				})
+			})
 		})
 
 		Context("when the task is privileged", func() {
@@ -478,7 +520,7 @@ var _ = Describe("ExecEngine", func() {
 			It("constructs the task step privileged", func() {
 				Ω(fakeFactory.TaskCallCount()).Should(Equal(1))
 
-				_, _, _, privileged, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, privileged, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Ω(privileged).Should(Equal(exec.Privileged(true)))
 			})
 		})
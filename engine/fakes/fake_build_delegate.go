@@ -42,6 +42,30 @@ type FakeBuildDelegate struct {
 	outputDelegateReturns struct {
 		result1 exec.PutDelegate
 	}
+	DeletionDelegateStub        func(lager.Logger, atc.DeletePlan, event.OriginLocation) exec.DeleteDelegate
+	deletionDelegateMutex       sync.RWMutex
+	deletionDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.DeletePlan
+		arg3 event.OriginLocation
+	}
+	deletionDelegateReturns struct {
+		result1 exec.DeleteDelegate
+	}
+	AggregateDelegateStub        func(lager.Logger, []event.OriginLocation) exec.AggregateDelegate
+	aggregateDelegateMutex       sync.RWMutex
+	aggregateDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []event.OriginLocation
+	}
+	aggregateDelegateReturns struct {
+		result1 exec.AggregateDelegate
+	}
+	RedactStub        func([]string)
+	redactMutex       sync.RWMutex
+	redactArgsForCall []struct {
+		arg1 []string
+	}
 	FinishStub        func(lager.Logger, error, exec.Success, bool)
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -154,6 +178,96 @@ func (fake *FakeBuildDelegate) OutputDelegateReturns(result1 exec.PutDelegate) {
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) DeletionDelegate(arg1 lager.Logger, arg2 atc.DeletePlan, arg3 event.OriginLocation) exec.DeleteDelegate {
+	fake.deletionDelegateMutex.Lock()
+	fake.deletionDelegateArgsForCall = append(fake.deletionDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.DeletePlan
+		arg3 event.OriginLocation
+	}{arg1, arg2, arg3})
+	fake.deletionDelegateMutex.Unlock()
+	if fake.DeletionDelegateStub != nil {
+		return fake.DeletionDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.deletionDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) DeletionDelegateCallCount() int {
+	fake.deletionDelegateMutex.RLock()
+	defer fake.deletionDelegateMutex.RUnlock()
+	return len(fake.deletionDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) DeletionDelegateArgsForCall(i int) (lager.Logger, atc.DeletePlan, event.OriginLocation) {
+	fake.deletionDelegateMutex.RLock()
+	defer fake.deletionDelegateMutex.RUnlock()
+	return fake.deletionDelegateArgsForCall[i].arg1, fake.deletionDelegateArgsForCall[i].arg2, fake.deletionDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) DeletionDelegateReturns(result1 exec.DeleteDelegate) {
+	fake.DeletionDelegateStub = nil
+	fake.deletionDelegateReturns = struct {
+		result1 exec.DeleteDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) AggregateDelegate(arg1 lager.Logger, arg2 []event.OriginLocation) exec.AggregateDelegate {
+	fake.aggregateDelegateMutex.Lock()
+	fake.aggregateDelegateArgsForCall = append(fake.aggregateDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []event.OriginLocation
+	}{arg1, arg2})
+	fake.aggregateDelegateMutex.Unlock()
+	if fake.AggregateDelegateStub != nil {
+		return fake.AggregateDelegateStub(arg1, arg2)
+	} else {
+		return fake.aggregateDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) AggregateDelegateCallCount() int {
+	fake.aggregateDelegateMutex.RLock()
+	defer fake.aggregateDelegateMutex.RUnlock()
+	return len(fake.aggregateDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) AggregateDelegateArgsForCall(i int) (lager.Logger, []event.OriginLocation) {
+	fake.aggregateDelegateMutex.RLock()
+	defer fake.aggregateDelegateMutex.RUnlock()
+	return fake.aggregateDelegateArgsForCall[i].arg1, fake.aggregateDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) AggregateDelegateReturns(result1 exec.AggregateDelegate) {
+	fake.AggregateDelegateStub = nil
+	fake.aggregateDelegateReturns = struct {
+		result1 exec.AggregateDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) Redact(arg1 []string) {
+	fake.redactMutex.Lock()
+	fake.redactArgsForCall = append(fake.redactArgsForCall, struct {
+		arg1 []string
+	}{arg1})
+	fake.redactMutex.Unlock()
+	if fake.RedactStub != nil {
+		fake.RedactStub(arg1)
+	}
+}
+
+func (fake *FakeBuildDelegate) RedactCallCount() int {
+	fake.redactMutex.RLock()
+	defer fake.redactMutex.RUnlock()
+	return len(fake.redactArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RedactArgsForCall(i int) []string {
+	fake.redactMutex.RLock()
+	defer fake.redactMutex.RUnlock()
+	return fake.redactArgsForCall[i].arg1
+}
+
 func (fake *FakeBuildDelegate) Finish(arg1 lager.Logger, arg2 error, arg3 exec.Success, arg4 bool) {
 	fake.finishMutex.Lock()
 	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
@@ -37,6 +37,27 @@ type FakeEngineDB struct {
 	saveBuildEngineMetadataReturns struct {
 		result1 error
 	}
+	SaveImageResourceVersionStub        func(buildID int, locationID uint, version atc.Version) error
+	saveImageResourceVersionMutex       sync.RWMutex
+	saveImageResourceVersionArgsForCall []struct {
+		buildID    int
+		locationID uint
+		version    atc.Version
+	}
+	saveImageResourceVersionReturns struct {
+		result1 error
+	}
+	GetImageResourceVersionStub        func(buildID int, locationID uint) (atc.Version, bool, error)
+	getImageResourceVersionMutex       sync.RWMutex
+	getImageResourceVersionArgsForCall []struct {
+		buildID    int
+		locationID uint
+	}
+	getImageResourceVersionReturns struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
 	SaveBuildInputStub        func(buildID int, input db.BuildInput) (db.SavedVersionedResource, error)
 	saveBuildInputMutex       sync.RWMutex
 	saveBuildInputArgsForCall []struct {
@@ -58,6 +79,14 @@ type FakeEngineDB struct {
 		result1 db.SavedVersionedResource
 		result2 error
 	}
+	MarkVersionedResourceDeletedStub        func(vr db.VersionedResource) error
+	markVersionedResourceDeletedMutex       sync.RWMutex
+	markVersionedResourceDeletedArgsForCall []struct {
+		vr db.VersionedResource
+	}
+	markVersionedResourceDeletedReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeEngineDB) SaveBuildEvent(buildID int, event atc.Event) error {
@@ -159,6 +188,75 @@ func (fake *FakeEngineDB) SaveBuildEngineMetadataReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeEngineDB) SaveImageResourceVersion(buildID int, locationID uint, version atc.Version) error {
+	fake.saveImageResourceVersionMutex.Lock()
+	fake.saveImageResourceVersionArgsForCall = append(fake.saveImageResourceVersionArgsForCall, struct {
+		buildID    int
+		locationID uint
+		version    atc.Version
+	}{buildID, locationID, version})
+	fake.saveImageResourceVersionMutex.Unlock()
+	if fake.SaveImageResourceVersionStub != nil {
+		return fake.SaveImageResourceVersionStub(buildID, locationID, version)
+	} else {
+		return fake.saveImageResourceVersionReturns.result1
+	}
+}
+
+func (fake *FakeEngineDB) SaveImageResourceVersionCallCount() int {
+	fake.saveImageResourceVersionMutex.RLock()
+	defer fake.saveImageResourceVersionMutex.RUnlock()
+	return len(fake.saveImageResourceVersionArgsForCall)
+}
+
+func (fake *FakeEngineDB) SaveImageResourceVersionArgsForCall(i int) (int, uint, atc.Version) {
+	fake.saveImageResourceVersionMutex.RLock()
+	defer fake.saveImageResourceVersionMutex.RUnlock()
+	return fake.saveImageResourceVersionArgsForCall[i].buildID, fake.saveImageResourceVersionArgsForCall[i].locationID, fake.saveImageResourceVersionArgsForCall[i].version
+}
+
+func (fake *FakeEngineDB) SaveImageResourceVersionReturns(result1 error) {
+	fake.SaveImageResourceVersionStub = nil
+	fake.saveImageResourceVersionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEngineDB) GetImageResourceVersion(buildID int, locationID uint) (atc.Version, bool, error) {
+	fake.getImageResourceVersionMutex.Lock()
+	fake.getImageResourceVersionArgsForCall = append(fake.getImageResourceVersionArgsForCall, struct {
+		buildID    int
+		locationID uint
+	}{buildID, locationID})
+	fake.getImageResourceVersionMutex.Unlock()
+	if fake.GetImageResourceVersionStub != nil {
+		return fake.GetImageResourceVersionStub(buildID, locationID)
+	} else {
+		return fake.getImageResourceVersionReturns.result1, fake.getImageResourceVersionReturns.result2, fake.getImageResourceVersionReturns.result3
+	}
+}
+
+func (fake *FakeEngineDB) GetImageResourceVersionCallCount() int {
+	fake.getImageResourceVersionMutex.RLock()
+	defer fake.getImageResourceVersionMutex.RUnlock()
+	return len(fake.getImageResourceVersionArgsForCall)
+}
+
+func (fake *FakeEngineDB) GetImageResourceVersionArgsForCall(i int) (int, uint) {
+	fake.getImageResourceVersionMutex.RLock()
+	defer fake.getImageResourceVersionMutex.RUnlock()
+	return fake.getImageResourceVersionArgsForCall[i].buildID, fake.getImageResourceVersionArgsForCall[i].locationID
+}
+
+func (fake *FakeEngineDB) GetImageResourceVersionReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.GetImageResourceVersionStub = nil
+	fake.getImageResourceVersionReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeEngineDB) SaveBuildInput(buildID int, input db.BuildInput) (db.SavedVersionedResource, error) {
 	fake.saveBuildInputMutex.Lock()
 	fake.saveBuildInputArgsForCall = append(fake.saveBuildInputArgsForCall, struct {
@@ -228,4 +326,36 @@ func (fake *FakeEngineDB) SaveBuildOutputReturns(result1 db.SavedVersionedResour
 	}{result1, result2}
 }
 
+func (fake *FakeEngineDB) MarkVersionedResourceDeleted(vr db.VersionedResource) error {
+	fake.markVersionedResourceDeletedMutex.Lock()
+	fake.markVersionedResourceDeletedArgsForCall = append(fake.markVersionedResourceDeletedArgsForCall, struct {
+		vr db.VersionedResource
+	}{vr})
+	fake.markVersionedResourceDeletedMutex.Unlock()
+	if fake.MarkVersionedResourceDeletedStub != nil {
+		return fake.MarkVersionedResourceDeletedStub(vr)
+	} else {
+		return fake.markVersionedResourceDeletedReturns.result1
+	}
+}
+
+func (fake *FakeEngineDB) MarkVersionedResourceDeletedCallCount() int {
+	fake.markVersionedResourceDeletedMutex.RLock()
+	defer fake.markVersionedResourceDeletedMutex.RUnlock()
+	return len(fake.markVersionedResourceDeletedArgsForCall)
+}
+
+func (fake *FakeEngineDB) MarkVersionedResourceDeletedArgsForCall(i int) db.VersionedResource {
+	fake.markVersionedResourceDeletedMutex.RLock()
+	defer fake.markVersionedResourceDeletedMutex.RUnlock()
+	return fake.markVersionedResourceDeletedArgsForCall[i].vr
+}
+
+func (fake *FakeEngineDB) MarkVersionedResourceDeletedReturns(result1 error) {
+	fake.MarkVersionedResourceDeletedStub = nil
+	fake.markVersionedResourceDeletedReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ engine.EngineDB = new(FakeEngineDB)
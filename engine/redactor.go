@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+const redactedText = "((redacted))"
+
+// A redactor replaces occurrences of sensitive values in build output.
+type redactor struct {
+	values []string
+}
+
+func newRedactor(values []string) redactor {
+	nonEmpty := []string{}
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+
+	// prefer the longest match when values overlap
+	sort.Sort(byLength(nonEmpty))
+
+	return redactor{values: nonEmpty}
+}
+
+// Redact replaces every complete occurrence of a sensitive value in text.
+func (r redactor) Redact(text string) string {
+	redacted, _ := r.redact(text, false)
+	return redacted
+}
+
+// redactStream replaces every complete occurrence of a sensitive value in
+// text. Any trailing text that could be the beginning of a sensitive value
+// is returned separately, so that it may be held back until more text
+// arrives.
+func (r redactor) redactStream(text string) (string, string) {
+	return r.redact(text, true)
+}
+
+func (r redactor) redact(text string, streaming bool) (string, string) {
+	if len(r.values) == 0 {
+		return text, ""
+	}
+
+	redacted := &bytes.Buffer{}
+
+	lastEnd := 0
+
+	for i := 0; i < len(text); {
+		if streaming && r.isPartialMatch(text[i:]) {
+			redacted.WriteString(text[lastEnd:i])
+			return redacted.String(), text[i:]
+		}
+
+		value, found := r.matchAt(text, i)
+		if !found {
+			i++
+			continue
+		}
+
+		redacted.WriteString(text[lastEnd:i])
+		redacted.WriteString(redactedText)
+
+		i += len(value)
+		lastEnd = i
+	}
+
+	redacted.WriteString(text[lastEnd:])
+
+	return redacted.String(), ""
+}
+
+func (r redactor) matchAt(text string, i int) (string, bool) {
+	for _, value := range r.values {
+		if strings.HasPrefix(text[i:], value) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+func (r redactor) isPartialMatch(suffix string) bool {
+	for _, value := range r.values {
+		if len(suffix) < len(value) && strings.HasPrefix(value, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type byLength []string
+
+func (vs byLength) Len() int           { return len(vs) }
+func (vs byLength) Swap(i, j int)      { vs[i], vs[j] = vs[j], vs[i] }
+func (vs byLength) Less(i, j int) bool { return len(vs[i]) > len(vs[j]) }
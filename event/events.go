@@ -22,6 +22,9 @@ func (FinishTask) Version() atc.EventVersion { return "2.0" }
 type InitializeTask struct {
 	TaskConfig TaskConfig `json:"config"`
 	Origin     Origin     `json:"origin"`
+
+	// the version of the task's image_resource that was fetched, if any
+	ImageVersion atc.Version `json:"image_version,omitempty"`
 }
 
 // shadow the real atc.TaskConfig
@@ -107,6 +110,7 @@ const (
 	OriginTypeInvalid OriginType = ""
 	OriginTypeGet     OriginType = "get"
 	OriginTypePut     OriginType = "put"
+	OriginTypeDelete  OriginType = "delete"
 	OriginTypeTask    OriginType = "task"
 )
 
@@ -123,6 +127,7 @@ func OriginLocationFrom(location atc.Location) OriginLocation {
 		ParallelGroup: location.ParallelGroup,
 		ID:            location.ID,
 		Hook:          location.Hook,
+		Attempt:       location.Attempt,
 	}
 }
 
@@ -131,6 +136,7 @@ type OriginLocation struct {
 	ID            uint   `json:"id"`
 	ParallelGroup uint   `json:"parallel_group"`
 	Hook          string `json:"hook"`
+	Attempt       uint   `json:"attempt,omitempty"`
 }
 
 func (ol OriginLocation) Incr(by OriginLocationIncrement) OriginLocation {
@@ -184,3 +190,20 @@ type PutPlan struct {
 	Resource string `json:"resource"`
 	Type     string `json:"type"`
 }
+
+type FinishDelete struct {
+	Origin          Origin              `json:"origin"`
+	Plan            DeletePlan          `json:"plan"`
+	DeletedVersion  atc.Version         `json:"version"`
+	DeletedMetadata []atc.MetadataField `json:"metadata,omitempty"`
+	ExitStatus      int                 `json:"exit_status"`
+}
+
+func (FinishDelete) EventType() atc.EventType  { return EventTypeFinishDelete }
+func (FinishDelete) Version() atc.EventVersion { return "1.0" }
+
+type DeletePlan struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+	Type     string `json:"type"`
+}
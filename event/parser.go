@@ -38,6 +38,7 @@ func init() {
 	registerEvent(FinishTask{})
 	registerEvent(FinishGet{})
 	registerEvent(FinishPut{})
+	registerEvent(FinishDelete{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
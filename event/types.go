@@ -26,6 +26,9 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// finished deleting something
+	EventTypeFinishDelete atc.EventType = "finish-delete"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
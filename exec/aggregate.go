@@ -11,26 +11,110 @@ import (
 type Aggregate []StepFactory
 
 func (a Aggregate) Using(prev Step, repo *SourceRepository) Step {
-	sources := aggregateStep{}
+	return limitedAggregate{steps: a}.Using(prev, repo)
+}
+
+//go:generate counterfeiter . AggregateDelegate
+
+// AggregateDelegate is told about each of an aggregate's steps that is
+// interrupted or skipped because another step failed and the aggregate fails
+// fast, or skipped because the aggregate was signalled before it started.
+// Steps are identified by their index in the aggregate.
+type AggregateDelegate interface {
+	Interrupted(index int)
+	Skipped(index int)
+}
+
+type limitedAggregate struct {
+	steps    []StepFactory
+	limit    int
+	failFast bool
+	delegate AggregateDelegate
+}
+
+// LimitedAggregate runs the given steps in parallel like Aggregate, but runs
+// at most limit of them at once. A limit of 0 runs them all at once.
+//
+// If failFast is true, once any step fails or errors the steps that are still
+// running are interrupted, and those that have not yet started are skipped,
+// telling the delegate about each of them. Errors from the interrupted steps
+// are reported by the steps themselves, so they do not cause the aggregate to
+// error.
+//
+// Steps that have not yet started when the aggregate is signalled are skipped
+// too. An aggregate that skipped any of its steps has not succeeded.
+func LimitedAggregate(steps []StepFactory, limit int, failFast bool, delegate AggregateDelegate) StepFactory {
+	return limitedAggregate{
+		steps:    steps,
+		limit:    limit,
+		failFast: failFast,
+		delegate: delegate,
+	}
+}
+
+func (a limitedAggregate) Using(prev Step, repo *SourceRepository) Step {
+	sources := &aggregateStep{
+		limit:    a.limit,
+		failFast: a.failFast,
+		delegate: a.delegate,
+	}
 
-	for _, step := range a {
-		sources = append(sources, step.Using(prev, repo))
+	for _, step := range a.steps {
+		sources.steps = append(sources.steps, step.Using(prev, repo))
 	}
 
 	return sources
 }
 
-type aggregateStep []Step
+type aggregateStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+	delegate AggregateDelegate
+
+	// the number of steps started, which are always the first ones
+	started int
+}
+
+type aggregateExit struct {
+	index int
+	err   error
+}
+
+func (step *aggregateStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	limit := step.limit
+	if limit <= 0 || limit > len(step.steps) {
+		limit = len(step.steps)
+	}
+
+	members := make([]ifrit.Process, len(step.steps))
+	exited := make([]bool, len(step.steps))
+	interrupted := make([]bool, len(step.steps))
+
+	exits := make(chan aggregateExit, len(step.steps))
+
+	next := 0
+	running := 0
+
+	start := func() {
+		index := next
+		next++
+		step.started = next
 
-func (step aggregateStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
-	members := []ifrit.Process{}
+		process := ifrit.Background(step.steps[index])
+		members[index] = process
+		running++
 
-	for _, ms := range step {
-		process := ifrit.Background(ms)
-		members = append(members, process)
+		go func() {
+			exits <- aggregateExit{index, <-process.Wait()}
+		}()
 	}
 
-	for _, mp := range members {
+	for next < limit {
+		start()
+	}
+
+	for _, mp := range members[:limit] {
 		select {
 		case <-mp.Ready():
 		case <-mp.Wait():
@@ -41,25 +125,47 @@ func (step aggregateStep) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 
 	var errorMessages []string
 
-dance:
-	for _, mp := range members {
+	// once signalled or failed fast, no more steps are started
+	stopping := false
+
+	for running > 0 {
 		select {
 		case sig := <-signals:
-			for _, mp := range members {
-				mp.Signal(sig)
+			for i, mp := range members {
+				if mp != nil && !exited[i] {
+					mp.Signal(sig)
+				}
+			}
+
+			if !stopping {
+				stopping = true
+				step.skipRemaining(next)
 			}
 
-			for _, mp := range members {
-				err := <-mp.Wait()
-				if err != nil {
-					errorMessages = append(errorMessages, err.Error())
+		case exit := <-exits:
+			exited[exit.index] = true
+			running--
+
+			if exit.err != nil && !interrupted[exit.index] {
+				errorMessages = append(errorMessages, exit.err.Error())
+			}
+
+			if step.failFast && !stopping && step.failed(exit) {
+				stopping = true
+
+				for i, mp := range members {
+					if mp != nil && !exited[i] {
+						interrupted[i] = true
+						mp.Signal(os.Interrupt)
+						step.delegate.Interrupted(i)
+					}
 				}
+
+				step.skipRemaining(next)
 			}
 
-			break dance
-		case err := <-mp.Wait():
-			if err != nil {
-				errorMessages = append(errorMessages, err.Error())
+			if !stopping && next < len(step.steps) {
+				start()
 			}
 		}
 	}
@@ -71,18 +177,39 @@ dance:
 	return nil
 }
 
-func (source aggregateStep) Release() {
+// skipRemaining tells the delegate about each step from next on, none of
+// which will be started.
+func (step *aggregateStep) skipRemaining(next int) {
+	for i := next; i < len(step.steps); i++ {
+		step.delegate.Skipped(i)
+	}
+}
+
+func (step *aggregateStep) failed(exit aggregateExit) bool {
+	if exit.err != nil {
+		return true
+	}
+
+	var succeeded Success
+	return step.steps[exit.index].Result(&succeeded) && !bool(succeeded)
+}
 
-	for _, src := range source {
+func (source *aggregateStep) Release() {
+	for _, src := range source.steps {
 		src.Release()
 	}
 }
 
-func (source aggregateStep) Result(x interface{}) bool {
+func (source *aggregateStep) Result(x interface{}) bool {
 	if success, ok := x.(*Success); ok {
+		if source.started < len(source.steps) {
+			*success = false
+			return true
+		}
+
 		succeeded := true
 		anyIndicated := false
-		for _, src := range source {
+		for _, src := range source.steps {
 			var s Success
 			if !src.Result(&s) {
 				continue
@@ -215,3 +215,211 @@ var _ = Describe("Aggregate", func() {
 		})
 	})
 })
+
+var _ = Describe("LimitedAggregate", func() {
+	var (
+		fakeStepA *fakes.FakeStepFactory
+		fakeStepB *fakes.FakeStepFactory
+
+		limit        int
+		failFast     bool
+		fakeDelegate *fakes.FakeAggregateDelegate
+
+		inStep *fakes.FakeStep
+		repo   *SourceRepository
+
+		outStepA *fakes.FakeStep
+		outStepB *fakes.FakeStep
+
+		finishA chan error
+
+		step    Step
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeStepA = new(fakes.FakeStepFactory)
+		fakeStepB = new(fakes.FakeStepFactory)
+
+		limit = 0
+		failFast = false
+		fakeDelegate = new(fakes.FakeAggregateDelegate)
+
+		inStep = new(fakes.FakeStep)
+		repo = NewSourceRepository()
+
+		finishA = make(chan error, 1)
+
+		outStepA = new(fakes.FakeStep)
+		outStepA.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			return <-finishA
+		}
+		fakeStepA.UsingReturns(outStepA)
+
+		outStepB = new(fakes.FakeStep)
+		outStepB.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+			close(ready)
+			<-signals
+			return ErrInterrupted
+		}
+		fakeStepB.UsingReturns(outStepB)
+	})
+
+	JustBeforeEach(func() {
+		step = LimitedAggregate([]StepFactory{fakeStepA, fakeStepB}, limit, failFast, fakeDelegate).Using(inStep, repo)
+		process = ifrit.Invoke(step)
+	})
+
+	Context("with a limit", func() {
+		BeforeEach(func() {
+			limit = 1
+			outStepB.RunStub = nil
+		})
+
+		It("does not start more steps than the limit", func() {
+			Consistently(outStepB.RunCallCount).Should(BeZero())
+
+			finishA <- nil
+
+			Eventually(outStepB.RunCallCount).Should(Equal(1))
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+		})
+
+		Context("when signalled", func() {
+			It("does not start the remaining steps", func() {
+				process.Signal(os.Interrupt)
+				finishA <- ErrInterrupted
+
+				Eventually(process.Wait()).Should(Receive(MatchError("sources failed:\ninterrupted")))
+				Ω(outStepB.RunCallCount()).Should(BeZero())
+			})
+
+			It("tells the delegate which steps it skipped", func() {
+				process.Signal(os.Interrupt)
+				finishA <- ErrInterrupted
+
+				Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+
+				Ω(fakeDelegate.SkippedCallCount()).Should(Equal(1))
+				Ω(fakeDelegate.SkippedArgsForCall(0)).Should(Equal(1))
+			})
+
+			It("only tells the delegate once when signalled again", func() {
+				process.Signal(os.Interrupt)
+				process.Signal(os.Interrupt)
+				finishA <- ErrInterrupted
+
+				Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+
+				Ω(fakeDelegate.SkippedCallCount()).Should(Equal(1))
+			})
+
+			It("does not succeed, even if the steps it started did", func() {
+				outStepA.ResultStub = successResult(true)
+
+				process.Signal(os.Interrupt)
+				finishA <- nil
+
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var succeeded Success
+				Ω(step.Result(&succeeded)).Should(BeTrue())
+				Ω(succeeded).Should(Equal(Success(false)))
+			})
+		})
+	})
+
+	Context("when failing fast", func() {
+		BeforeEach(func() {
+			failFast = true
+		})
+
+		Context("when a step fails", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(false)
+			})
+
+			It("interrupts the steps that are still running", func() {
+				Eventually(outStepB.RunCallCount).Should(Equal(1))
+
+				finishA <- nil
+
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var succeeded Success
+				Ω(step.Result(&succeeded)).Should(BeTrue())
+				Ω(succeeded).Should(Equal(Success(false)))
+			})
+
+			It("tells the delegate which steps it interrupted", func() {
+				Eventually(outStepB.RunCallCount).Should(Equal(1))
+
+				finishA <- nil
+
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Ω(fakeDelegate.InterruptedCallCount()).Should(Equal(1))
+				Ω(fakeDelegate.InterruptedArgsForCall(0)).Should(Equal(1))
+				Ω(fakeDelegate.SkippedCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when a step errors", func() {
+			It("interrupts the steps that are still running, and exits with the error", func() {
+				Eventually(outStepB.RunCallCount).Should(Equal(1))
+
+				finishA <- errors.New("nope A")
+
+				Eventually(process.Wait()).Should(Receive(MatchError("sources failed:\nnope A")))
+			})
+		})
+
+		Context("with a limit", func() {
+			BeforeEach(func() {
+				limit = 1
+			})
+
+			It("does not start the remaining steps once one fails", func() {
+				finishA <- errors.New("nope A")
+
+				Eventually(process.Wait()).Should(Receive(MatchError("sources failed:\nnope A")))
+				Ω(outStepB.RunCallCount()).Should(BeZero())
+			})
+
+			It("tells the delegate which steps it skipped", func() {
+				finishA <- errors.New("nope A")
+
+				Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+
+				Ω(fakeDelegate.SkippedCallCount()).Should(Equal(1))
+				Ω(fakeDelegate.SkippedArgsForCall(0)).Should(Equal(1))
+				Ω(fakeDelegate.InterruptedCallCount()).Should(BeZero())
+			})
+
+			It("does not succeed", func() {
+				finishA <- errors.New("nope A")
+
+				Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+
+				var succeeded Success
+				Ω(step.Result(&succeeded)).Should(BeTrue())
+				Ω(succeeded).Should(Equal(Success(false)))
+			})
+		})
+
+		Context("when no step fails", func() {
+			BeforeEach(func() {
+				outStepA.ResultStub = successResult(true)
+				outStepB.RunStub = nil
+			})
+
+			It("runs every step", func() {
+				finishA <- nil
+
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+				Ω(outStepB.RunCallCount()).Should(Equal(1))
+			})
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package exec
 
-import "os"
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
 
 type ensure struct {
 	stepFactory   StepFactory
@@ -32,9 +36,26 @@ func (o ensure) Using(prev Step, repo *SourceRepository) Step {
 }
 
 func (o *ensure) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
-	stepRunErr := o.step.Run(signals, ready)
+	stepProcess := ifrit.Background(o.step)
 
-	if stepRunErr != nil {
+	var aborted bool
+	var stepRunErr error
+
+dance:
+	for {
+		select {
+		case stepRunErr = <-stepProcess.Wait():
+			break dance
+
+		case sig := <-signals:
+			stepProcess.Signal(sig)
+			aborted = true
+		}
+	}
+
+	// the hook still runs if the step was interrupted by an abort, so that it
+	// can clean up after it
+	if stepRunErr != nil && !aborted {
 		return stepRunErr
 	}
 
@@ -43,6 +64,11 @@ func (o *ensure) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	// Therefore there is no need to check the return value here.
 	o.ensure = o.ensureFactory.Using(o.step, o.repo)
 	err := o.ensure.Run(signals, make(chan struct{})) // TODO test
+
+	if stepRunErr != nil {
+		return stepRunErr
+	}
+
 	return err
 }
 
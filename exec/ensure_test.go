@@ -92,7 +92,7 @@ var _ = Describe("Ensure Step", func() {
 		Ω(hook.RunCallCount()).Should(Equal(0))
 	})
 
-	It("propagates signals to the first step when first step is running", func() {
+	It("propagates signals to the first step when first step is running, and then runs the hook", func() {
 		step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
 			close(ready)
 
@@ -106,7 +106,7 @@ var _ = Describe("Ensure Step", func() {
 
 		Eventually(step.RunCallCount).Should(Equal(1))
 		Eventually(process.Wait()).Should(Receive(errorMatching("interrupted")))
-		Ω(hook.RunCallCount()).Should(Equal(0))
+		Ω(hook.RunCallCount()).Should(Equal(1))
 	})
 
 	It("propagates signals to the hook when the hook is running", func() {
@@ -119,11 +119,12 @@ var _ = Describe("Ensure Step", func() {
 
 		process := ifrit.Background(ensureStep)
 
+		Eventually(hook.RunCallCount).Should(Equal(1))
+
 		process.Signal(os.Kill)
 
-		Eventually(step.RunCallCount).Should(Equal(1))
 		Eventually(process.Wait()).Should(Receive(errorMatching("interrupted")))
-		Ω(hook.RunCallCount()).Should(Equal(1))
+		Ω(step.RunCallCount()).Should(Equal(1))
 	})
 
 	Describe("Result", func() {
@@ -0,0 +1,33 @@
+package exec
+
+import "os"
+
+type errorStep struct {
+	err error
+}
+
+// Error returns a step that does nothing but error with the given error.
+func Error(err error) StepFactory {
+	return errorStep{err: err}
+}
+
+func (step errorStep) Using(prev Step, repo *SourceRepository) Step {
+	return &step
+}
+
+func (step *errorStep) Run(<-chan os.Signal, chan<- struct{}) error {
+	return step.err
+}
+
+func (*errorStep) Release() {}
+
+func (*errorStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = false
+		return true
+
+	default:
+		return false
+	}
+}
@@ -12,8 +12,8 @@ import (
 type Factory interface {
 	Get(SourceName, worker.Identifier, GetDelegate, atc.ResourceConfig, atc.Params, atc.Tags, atc.Version) StepFactory
 	Put(worker.Identifier, PutDelegate, atc.ResourceConfig, atc.Tags, atc.Params) StepFactory
-	// Delete(atc.ResourceConfig, atc.Params, atc.Version) Step
-	Task(SourceName, worker.Identifier, TaskDelegate, Privileged, atc.Tags, TaskConfigSource) StepFactory
+	Delete(worker.Identifier, DeleteDelegate, atc.ResourceConfig, atc.Tags, atc.Params) StepFactory
+	Task(SourceName, worker.Identifier, TaskDelegate, Privileged, atc.Tags, TaskConfigSource, map[string]string, map[string]string) StepFactory
 
 	DependentGet(SourceName, worker.Identifier, GetDelegate, atc.ResourceConfig, atc.Tags, atc.Params) StepFactory
 }
@@ -21,7 +21,15 @@ type Factory interface {
 //go:generate counterfeiter . TaskDelegate
 
 type TaskDelegate interface {
-	Initializing(atc.TaskConfig)
+	// ImageVersion returns the version of the task's image_resource that has
+	// already been chosen for the build, e.g. by the build being rerun.
+	ImageVersion() (atc.Version, bool, error)
+
+	// ImageVersionChosen records the version of the task's image_resource
+	// chosen for the build, before it is fetched.
+	ImageVersionChosen(atc.Version) error
+
+	Initializing(atc.TaskConfig, atc.Version)
 	Started()
 
 	Finished(ExitStatus)
@@ -51,6 +59,12 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . DeleteDelegate
+
+type DeleteDelegate interface {
+	ResourceDelegate
+}
+
 type HijackedProcess interface {
 	Wait() (int, error)
 	SetTTY(atc.HijackTTYSpec) error
@@ -0,0 +1,69 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeAggregateDelegate struct {
+	InterruptedStub        func(index int)
+	interruptedMutex       sync.RWMutex
+	interruptedArgsForCall []struct {
+		index int
+	}
+	SkippedStub        func(index int)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		index int
+	}
+}
+
+func (fake *FakeAggregateDelegate) Interrupted(index int) {
+	fake.interruptedMutex.Lock()
+	fake.interruptedArgsForCall = append(fake.interruptedArgsForCall, struct {
+		index int
+	}{index})
+	fake.interruptedMutex.Unlock()
+	if fake.InterruptedStub != nil {
+		fake.InterruptedStub(index)
+	}
+}
+
+func (fake *FakeAggregateDelegate) InterruptedCallCount() int {
+	fake.interruptedMutex.RLock()
+	defer fake.interruptedMutex.RUnlock()
+	return len(fake.interruptedArgsForCall)
+}
+
+func (fake *FakeAggregateDelegate) InterruptedArgsForCall(i int) int {
+	fake.interruptedMutex.RLock()
+	defer fake.interruptedMutex.RUnlock()
+	return fake.interruptedArgsForCall[i].index
+}
+
+func (fake *FakeAggregateDelegate) Skipped(index int) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		index int
+	}{index})
+	fake.skippedMutex.Unlock()
+	if fake.SkippedStub != nil {
+		fake.SkippedStub(index)
+	}
+}
+
+func (fake *FakeAggregateDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeAggregateDelegate) SkippedArgsForCall(i int) int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return fake.skippedArgsForCall[i].index
+}
+
+var _ exec.AggregateDelegate = new(FakeAggregateDelegate)
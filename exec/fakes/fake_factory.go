@@ -36,7 +36,19 @@ type FakeFactory struct {
 	putReturns struct {
 		result1 exec.StepFactory
 	}
-	TaskStub        func(exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource) exec.StepFactory
+	DeleteStub        func(worker.Identifier, exec.DeleteDelegate, atc.ResourceConfig, atc.Tags, atc.Params) exec.StepFactory
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 worker.Identifier
+		arg2 exec.DeleteDelegate
+		arg3 atc.ResourceConfig
+		arg4 atc.Tags
+		arg5 atc.Params
+	}
+	deleteReturns struct {
+		result1 exec.StepFactory
+	}
+	TaskStub        func(exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, map[string]string, map[string]string) exec.StepFactory
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1 exec.SourceName
@@ -45,6 +57,8 @@ type FakeFactory struct {
 		arg4 exec.Privileged
 		arg5 atc.Tags
 		arg6 exec.TaskConfigSource
+		arg7 map[string]string
+		arg8 map[string]string
 	}
 	taskReturns struct {
 		result1 exec.StepFactory
@@ -138,7 +152,43 @@ func (fake *FakeFactory) PutReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 exec.SourceName, arg2 worker.Identifier, arg3 exec.TaskDelegate, arg4 exec.Privileged, arg5 atc.Tags, arg6 exec.TaskConfigSource) exec.StepFactory {
+func (fake *FakeFactory) Delete(arg1 worker.Identifier, arg2 exec.DeleteDelegate, arg3 atc.ResourceConfig, arg4 atc.Tags, arg5 atc.Params) exec.StepFactory {
+	fake.deleteMutex.Lock()
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 worker.Identifier
+		arg2 exec.DeleteDelegate
+		arg3 atc.ResourceConfig
+		arg4 atc.Tags
+		arg5 atc.Params
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.deleteMutex.Unlock()
+	if fake.DeleteStub != nil {
+		return fake.DeleteStub(arg1, arg2, arg3, arg4, arg5)
+	} else {
+		return fake.deleteReturns.result1
+	}
+}
+
+func (fake *FakeFactory) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeFactory) DeleteArgsForCall(i int) (worker.Identifier, exec.DeleteDelegate, atc.ResourceConfig, atc.Tags, atc.Params) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2, fake.deleteArgsForCall[i].arg3, fake.deleteArgsForCall[i].arg4, fake.deleteArgsForCall[i].arg5
+}
+
+func (fake *FakeFactory) DeleteReturns(result1 exec.StepFactory) {
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) Task(arg1 exec.SourceName, arg2 worker.Identifier, arg3 exec.TaskDelegate, arg4 exec.Privileged, arg5 atc.Tags, arg6 exec.TaskConfigSource, arg7 map[string]string, arg8 map[string]string) exec.StepFactory {
 	fake.taskMutex.Lock()
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
 		arg1 exec.SourceName
@@ -147,10 +197,12 @@ func (fake *FakeFactory) Task(arg1 exec.SourceName, arg2 worker.Identifier, arg3
 		arg4 exec.Privileged
 		arg5 atc.Tags
 		arg6 exec.TaskConfigSource
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg7 map[string]string
+		arg8 map[string]string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	} else {
 		return fake.taskReturns.result1
 	}
@@ -162,10 +214,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, map[string]string, map[string]string) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
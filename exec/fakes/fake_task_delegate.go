@@ -10,10 +10,27 @@ import (
 )
 
 type FakeTaskDelegate struct {
-	InitializingStub        func(atc.TaskConfig)
+	ImageVersionStub        func() (atc.Version, bool, error)
+	imageVersionMutex       sync.RWMutex
+	imageVersionArgsForCall []struct{}
+	imageVersionReturns     struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}
+	ImageVersionChosenStub        func(atc.Version) error
+	imageVersionChosenMutex       sync.RWMutex
+	imageVersionChosenArgsForCall []struct {
+		arg1 atc.Version
+	}
+	imageVersionChosenReturns struct {
+		result1 error
+	}
+	InitializingStub        func(atc.TaskConfig, atc.Version)
 	initializingMutex       sync.RWMutex
 	initializingArgsForCall []struct {
 		arg1 atc.TaskConfig
+		arg2 atc.Version
 	}
 	StartedStub         func()
 	startedMutex        sync.RWMutex
@@ -42,14 +59,73 @@ type FakeTaskDelegate struct {
 	}
 }
 
-func (fake *FakeTaskDelegate) Initializing(arg1 atc.TaskConfig) {
+func (fake *FakeTaskDelegate) ImageVersion() (atc.Version, bool, error) {
+	fake.imageVersionMutex.Lock()
+	fake.imageVersionArgsForCall = append(fake.imageVersionArgsForCall, struct{}{})
+	fake.imageVersionMutex.Unlock()
+	if fake.ImageVersionStub != nil {
+		return fake.ImageVersionStub()
+	} else {
+		return fake.imageVersionReturns.result1, fake.imageVersionReturns.result2, fake.imageVersionReturns.result3
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageVersionCallCount() int {
+	fake.imageVersionMutex.RLock()
+	defer fake.imageVersionMutex.RUnlock()
+	return len(fake.imageVersionArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageVersionReturns(result1 atc.Version, result2 bool, result3 error) {
+	fake.ImageVersionStub = nil
+	fake.imageVersionReturns = struct {
+		result1 atc.Version
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskDelegate) ImageVersionChosen(arg1 atc.Version) error {
+	fake.imageVersionChosenMutex.Lock()
+	fake.imageVersionChosenArgsForCall = append(fake.imageVersionChosenArgsForCall, struct {
+		arg1 atc.Version
+	}{arg1})
+	fake.imageVersionChosenMutex.Unlock()
+	if fake.ImageVersionChosenStub != nil {
+		return fake.ImageVersionChosenStub(arg1)
+	} else {
+		return fake.imageVersionChosenReturns.result1
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageVersionChosenCallCount() int {
+	fake.imageVersionChosenMutex.RLock()
+	defer fake.imageVersionChosenMutex.RUnlock()
+	return len(fake.imageVersionChosenArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageVersionChosenArgsForCall(i int) atc.Version {
+	fake.imageVersionChosenMutex.RLock()
+	defer fake.imageVersionChosenMutex.RUnlock()
+	return fake.imageVersionChosenArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) ImageVersionChosenReturns(result1 error) {
+	fake.ImageVersionChosenStub = nil
+	fake.imageVersionChosenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) Initializing(arg1 atc.TaskConfig, arg2 atc.Version) {
 	fake.initializingMutex.Lock()
 	fake.initializingArgsForCall = append(fake.initializingArgsForCall, struct {
 		arg1 atc.TaskConfig
-	}{arg1})
+		arg2 atc.Version
+	}{arg1, arg2})
 	fake.initializingMutex.Unlock()
 	if fake.InitializingStub != nil {
-		fake.InitializingStub(arg1)
+		fake.InitializingStub(arg1, arg2)
 	}
 }
 
@@ -59,10 +135,10 @@ func (fake *FakeTaskDelegate) InitializingCallCount() int {
 	return len(fake.initializingArgsForCall)
 }
 
-func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) atc.TaskConfig {
+func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) (atc.TaskConfig, atc.Version) {
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
-	return fake.initializingArgsForCall[i].arg1
+	return fake.initializingArgsForCall[i].arg1, fake.initializingArgsForCall[i].arg2
 }
 
 func (fake *FakeTaskDelegate) Started() {
@@ -3,6 +3,7 @@ package exec
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 
@@ -15,6 +16,11 @@ type gardenFactory struct {
 	workerClient    worker.Client
 	resourceTracker resource.Tracker
 	uuidGenerator   UUIDGenFunc
+
+	defaultContainerLimits atc.ContainerLimits
+	maxContainerLimits     atc.ContainerLimits
+
+	abortGracePeriod time.Duration
 }
 
 type UUIDGenFunc func() string
@@ -23,11 +29,19 @@ func NewGardenFactory(
 	workerClient worker.Client,
 	resourceTracker resource.Tracker,
 	uuidGenerator UUIDGenFunc,
+	defaultContainerLimits atc.ContainerLimits,
+	maxContainerLimits atc.ContainerLimits,
+	abortGracePeriod time.Duration,
 ) Factory {
 	return &gardenFactory{
 		workerClient:    workerClient,
 		resourceTracker: resourceTracker,
 		uuidGenerator:   uuidGenerator,
+
+		defaultContainerLimits: defaultContainerLimits,
+		maxContainerLimits:     maxContainerLimits,
+
+		abortGracePeriod: abortGracePeriod,
 	}
 }
 
@@ -46,6 +60,8 @@ func (factory *gardenFactory) DependentGet(sourceName SourceName, id worker.Iden
 		Type:    resource.ResourceType(config.Type),
 		Tags:    tags,
 
+		GracePeriod: factory.abortGracePeriod,
+
 		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
 			return r.Get(resource.IOConfig{
 				Stdout: delegate.Stdout(),
@@ -70,6 +86,8 @@ func (factory *gardenFactory) Get(sourceName SourceName, id worker.Identifier, d
 		Type:    resource.ResourceType(config.Type),
 		Tags:    tags,
 
+		GracePeriod: factory.abortGracePeriod,
+
 		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
 			return r.Get(resource.IOConfig{
 				Stdout: delegate.Stdout(),
@@ -91,6 +109,8 @@ func (factory *gardenFactory) Put(id worker.Identifier, delegate PutDelegate, co
 		Type:    resource.ResourceType(config.Type),
 		Tags:    tags,
 
+		GracePeriod: factory.abortGracePeriod,
+
 		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
 			return r.Put(resource.IOConfig{
 				Stdout: delegate.Stdout(),
@@ -100,7 +120,30 @@ func (factory *gardenFactory) Put(id worker.Identifier, delegate PutDelegate, co
 	}
 }
 
-func (factory *gardenFactory) Task(sourceName SourceName, id worker.Identifier, delegate TaskDelegate, privileged Privileged, tags atc.Tags, configSource TaskConfigSource) StepFactory {
+func (factory *gardenFactory) Delete(id worker.Identifier, delegate DeleteDelegate, config atc.ResourceConfig, tags atc.Tags, params atc.Params) StepFactory {
+	return resourceStep{
+		Session: resource.Session{
+			ID: id,
+		},
+
+		Delegate: delegate,
+
+		Tracker: factory.resourceTracker,
+		Type:    resource.ResourceType(config.Type),
+		Tags:    tags,
+
+		GracePeriod: factory.abortGracePeriod,
+
+		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
+			return r.Delete(resource.IOConfig{
+				Stdout: delegate.Stdout(),
+				Stderr: delegate.Stderr(),
+			}, config.Source, params, resourceSource{s})
+		},
+	}
+}
+
+func (factory *gardenFactory) Task(sourceName SourceName, id worker.Identifier, delegate TaskDelegate, privileged Privileged, tags atc.Tags, configSource TaskConfigSource, inputMapping map[string]string, outputMapping map[string]string) StepFactory {
 
 	artifactsRoot := filepath.Join("/tmp", "build", factory.uuidGenerator())
 
@@ -115,7 +158,16 @@ func (factory *gardenFactory) Task(sourceName SourceName, id worker.Identifier,
 		Privileged:   privileged,
 		ConfigSource: configSource,
 
+		InputMapping:  inputMapping,
+		OutputMapping: outputMapping,
+
+		DefaultContainerLimits: factory.defaultContainerLimits,
+		MaxContainerLimits:     factory.maxContainerLimits,
+
 		WorkerClient: factory.workerClient,
+		Tracker:      factory.resourceTracker,
+
+		GracePeriod: factory.abortGracePeriod,
 
 		artifactsRoot: artifactsRoot,
 	}
@@ -0,0 +1,43 @@
+package exec
+
+import (
+	"os"
+	"time"
+)
+
+// withGracePeriod relays signals to a process that terminates gracefully when
+// sent os.Interrupt, and is killed when sent os.Kill.
+//
+// The first signal is relayed as os.Interrupt, unless it is already os.Kill
+// (e.g. when a step times out). The process is then killed if it is signalled
+// again, or if it has not exited within the grace period.
+func withGracePeriod(signals <-chan os.Signal, gracePeriod time.Duration, exited <-chan struct{}) <-chan os.Signal {
+	relayed := make(chan os.Signal, 2)
+
+	go func() {
+		var timeout <-chan time.Time
+
+		for {
+			select {
+			case sig := <-signals:
+				if sig == os.Kill || timeout != nil {
+					relayed <- os.Kill
+					return
+				}
+
+				relayed <- os.Interrupt
+
+				timeout = time.After(gracePeriod)
+
+			case <-timeout:
+				relayed <- os.Kill
+				return
+
+			case <-exited:
+				return
+			}
+		}
+	}()
+
+	return relayed
+}
@@ -0,0 +1,91 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+type onAbort struct {
+	stepFactory  StepFactory
+	abortFactory StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	step  Step
+	abort Step
+}
+
+// OnAbort runs the hook once the step has exited after being signalled,
+// i.e. when the build has been aborted while the step was running.
+func OnAbort(
+	stepFactory StepFactory,
+	abortFactory StepFactory,
+) StepFactory {
+	return onAbort{
+		stepFactory:  stepFactory,
+		abortFactory: abortFactory,
+	}
+}
+
+func (o onAbort) Using(prev Step, repo *SourceRepository) Step {
+	o.repo = repo
+	o.prev = prev
+
+	o.step = o.stepFactory.Using(o.prev, o.repo)
+	return &o
+}
+
+func (o *onAbort) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	stepProcess := ifrit.Background(o.step)
+
+	var aborted bool
+	var stepRunErr error
+
+dance:
+	for {
+		select {
+		case stepRunErr = <-stepProcess.Wait():
+			break dance
+
+		case sig := <-signals:
+			stepProcess.Signal(sig)
+			aborted = true
+		}
+	}
+
+	if !aborted {
+		return stepRunErr
+	}
+
+	o.abort = o.abortFactory.Using(o.step, o.repo)
+	o.abort.Run(signals, make(chan struct{}))
+
+	// the build was aborted regardless of how the hook went
+	return stepRunErr
+}
+
+func (o *onAbort) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		if o.abort != nil {
+			*v = false
+			return true
+		}
+
+		return o.step.Result(v)
+
+	default:
+		return false
+	}
+}
+
+func (o *onAbort) Release() {
+	if o.step != nil {
+		o.step.Release()
+	}
+	if o.abort != nil {
+		o.abort.Release()
+	}
+}
@@ -0,0 +1,152 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/fakes"
+)
+
+var _ = Describe("On Abort Step", func() {
+	var (
+		stepFactory  *fakes.FakeStepFactory
+		abortFactory *fakes.FakeStepFactory
+
+		step *fakes.FakeStep
+		hook *fakes.FakeStep
+
+		previousStep *fakes.FakeStep
+
+		repo *exec.SourceRepository
+
+		onAbortFactory exec.StepFactory
+		onAbortStep    exec.Step
+	)
+
+	BeforeEach(func() {
+		stepFactory = &fakes.FakeStepFactory{}
+		abortFactory = &fakes.FakeStepFactory{}
+
+		step = &fakes.FakeStep{}
+		hook = &fakes.FakeStep{}
+
+		previousStep = &fakes.FakeStep{}
+
+		stepFactory.UsingReturns(step)
+		abortFactory.UsingReturns(hook)
+
+		repo = exec.NewSourceRepository()
+
+		onAbortFactory = exec.OnAbort(stepFactory, abortFactory)
+		onAbortStep = onAbortFactory.Using(previousStep, repo)
+	})
+
+	Context("when the step is signalled", func() {
+		BeforeEach(func() {
+			step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+				close(ready)
+
+				<-signals
+				return errors.New("interrupted")
+			}
+		})
+
+		It("propagates the signal to the step, runs the hook, and returns the step's error", func() {
+			process := ifrit.Background(onAbortStep)
+
+			Eventually(step.RunCallCount).Should(Equal(1))
+
+			process.Signal(os.Kill)
+
+			Eventually(process.Wait()).Should(Receive(MatchError("interrupted")))
+			Ω(hook.RunCallCount()).Should(Equal(1))
+		})
+
+		It("provides the step as the previous step to the hook", func() {
+			process := ifrit.Background(onAbortStep)
+
+			Eventually(step.RunCallCount).Should(Equal(1))
+
+			process.Signal(os.Kill)
+
+			Eventually(process.Wait()).Should(Receive())
+
+			Ω(abortFactory.UsingCallCount()).Should(Equal(1))
+			argsPrev, argsRepo := abortFactory.UsingArgsForCall(0)
+			Ω(argsPrev).Should(Equal(step))
+			Ω(argsRepo).Should(Equal(repo))
+		})
+
+		It("does not indicate success", func() {
+			step.ResultStub = successResult(true)
+
+			process := ifrit.Background(onAbortStep)
+
+			Eventually(step.RunCallCount).Should(Equal(1))
+
+			process.Signal(os.Kill)
+
+			Eventually(process.Wait()).Should(Receive())
+
+			var success exec.Success
+			Ω(onAbortStep.Result(&success)).Should(BeTrue())
+			Ω(bool(success)).Should(BeFalse())
+		})
+
+		It("releases both the step and the hook", func() {
+			process := ifrit.Background(onAbortStep)
+
+			Eventually(step.RunCallCount).Should(Equal(1))
+
+			process.Signal(os.Kill)
+
+			Eventually(process.Wait()).Should(Receive())
+
+			onAbortStep.Release()
+			Ω(step.ReleaseCallCount()).Should(Equal(1))
+			Ω(hook.ReleaseCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when the step finishes without being signalled", func() {
+		BeforeEach(func() {
+			step.ResultStub = successResult(false)
+		})
+
+		It("does not run the hook", func() {
+			process := ifrit.Background(onAbortStep)
+
+			Eventually(process.Wait()).Should(Receive(BeNil()))
+			Ω(hook.RunCallCount()).Should(Equal(0))
+		})
+
+		It("returns the step's result", func() {
+			process := ifrit.Background(onAbortStep)
+
+			Eventually(process.Wait()).Should(Receive())
+
+			success := exec.Success(true)
+			Ω(onAbortStep.Result(&success)).Should(BeTrue())
+			Ω(bool(success)).Should(BeFalse())
+		})
+
+		Context("when the step errors", func() {
+			BeforeEach(func() {
+				step.RunReturns(errors.New("disaster"))
+			})
+
+			It("returns the error without running the hook", func() {
+				process := ifrit.Background(onAbortStep)
+
+				Eventually(process.Wait()).Should(Receive(MatchError("disaster")))
+				Ω(hook.RunCallCount()).Should(Equal(0))
+			})
+		})
+	})
+})
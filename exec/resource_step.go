@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"io"
 	"os"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/resource"
@@ -20,6 +21,9 @@ type resourceStep struct {
 	Type    resource.ResourceType
 	Tags    atc.Tags
 
+	// how long the resource script may take to exit once asked to terminate
+	GracePeriod time.Duration
+
 	Action func(resource.Resource, ArtifactSource, VersionInfo) resource.VersionedSource
 
 	PreviousStep Step
@@ -54,7 +58,9 @@ func (ras *resourceStep) Run(signals <-chan os.Signal, ready chan<- struct{}) er
 	ras.Resource = trackedResource
 	ras.VersionedSource = ras.Action(trackedResource, ras.Repository, versionInfo)
 
-	err = ras.VersionedSource.Run(signals, ready)
+	exited := make(chan struct{})
+	err = ras.VersionedSource.Run(withGracePeriod(signals, ras.GracePeriod, exited), ready)
+	close(exited)
 
 	if err, ok := err.(resource.ErrResourceScriptFailed); ok {
 		ras.exitStatus = err.ExitStatus
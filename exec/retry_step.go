@@ -0,0 +1,89 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/tedsuo/ifrit"
+)
+
+type retry struct {
+	attempts []StepFactory
+
+	prev Step
+	repo *SourceRepository
+
+	steps       []Step
+	lastAttempt Step
+}
+
+// Retry runs each attempt in turn until one of them succeeds, or until they
+// have all failed or errored. Each attempt is expected to be a separate copy
+// of the same step, so that they do not share containers.
+func Retry(attempts ...StepFactory) StepFactory {
+	return retry{
+		attempts: attempts,
+	}
+}
+
+func (r retry) Using(prev Step, repo *SourceRepository) Step {
+	r.prev = prev
+	r.repo = repo
+
+	return &r
+}
+
+func (r *retry) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	var runErr error
+
+	for _, attempt := range r.attempts {
+		r.lastAttempt = attempt.Using(r.prev, r.repo)
+		r.steps = append(r.steps, r.lastAttempt)
+
+		process := ifrit.Background(r.lastAttempt)
+
+		var signalled bool
+
+	dance:
+		for {
+			select {
+			case runErr = <-process.Wait():
+				break dance
+
+			case sig := <-signals:
+				process.Signal(sig)
+				signalled = true
+			}
+		}
+
+		if signalled {
+			return runErr
+		}
+
+		if runErr != nil {
+			continue
+		}
+
+		var success Success
+		if r.lastAttempt.Result(&success) && bool(success) {
+			return nil
+		}
+	}
+
+	return runErr
+}
+
+func (r *retry) Release() {
+	for _, step := range r.steps {
+		step.Release()
+	}
+}
+
+func (r *retry) Result(x interface{}) bool {
+	if r.lastAttempt == nil {
+		return false
+	}
+
+	return r.lastAttempt.Result(x)
+}
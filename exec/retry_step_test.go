@@ -0,0 +1,182 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Retry Step", func() {
+	var (
+		attempt1Factory *fakes.FakeStepFactory
+		attempt1Step    *fakes.FakeStep
+
+		attempt2Factory *fakes.FakeStepFactory
+		attempt2Step    *fakes.FakeStep
+
+		attempt3Factory *fakes.FakeStepFactory
+		attempt3Step    *fakes.FakeStep
+
+		prev *fakes.FakeStep
+		repo *SourceRepository
+
+		step Step
+	)
+
+	BeforeEach(func() {
+		attempt1Factory = new(fakes.FakeStepFactory)
+		attempt1Step = new(fakes.FakeStep)
+		attempt1Factory.UsingReturns(attempt1Step)
+
+		attempt2Factory = new(fakes.FakeStepFactory)
+		attempt2Step = new(fakes.FakeStep)
+		attempt2Factory.UsingReturns(attempt2Step)
+
+		attempt3Factory = new(fakes.FakeStepFactory)
+		attempt3Step = new(fakes.FakeStep)
+		attempt3Factory.UsingReturns(attempt3Step)
+
+		prev = new(fakes.FakeStep)
+		repo = NewSourceRepository()
+
+		step = Retry(attempt1Factory, attempt2Factory, attempt3Factory).Using(prev, repo)
+	})
+
+	Describe("Run", func() {
+		var process ifrit.Process
+
+		JustBeforeEach(func() {
+			process = ifrit.Invoke(step)
+		})
+
+		Context("when the first attempt succeeds", func() {
+			BeforeEach(func() {
+				attempt1Step.ResultStub = successResult(true)
+			})
+
+			It("does not run the other attempts", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Ω(attempt1Step.RunCallCount()).Should(Equal(1))
+				Ω(attempt2Step.RunCallCount()).Should(BeZero())
+				Ω(attempt3Step.RunCallCount()).Should(BeZero())
+			})
+
+			It("uses the previous step and source repository", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				usedPrev, usedRepo := attempt1Factory.UsingArgsForCall(0)
+				Ω(usedPrev).Should(Equal(prev))
+				Ω(usedRepo).Should(Equal(repo))
+			})
+
+			It("is successful", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var success Success
+				Ω(step.Result(&success)).Should(BeTrue())
+				Ω(bool(success)).Should(BeTrue())
+			})
+		})
+
+		Context("when the first attempt fails and the second errors", func() {
+			BeforeEach(func() {
+				attempt1Step.ResultStub = successResult(false)
+				attempt2Step.RunReturns(errors.New("nope"))
+			})
+
+			Context("and the third attempt succeeds", func() {
+				BeforeEach(func() {
+					attempt3Step.ResultStub = successResult(true)
+				})
+
+				It("runs each attempt in order", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Ω(attempt1Step.RunCallCount()).Should(Equal(1))
+					Ω(attempt2Step.RunCallCount()).Should(Equal(1))
+					Ω(attempt3Step.RunCallCount()).Should(Equal(1))
+				})
+
+				It("is successful", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					var success Success
+					Ω(step.Result(&success)).Should(BeTrue())
+					Ω(bool(success)).Should(BeTrue())
+				})
+			})
+
+			Context("and the third attempt fails", func() {
+				BeforeEach(func() {
+					attempt3Step.ResultStub = successResult(false)
+				})
+
+				It("is not successful", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					var success Success
+					Ω(step.Result(&success)).Should(BeTrue())
+					Ω(bool(success)).Should(BeFalse())
+				})
+			})
+
+			Context("and the third attempt errors", func() {
+				disaster := errors.New("oh no!")
+
+				BeforeEach(func() {
+					attempt3Step.RunReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+				})
+			})
+		})
+
+		Context("when an attempt is interrupted", func() {
+			BeforeEach(func() {
+				attempt1Step.RunStub = func(signals <-chan os.Signal, ready chan<- struct{}) error {
+					close(ready)
+					<-signals
+					return ErrInterrupted
+				}
+			})
+
+			It("does not run the other attempts", func() {
+				Eventually(attempt1Step.RunCallCount).Should(Equal(1))
+
+				process.Signal(os.Interrupt)
+
+				Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+				Ω(attempt2Step.RunCallCount()).Should(BeZero())
+				Ω(attempt3Step.RunCallCount()).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("Release", func() {
+		BeforeEach(func() {
+			attempt1Step.ResultStub = successResult(false)
+			attempt2Step.ResultStub = successResult(true)
+		})
+
+		It("releases each attempt that was run", func() {
+			Eventually(ifrit.Invoke(step).Wait()).Should(Receive(BeNil()))
+
+			step.Release()
+
+			Ω(attempt1Step.ReleaseCallCount()).Should(Equal(1))
+			Ω(attempt2Step.ReleaseCallCount()).Should(Equal(1))
+			Ω(attempt3Step.ReleaseCallCount()).Should(BeZero())
+		})
+	})
+})
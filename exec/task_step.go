@@ -6,13 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
+	"github.com/tedsuo/ifrit"
 )
 
 const taskProcessPropertyName = "concourse:task-process"
@@ -20,6 +24,20 @@ const taskExitStatusPropertyName = "concourse:exit-status"
 
 var ErrInterrupted = errors.New("interrupted")
 
+var ErrNoImageVersions = errors.New("no versions of the image resource are available")
+
+// imageRootFSURIFile is the file, at the top of what an image_resource
+// fetches, containing the URI of the rootfs to run the task in.
+const imageRootFSURIFile = "rootfs_uri"
+
+type ContainerLimitsExceededError struct {
+	Limits []string
+}
+
+func (err ContainerLimitsExceededError) Error() string {
+	return fmt.Sprintf("container limits exceed the maximum: %s", strings.Join(err.Limits, ", "))
+}
+
 type MissingInputsError struct {
 	Inputs []string
 }
@@ -39,14 +57,26 @@ type taskStep struct {
 	Tags         atc.Tags
 	ConfigSource TaskConfigSource
 
+	InputMapping  map[string]string
+	OutputMapping map[string]string
+
+	DefaultContainerLimits atc.ContainerLimits
+	MaxContainerLimits     atc.ContainerLimits
+
 	WorkerClient worker.Client
+	Tracker      resource.Tracker
+
+	// how long the task's process may take to exit once asked to terminate
+	GracePeriod time.Duration
 
 	prev Step
 	repo *SourceRepository
 
 	container     worker.Container
+	imageResource resource.Resource
 	process       garden.Process
 	artifactsRoot string
+	outputs       []atc.TaskOutputConfig
 
 	exitStatus int
 }
@@ -112,22 +142,55 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		tags := step.mergeTags(step.Tags, config.Tags)
 
-		step.Delegate.Initializing(config)
-
-		step.container, err = step.WorkerClient.CreateContainer(
-			step.WorkerID,
-			worker.TaskContainerSpec{
-				Platform:   config.Platform,
-				Tags:       tags,
-				Image:      config.Image,
-				Privileged: bool(step.Privileged),
-			},
-		)
+		limits := config.ContainerLimits.WithDefaults(step.DefaultContainerLimits)
+
+		exceeding := limits.Exceeding(step.MaxContainerLimits)
+		if len(exceeding) > 0 {
+			return ContainerLimitsExceededError{exceeding}
+		}
+
+		var imageVersion atc.Version
+		if config.ImageResource != nil {
+			config.Image, imageVersion, err = step.fetchImage(signals, *config.ImageResource)
+			if err != nil {
+				return err
+			}
+		}
+
+		step.Delegate.Initializing(config, imageVersion)
+
+		containerSpec := worker.TaskContainerSpec{
+			Platform:   config.Platform,
+			Tags:       tags,
+			Image:      config.Image,
+			Privileged: bool(step.Privileged),
+			Limits:     limits,
+		}
+
+		// caches are kept per job, so one-off builds don't get any
+		if len(config.Caches) > 0 && step.WorkerID.JobName != "" {
+			containerSpec.CacheID = &worker.Identifier{
+				PipelineName: step.WorkerID.PipelineName,
+				JobName:      step.WorkerID.JobName,
+				Name:         step.WorkerID.Name,
+				Type:         worker.ContainerTypeCache,
+			}
+
+			// the artifacts root differs for every build, so caches are kept
+			// relative to it
+			containerSpec.CacheDir = step.artifactsRoot
+
+			for _, cache := range config.Caches {
+				containerSpec.Caches = append(containerSpec.Caches, cache.Path)
+			}
+		}
+
+		step.container, err = step.WorkerClient.CreateContainer(step.WorkerID, containerSpec)
 		if err != nil {
 			return err
 		}
 
-		err = step.ensureBuildDirExists(step.container)
+		err = step.ensureDirExists(step.container, step.artifactsRoot)
 		if err != nil {
 			return err
 		}
@@ -137,6 +200,15 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		for _, output := range config.Outputs {
+			err = step.ensureDirExists(step.container, path.Join(step.artifactsRoot, outputPath(output)))
+			if err != nil {
+				return err
+			}
+		}
+
+		step.outputs = config.Outputs
+
 		step.Delegate.Started()
 
 		step.process, err = step.container.Run(garden.ProcessSpec{
@@ -173,29 +245,64 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		}
 	}()
 
-	select {
-	case <-signals:
-		step.container.Stop(false)
-		return ErrInterrupted
+	exited := make(chan struct{})
+	defer close(exited)
 
-	case status := <-waitExitStatus:
-		step.repo.RegisterSource(step.SourceName, step)
+	processSignals := withGracePeriod(signals, step.GracePeriod, exited)
 
-		step.exitStatus = status
+	var interrupted bool
 
-		step.Delegate.Finished(ExitStatus(status))
+	for {
+		select {
+		case sig := <-processSignals:
+			if sig != os.Kill {
+				// give the process a chance to clean up; it will be killed if it
+				// does not exit within the grace period
+				err := step.process.Signal(garden.SignalTerminate)
+				if err == nil {
+					interrupted = true
+					continue
+				}
+			}
 
-		statusValue := fmt.Sprintf("%d", status)
+			step.container.Stop(true)
+			return ErrInterrupted
 
-		err := step.container.SetProperty(taskExitStatusPropertyName, statusValue)
-		if err != nil {
-			return err
-		}
+		case status := <-waitExitStatus:
+			if interrupted {
+				return ErrInterrupted
+			}
 
-		return nil
+			step.repo.RegisterSource(step.SourceName, step)
 
-	case err := <-waitErr:
-		return err
+			for _, output := range step.outputs {
+				outputName := output.Name
+				if mappedName, found := step.OutputMapping[output.Name]; found {
+					outputName = mappedName
+				}
+
+				step.repo.RegisterSource(
+					SourceName(outputName),
+					newContainerSource(step.container, path.Join(step.artifactsRoot, outputPath(output))),
+				)
+			}
+
+			step.exitStatus = status
+
+			step.Delegate.Finished(ExitStatus(status))
+
+			statusValue := fmt.Sprintf("%d", status)
+
+			err := step.container.SetProperty(taskExitStatusPropertyName, statusValue)
+			if err != nil {
+				return err
+			}
+
+			return nil
+
+		case err := <-waitErr:
+			return err
+		}
 	}
 }
 
@@ -218,42 +325,104 @@ func (step *taskStep) Release() {
 	if step.container != nil {
 		step.container.Release()
 	}
+
+	if step.imageResource != nil {
+		step.imageResource.Release()
+	}
 }
 
 func (step *taskStep) StreamFile(source string) (io.ReadCloser, error) {
-	out, err := step.container.StreamOut(garden.StreamOutSpec{
-		Path: path.Join(step.artifactsRoot, source),
-	})
+	return newContainerSource(step.container, step.artifactsRoot).StreamFile(source)
+}
 
+func (step *taskStep) StreamTo(destination ArtifactDestination) error {
+	return newContainerSource(step.container, step.artifactsRoot).StreamTo(destination)
+}
+
+// fetchImage fetches the latest version of the task's image resource, and
+// returns the rootfs URI that it provides along with the version fetched.
+func (step *taskStep) fetchImage(signals <-chan os.Signal, image atc.TaskImageConfig) (string, atc.Version, error) {
+	imageID := step.WorkerID
+	imageID.Type = worker.ContainerTypeGet
+
+	var err error
+	step.imageResource, err = step.Tracker.Init(
+		resource.Session{ID: imageID},
+		resource.ResourceType(image.Type),
+		step.Tags,
+	)
 	if err != nil {
-		return nil, err
+		return "", nil, err
+	}
+
+	// like the inputs of a build, the image's version is chosen once, so that
+	// reruns of the build use the same image
+	version, found, err := step.Delegate.ImageVersion()
+	if err != nil {
+		return "", nil, err
 	}
 
+	if !found {
+		versions, err := step.imageResource.Check(image.Source, nil)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if len(versions) == 0 {
+			return "", nil, ErrNoImageVersions
+		}
+
+		version = versions[len(versions)-1]
+
+		err = step.Delegate.ImageVersionChosen(version)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	versionedSource := step.imageResource.Get(resource.IOConfig{
+		Stdout: step.Delegate.Stdout(),
+		Stderr: step.Delegate.Stderr(),
+	}, image.Source, nil, version)
+
+	process := ifrit.Background(versionedSource)
+
+	select {
+	case <-signals:
+		// there's nothing for fetching an image to clean up
+		process.Signal(os.Kill)
+		<-process.Wait()
+		return "", nil, ErrInterrupted
+
+	case err := <-process.Wait():
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	out, err := versionedSource.StreamOut(imageRootFSURIFile)
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer out.Close()
+
 	tarReader := tar.NewReader(out)
 
 	_, err = tarReader.Next()
 	if err != nil {
-		return nil, FileNotFoundError{Path: source}
+		return "", nil, FileNotFoundError{Path: imageRootFSURIFile}
 	}
 
-	return fileReadCloser{
-		Reader: tarReader,
-		Closer: out,
-	}, nil
-}
-
-func (step *taskStep) StreamTo(destination ArtifactDestination) error {
-	out, err := step.container.StreamOut(garden.StreamOutSpec{
-		Path: step.artifactsRoot + "/",
-	})
+	rootFSURI, err := ioutil.ReadAll(tarReader)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
-	return destination.StreamIn(".", out)
+	return strings.TrimSpace(string(rootFSURI)), versionedSource.Version(), nil
 }
 
-func (step *taskStep) ensureBuildDirExists(container garden.Container) error {
+func (step *taskStep) ensureDirExists(container garden.Container, dir string) error {
 	emptyTar := new(bytes.Buffer)
 
 	err := tar.NewWriter(emptyTar).Close()
@@ -262,7 +431,7 @@ func (step *taskStep) ensureBuildDirExists(container garden.Container) error {
 	}
 
 	err = container.StreamIn(garden.StreamInSpec{
-		Path:      step.artifactsRoot,
+		Path:      dir,
 		TarStream: emptyTar,
 	})
 	if err != nil {
@@ -282,9 +451,14 @@ func (step *taskStep) collectInputs(inputs []atc.TaskInputConfig) error {
 
 	var missingInputs []string
 	for _, input := range inputs {
-		source, found := step.repo.SourceFor(SourceName(input.Name))
+		inputName := input.Name
+		if sourceName, found := step.InputMapping[input.Name]; found {
+			inputName = sourceName
+		}
+
+		source, found := step.repo.SourceFor(SourceName(inputName))
 		if !found {
-			missingInputs = append(missingInputs, input.Name)
+			missingInputs = append(missingInputs, inputName)
 			continue
 		}
 
@@ -363,3 +537,58 @@ func (dest *containerDestination) StreamIn(dst string, src io.Reader) error {
 		TarStream: src,
 	})
 }
+
+func outputPath(output atc.TaskOutputConfig) string {
+	if len(output.Path) == 0 {
+		return output.Name
+	}
+
+	return output.Path
+}
+
+// containerSource is an artifact source for a directory within a task's
+// container, e.g. the task's artifacts root or one of its outputs.
+type containerSource struct {
+	container garden.Container
+	path      string
+}
+
+func newContainerSource(container garden.Container, path string) *containerSource {
+	return &containerSource{
+		container: container,
+		path:      path,
+	}
+}
+
+func (src *containerSource) StreamFile(source string) (io.ReadCloser, error) {
+	out, err := src.container.StreamOut(garden.StreamOutSpec{
+		Path: path.Join(src.path, source),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tarReader := tar.NewReader(out)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		return nil, FileNotFoundError{Path: source}
+	}
+
+	return fileReadCloser{
+		Reader: tarReader,
+		Closer: out,
+	}, nil
+}
+
+func (src *containerSource) StreamTo(destination ArtifactDestination) error {
+	out, err := src.container.StreamOut(garden.StreamOutSpec{
+		Path: src.path + "/",
+	})
+	if err != nil {
+		return err
+	}
+
+	return destination.StreamIn(".", out)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
@@ -34,18 +35,20 @@ var _ = Describe("GardenFactory", func() {
 
 		sourceName SourceName = "some-source-name"
 
+		identifier worker.Identifier
+	)
+
+	BeforeEach(func() {
 		identifier = worker.Identifier{
 			Name: "some-session-id",
 		}
-	)
 
-	BeforeEach(func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeWorkerClient = new(wfakes.FakeClient)
 
 		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string {
 			return "a-random-guid"
-		})
+		}, atc.ContainerLimits{}, atc.ContainerLimits{}, 0)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -58,6 +61,9 @@ var _ = Describe("GardenFactory", func() {
 			tags         []string
 			configSource *fakes.FakeTaskConfigSource
 
+			inputMapping  map[string]string
+			outputMapping map[string]string
+
 			inStep *fakes.FakeStep
 			repo   *SourceRepository
 
@@ -74,12 +80,15 @@ var _ = Describe("GardenFactory", func() {
 			tags = []string{"step", "tags"}
 			configSource = new(fakes.FakeTaskConfigSource)
 
+			inputMapping = nil
+			outputMapping = nil
+
 			inStep = new(fakes.FakeStep)
 			repo = NewSourceRepository()
 		})
 
 		JustBeforeEach(func() {
-			step = factory.Task(sourceName, identifier, taskDelegate, privileged, tags, configSource).Using(inStep, repo)
+			step = factory.Task(sourceName, identifier, taskDelegate, privileged, tags, configSource, inputMapping, outputMapping).Using(inStep, repo)
 			process = ifrit.Invoke(step)
 		})
 
@@ -126,7 +135,7 @@ var _ = Describe("GardenFactory", func() {
 
 					Describe("before having created the container", func() {
 						BeforeEach(func() {
-							taskDelegate.InitializingStub = func(atc.TaskConfig) {
+							taskDelegate.InitializingStub = func(atc.TaskConfig, atc.Version) {
 								defer GinkgoRecover()
 								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(BeZero())
 							}
@@ -134,7 +143,10 @@ var _ = Describe("GardenFactory", func() {
 
 						It("invokes the delegate's Initializing callback", func() {
 							Ω(taskDelegate.InitializingCallCount()).Should(Equal(1))
-							Ω(taskDelegate.InitializingArgsForCall(0)).Should(Equal(fetchedConfig))
+
+							config, imageVersion := taskDelegate.InitializingArgsForCall(0)
+							Ω(config).Should(Equal(fetchedConfig))
+							Ω(imageVersion).Should(BeNil())
 						})
 					})
 
@@ -157,7 +169,311 @@ var _ = Describe("GardenFactory", func() {
 						Ω(taskSpec.Tags).Should(ConsistOf("config", "step", "tags"))
 						Ω(taskSpec.Image).Should(Equal("some-image"))
 						Ω(taskSpec.Privileged).Should(BeFalse())
+						Ω(taskSpec.Limits).Should(BeZero())
+
+					})
+
+					Context("when the config specifies an image resource", func() {
+						var (
+							fakeImageResource        *rfakes.FakeResource
+							fakeImageVersionedSource *rfakes.FakeVersionedSource
+						)
+
+						BeforeEach(func() {
+							fetchedConfig.Image = ""
+							fetchedConfig.ImageResource = &atc.TaskImageConfig{
+								Type:   "docker-image",
+								Source: atc.Source{"repository": "some/image"},
+							}
+
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+
+							fakeImageResource = new(rfakes.FakeResource)
+							fakeTracker.InitReturns(fakeImageResource, nil)
+
+							fakeImageResource.CheckReturns([]atc.Version{
+								{"digest": "some-old-digest"},
+								{"digest": "some-digest"},
+							}, nil)
+
+							fakeImageVersionedSource = new(rfakes.FakeVersionedSource)
+							fakeImageVersionedSource.VersionReturns(atc.Version{"digest": "some-digest"})
+							fakeImageResource.GetReturns(fakeImageVersionedSource)
+
+							tarBuffer := new(bytes.Buffer)
+							tarWriter := tar.NewWriter(tarBuffer)
+
+							rootFSURI := []byte("docker:///some/image@some-digest\n")
+
+							err := tarWriter.WriteHeader(&tar.Header{
+								Name: "rootfs_uri",
+								Mode: 0644,
+								Size: int64(len(rootFSURI)),
+							})
+							Ω(err).ShouldNot(HaveOccurred())
+
+							_, err = tarWriter.Write(rootFSURI)
+							Ω(err).ShouldNot(HaveOccurred())
+
+							err = tarWriter.Close()
+							Ω(err).ShouldNot(HaveOccurred())
+
+							fakeImageVersionedSource.StreamOutReturns(ioutil.NopCloser(tarBuffer), nil)
+						})
+
+						It("initializes the image resource with a get container for the task", func() {
+							Ω(fakeTracker.InitCallCount()).Should(Equal(1))
+
+							session, typ, tags := fakeTracker.InitArgsForCall(0)
+							Ω(session.ID).Should(Equal(worker.Identifier{
+								Name: "some-session-id",
+								Type: worker.ContainerTypeGet,
+							}))
+							Ω(string(typ)).Should(Equal("docker-image"))
+							Ω(tags).Should(ConsistOf("step", "tags"))
+						})
+
+						It("fetches the latest version of the image", func() {
+							Ω(fakeImageResource.CheckCallCount()).Should(Equal(1))
+							source, version := fakeImageResource.CheckArgsForCall(0)
+							Ω(source).Should(Equal(atc.Source{"repository": "some/image"}))
+							Ω(version).Should(BeNil())
+
+							Ω(fakeImageResource.GetCallCount()).Should(Equal(1))
+							_, source, params, version := fakeImageResource.GetArgsForCall(0)
+							Ω(source).Should(Equal(atc.Source{"repository": "some/image"}))
+							Ω(params).Should(BeNil())
+							Ω(version).Should(Equal(atc.Version{"digest": "some-digest"}))
+
+							Ω(fakeImageVersionedSource.RunCallCount()).Should(Equal(1))
+						})
+
+						It("records the chosen version before fetching it", func() {
+							Ω(taskDelegate.ImageVersionCallCount()).Should(Equal(1))
+
+							Ω(taskDelegate.ImageVersionChosenCallCount()).Should(Equal(1))
+							Ω(taskDelegate.ImageVersionChosenArgsForCall(0)).Should(Equal(atc.Version{"digest": "some-digest"}))
+						})
+
+						Context("when a version of the image has already been chosen for the build", func() {
+							BeforeEach(func() {
+								taskDelegate.ImageVersionReturns(atc.Version{"digest": "some-old-digest"}, true, nil)
+							})
+
+							It("fetches that version, without checking for a newer one", func() {
+								Ω(fakeImageResource.CheckCallCount()).Should(BeZero())
+
+								Ω(fakeImageResource.GetCallCount()).Should(Equal(1))
+								_, _, _, version := fakeImageResource.GetArgsForCall(0)
+								Ω(version).Should(Equal(atc.Version{"digest": "some-old-digest"}))
+
+								Ω(taskDelegate.ImageVersionChosenCallCount()).Should(BeZero())
+							})
+						})
+
+						Context("when looking up the chosen version fails", func() {
+							disaster := errors.New("nope")
+
+							BeforeEach(func() {
+								taskDelegate.ImageVersionReturns(nil, false, disaster)
+							})
+
+							It("exits with the error, without fetching the image", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+								Ω(fakeImageResource.GetCallCount()).Should(BeZero())
+							})
+						})
+
+						Context("when recording the chosen version fails", func() {
+							disaster := errors.New("nope")
+
+							BeforeEach(func() {
+								taskDelegate.ImageVersionChosenReturns(disaster)
+							})
+
+							It("exits with the error, without fetching the image", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+								Ω(fakeImageResource.GetCallCount()).Should(BeZero())
+							})
+						})
+
+						It("creates the container with the rootfs provided by the image", func() {
+							Ω(fakeImageVersionedSource.StreamOutCallCount()).Should(Equal(1))
+							Ω(fakeImageVersionedSource.StreamOutArgsForCall(0)).Should(Equal("rootfs_uri"))
+
+							Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(1))
+							_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+
+							taskSpec := spec.(worker.TaskContainerSpec)
+							Ω(taskSpec.Image).Should(Equal("docker:///some/image@some-digest"))
+						})
+
+						It("invokes the delegate's Initializing callback with the image's version", func() {
+							Ω(taskDelegate.InitializingCallCount()).Should(Equal(1))
+
+							_, imageVersion := taskDelegate.InitializingArgsForCall(0)
+							Ω(imageVersion).Should(Equal(atc.Version{"digest": "some-digest"}))
+						})
+
+						It("releases the image resource when released", func() {
+							Eventually(process.Wait()).Should(Receive())
+
+							step.Release()
+							Ω(fakeImageResource.ReleaseCallCount()).Should(Equal(1))
+						})
+
+						Context("when the image resource has no versions", func() {
+							BeforeEach(func() {
+								fakeImageResource.CheckReturns([]atc.Version{}, nil)
+							})
+
+							It("exits with an error", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(ErrNoImageVersions)))
+							})
+
+							It("does not create a container", func() {
+								Eventually(process.Wait()).Should(Receive())
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(0))
+							})
+						})
+
+						Context("when fetching the image fails", func() {
+							disaster := errors.New("nope")
+
+							BeforeEach(func() {
+								fakeImageVersionedSource.RunReturns(disaster)
+							})
+
+							It("exits with the error", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+							})
 
+							It("does not create a container", func() {
+								Eventually(process.Wait()).Should(Receive())
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(0))
+							})
+						})
+					})
+
+					Context("when the config specifies container limits", func() {
+						BeforeEach(func() {
+							fetchedConfig.ContainerLimits = atc.ContainerLimits{
+								Memory: 1024,
+							}
+
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+
+							factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string {
+								return "a-random-guid"
+							}, atc.ContainerLimits{
+								Memory: 512,
+								CPU:    256,
+							}, atc.ContainerLimits{
+								Memory: 2048,
+							}, 0)
+						})
+
+						It("creates the container with the limits, defaulting any that are unset", func() {
+							Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(1))
+							_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+
+							taskSpec := spec.(worker.TaskContainerSpec)
+							Ω(taskSpec.Limits).Should(Equal(atc.ContainerLimits{
+								Memory: 1024,
+								CPU:    256,
+							}))
+						})
+
+						Context("when the limits exceed the maximum", func() {
+							BeforeEach(func() {
+								fetchedConfig.ContainerLimits = atc.ContainerLimits{
+									Memory: 4096,
+								}
+
+								configSource.FetchConfigReturns(fetchedConfig, nil)
+							})
+
+							It("exits with an error", func() {
+								var err error
+								Eventually(process.Wait()).Should(Receive(&err))
+								Ω(err).Should(Equal(ContainerLimitsExceededError{
+									Limits: []string{"memory (4096 > 2048)"},
+								}))
+							})
+
+							It("does not create a container", func() {
+								Eventually(process.Wait()).Should(Receive())
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(0))
+							})
+						})
+					})
+
+					Context("when the config specifies caches", func() {
+						BeforeEach(func() {
+							fetchedConfig.Caches = []atc.CacheConfig{
+								{Path: "some-cache"},
+								{Path: "some/other-cache"},
+							}
+
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+						})
+
+						Context("when the task is part of a job", func() {
+							BeforeEach(func() {
+								identifier.PipelineName = "some-pipeline"
+								identifier.JobName = "some-job"
+							})
+
+							It("creates the container with the job's caches for the step", func() {
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(1))
+								_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+
+								taskSpec := spec.(worker.TaskContainerSpec)
+								Ω(taskSpec.CacheID).Should(Equal(&worker.Identifier{
+									PipelineName: "some-pipeline",
+									JobName:      "some-job",
+									Name:         "some-session-id",
+									Type:         worker.ContainerTypeCache,
+								}))
+								Ω(taskSpec.CacheDir).Should(Equal("/tmp/build/a-random-guid"))
+								Ω(taskSpec.Caches).Should(Equal([]string{
+									"some-cache",
+									"some/other-cache",
+								}))
+							})
+
+							It("keys the caches the same way for later builds, whose working directories differ", func() {
+								Eventually(process.Wait()).Should(Receive())
+
+								laterFactory := NewGardenFactory(fakeWorkerClient, fakeTracker, func() string {
+									return "another-random-guid"
+								}, atc.ContainerLimits{}, atc.ContainerLimits{}, 0)
+
+								laterStep := laterFactory.Task(sourceName, identifier, taskDelegate, privileged, tags, configSource, inputMapping, outputMapping).Using(inStep, repo)
+								Eventually(ifrit.Invoke(laterStep).Wait()).Should(Receive())
+
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(2))
+								_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+								_, laterSpec := fakeWorkerClient.CreateContainerArgsForCall(1)
+
+								taskSpec := spec.(worker.TaskContainerSpec)
+								laterTaskSpec := laterSpec.(worker.TaskContainerSpec)
+								Ω(laterTaskSpec.CacheDir).Should(Equal("/tmp/build/another-random-guid"))
+								Ω(laterTaskSpec.CacheID).Should(Equal(taskSpec.CacheID))
+								Ω(laterTaskSpec.Caches).Should(Equal(taskSpec.Caches))
+							})
+						})
+
+						Context("when the task is part of a one-off build", func() {
+							It("creates the container without caches", func() {
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(1))
+								_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+
+								taskSpec := spec.(worker.TaskContainerSpec)
+								Ω(taskSpec.CacheID).Should(BeNil())
+								Ω(taskSpec.Caches).Should(BeEmpty())
+							})
+						})
 					})
 
 					It("ensures artifacts root exists by streaming in an empty payload", func() {
@@ -326,6 +642,47 @@ var _ = Describe("GardenFactory", func() {
 							})
 						})
 
+						Context("when an input is mapped to a differently named source", func() {
+							BeforeEach(func() {
+								inputMapping = map[string]string{"some-input": "some-mapped-input"}
+
+								repo.RegisterSource("some-mapped-input", inputSource)
+								repo.RegisterSource("some-other-input", otherInputSource)
+							})
+
+							It("streams the mapped source to the input's configured destination", func() {
+								Ω(inputSource.StreamToCallCount()).Should(Equal(1))
+
+								destination := inputSource.StreamToArgsForCall(0)
+
+								initial := fakeContainer.StreamInCallCount()
+
+								err := destination.StreamIn("foo", new(bytes.Buffer))
+								Ω(err).ShouldNot(HaveOccurred())
+
+								spec := fakeContainer.StreamInArgsForCall(initial)
+								Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-input-configured-path/foo"))
+
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+							})
+						})
+
+						Context("when a mapped source is missing", func() {
+							BeforeEach(func() {
+								inputMapping = map[string]string{"some-input": "some-mapped-input"}
+
+								repo.RegisterSource("some-input", inputSource)
+								repo.RegisterSource("some-other-input", otherInputSource)
+							})
+
+							It("exits with failure, naming the missing source", func() {
+								var err error
+								Eventually(process.Wait()).Should(Receive(&err))
+								Ω(err).Should(BeAssignableToTypeOf(MissingInputsError{}))
+								Ω(err.(MissingInputsError).Inputs).Should(ConsistOf("some-mapped-input"))
+							})
+						})
+
 						Context("when any of the inputs are missing", func() {
 							BeforeEach(func() {
 								repo.RegisterSource("some-input", inputSource)
@@ -351,6 +708,106 @@ var _ = Describe("GardenFactory", func() {
 						})
 					})
 
+					Context("when the configuration specifies outputs", func() {
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								Image: "some-image",
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+								Outputs: []atc.TaskOutputConfig{
+									{Name: "some-output", Path: "some-output-configured-path"},
+									{Name: "some-other-output"},
+								},
+							}, nil)
+
+							fakeProcess.WaitReturns(0, nil)
+						})
+
+						It("ensures each output directory exists by streaming in an empty payload", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Ω(fakeContainer.StreamInCallCount()).Should(Equal(3))
+
+							spec := fakeContainer.StreamInArgsForCall(1)
+							Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-output-configured-path"))
+
+							tarReader := tar.NewReader(spec.TarStream)
+
+							_, err := tarReader.Next()
+							Ω(err).Should(Equal(io.EOF))
+
+							spec = fakeContainer.StreamInArgsForCall(2)
+							Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-other-output"))
+						})
+
+						It("registers each output as its own source", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							streamedOut := gbytes.NewBuffer()
+							fakeContainer.StreamOutReturns(streamedOut, nil)
+
+							outputSource, found := repo.SourceFor("some-output")
+							Ω(found).Should(BeTrue())
+
+							fakeDestination := new(fakes.FakeArtifactDestination)
+
+							err := outputSource.StreamTo(fakeDestination)
+							Ω(err).ShouldNot(HaveOccurred())
+
+							Ω(fakeContainer.StreamOutCallCount()).Should(Equal(1))
+							spec := fakeContainer.StreamOutArgsForCall(0)
+							Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-output-configured-path/"))
+
+							Ω(fakeDestination.StreamInCallCount()).Should(Equal(1))
+							dest, src := fakeDestination.StreamInArgsForCall(0)
+							Ω(dest).Should(Equal("."))
+							Ω(src).Should(Equal(streamedOut))
+
+							otherOutputSource, found := repo.SourceFor("some-other-output")
+							Ω(found).Should(BeTrue())
+
+							err = otherOutputSource.StreamTo(fakeDestination)
+							Ω(err).ShouldNot(HaveOccurred())
+
+							Ω(fakeContainer.StreamOutCallCount()).Should(Equal(2))
+							spec = fakeContainer.StreamOutArgsForCall(1)
+							Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-other-output/"))
+						})
+
+						Context("when an output is mapped to another name", func() {
+							BeforeEach(func() {
+								outputMapping = map[string]string{"some-output": "some-mapped-output"}
+							})
+
+							It("registers the output under the mapped name", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								_, found := repo.SourceFor("some-mapped-output")
+								Ω(found).Should(BeTrue())
+
+								_, found = repo.SourceFor("some-output")
+								Ω(found).Should(BeFalse())
+
+								_, found = repo.SourceFor("some-other-output")
+								Ω(found).Should(BeTrue())
+							})
+						})
+
+						Context("when the process exits nonzero", func() {
+							BeforeEach(func() {
+								fakeProcess.WaitReturns(1, nil)
+							})
+
+							It("still registers the outputs", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								_, found := repo.SourceFor("some-output")
+								Ω(found).Should(BeTrue())
+							})
+						})
+					})
+
 					Context("when the process exits 0", func() {
 						BeforeEach(func() {
 							fakeProcess.WaitReturns(0, nil)
@@ -712,11 +1169,70 @@ var _ = Describe("GardenFactory", func() {
 							}
 						})
 
-						It("stops the container", func() {
+						It("terminates the process, and kills the container once the grace period has passed", func() {
 							process.Signal(os.Interrupt)
 							Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
 
+							Ω(fakeProcess.SignalCallCount()).Should(Equal(1))
+							Ω(fakeProcess.SignalArgsForCall(0)).Should(Equal(garden.SignalTerminate))
+
 							Ω(fakeContainer.StopCallCount()).Should(Equal(1))
+							Ω(fakeContainer.StopArgsForCall(0)).Should(BeTrue())
+						})
+
+						Context("when the grace period has not passed", func() {
+							BeforeEach(func() {
+								factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string {
+									return "a-random-guid"
+								}, atc.ContainerLimits{}, atc.ContainerLimits{}, time.Hour)
+							})
+
+							It("does not kill the container", func() {
+								process.Signal(os.Interrupt)
+
+								Eventually(fakeProcess.SignalCallCount).Should(Equal(1))
+								Consistently(fakeContainer.StopCallCount).Should(BeZero())
+							})
+
+							Context("when the process exits", func() {
+								BeforeEach(func() {
+									fakeProcess.SignalStub = func(garden.Signal) error {
+										close(stopped)
+										return nil
+									}
+								})
+
+								It("exits with ErrInterrupted without killing the container", func() {
+									process.Signal(os.Interrupt)
+									Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+									Ω(fakeContainer.StopCallCount()).Should(BeZero())
+								})
+							})
+
+							Context("when signalled again", func() {
+								It("kills the container", func() {
+									process.Signal(os.Interrupt)
+									Eventually(fakeProcess.SignalCallCount).Should(Equal(1))
+
+									process.Signal(os.Interrupt)
+									Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+									Ω(fakeContainer.StopCallCount()).Should(Equal(1))
+									Ω(fakeContainer.StopArgsForCall(0)).Should(BeTrue())
+								})
+							})
+						})
+
+						Context("when killed", func() {
+							It("kills the container immediately", func() {
+								process.Signal(os.Kill)
+								Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+								Ω(fakeProcess.SignalCallCount()).Should(BeZero())
+								Ω(fakeContainer.StopCallCount()).Should(Equal(1))
+								Ω(fakeContainer.StopArgsForCall(0)).Should(BeTrue())
+							})
 						})
 					})
 
@@ -14,13 +14,68 @@ type Job struct {
 }
 
 type JobInput struct {
-	Name     string   `json:"name"`
-	Resource string   `json:"resource"`
-	Passed   []string `json:"passed,omitempty"`
-	Trigger  bool     `json:"trigger"`
+	Name     string        `json:"name"`
+	Resource string        `json:"resource"`
+	Passed   []string      `json:"passed,omitempty"`
+	Trigger  bool          `json:"trigger"`
+	Version  VersionConfig `json:"version,omitempty"`
+	Tags     Tags          `json:"tags,omitempty"`
 }
 
 type JobOutput struct {
 	Name     string `json:"name"`
 	Resource string `json:"resource"`
 }
+
+// A JobInputsPreview is the set of versions that the next build of a job
+// would use for its inputs.
+type JobInputsPreview struct {
+	Inputs []BuildInput `json:"inputs"`
+
+	// a build of the job that has already used these versions, if any
+	ExistingBuild *Build `json:"existing_build"`
+
+	// why no versions satisfy the job's inputs, if none do
+	Unsatisfied *JobSchedulingStatus `json:"unsatisfied,omitempty"`
+}
+
+type BuildInput struct {
+	Name     string          `json:"name"`
+	Resource string          `json:"resource"`
+	Type     string          `json:"type"`
+	Version  Version         `json:"version"`
+	Metadata []MetadataField `json:"metadata"`
+}
+
+// A JobBuildRequest triggers a build of a job, optionally choosing the
+// versions of some of its inputs. Inputs without a chosen version use their
+// latest versions, as usual.
+type JobBuildRequest struct {
+	Inputs []JobBuildInput `json:"inputs,omitempty"`
+
+	// use the chosen versions even if they have not passed through the jobs
+	// that their inputs' passed constraints require
+	IgnorePassed bool `json:"ignore_passed,omitempty"`
+}
+
+// A JobBuildInput chooses a version for one of a job's inputs, either by the
+// ID of the version or by the version itself.
+type JobBuildInput struct {
+	Name      string  `json:"name"`
+	VersionID int     `json:"version_id,omitempty"`
+	Version   Version `json:"version,omitempty"`
+}
+
+// A JobSchedulingStatus explains why the job did or did not start a build the
+// last time it was scheduled.
+type JobSchedulingStatus struct {
+	Reason      string `json:"reason"`
+	Description string `json:"description"`
+
+	Input     string `json:"input,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+	PassedJob string `json:"passed_job,omitempty"`
+	BuildName string `json:"build_name,omitempty"`
+
+	Time int64 `json:"time"`
+}
@@ -110,6 +110,27 @@ var _ = Describe("JobConfig", func() {
 				})
 			})
 
+			Context("with a tagged get", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = PlanSequence{
+						{
+							Get:  "some-get-plan",
+							Tags: Tags{"some-tag"},
+						},
+					}
+				})
+
+				It("includes the tags in the input", func() {
+					Ω(inputs).Should(Equal([]JobInput{
+						{
+							Name:     "some-get-plan",
+							Resource: "some-get-plan",
+							Tags:     Tags{"some-tag"},
+						},
+					}))
+				})
+			})
+
 			Context("when a plan has an ensure hook on a get", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = PlanSequence{
@@ -3,12 +3,14 @@ package pipelines
 import (
 	"time"
 
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/worker"
 )
 
 //go:generate counterfeiter . Locker
@@ -28,11 +30,13 @@ type RadarSchedulerFactory interface {
 }
 
 type radarSchedulerFactory struct {
-	tracker  resource.Tracker
-	interval time.Duration
-	locker   Locker
-	engine   engine.Engine
-	db       db.DB
+	tracker     resource.Tracker
+	interval    time.Duration
+	locker      Locker
+	engine      engine.Engine
+	db          db.DB
+	credentials credentials.CredentialManager
+	workers     worker.WorkerProvider
 }
 
 func NewRadarSchedulerFactory(
@@ -41,18 +45,22 @@ func NewRadarSchedulerFactory(
 	locker Locker,
 	engine engine.Engine,
 	db db.DB,
+	credentials credentials.CredentialManager,
+	workers worker.WorkerProvider,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		tracker:  tracker,
-		interval: interval,
-		locker:   locker,
-		engine:   engine,
-		db:       db,
+		tracker:     tracker,
+		interval:    interval,
+		locker:      locker,
+		engine:      engine,
+		db:          db,
+		credentials: credentials,
+		workers:     workers,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildRadar(pipelineDB db.PipelineDB) *radar.Radar {
-	return radar.NewRadar(rsf.tracker, rsf.interval, rsf.locker, pipelineDB)
+	return radar.NewRadar(rsf.tracker, rsf.interval, rsf.locker, pipelineDB, rsf.credentials)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB) *scheduler.Scheduler {
@@ -60,8 +68,11 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB) *sche
 	return &scheduler.Scheduler{
 		PipelineDB: pipelineDB,
 		BuildsDB:   rsf.db,
-		Factory:    &factory.BuildFactory{PipelineName: pipelineDB.GetPipelineName()},
-		Engine:     rsf.engine,
-		Scanner:    radar,
+		Factory: &factory.BuildFactory{
+			PipelineName: pipelineDB.GetPipelineName(),
+		},
+		Engine:  rsf.engine,
+		Scanner: radar,
+		Workers: rsf.workers,
 	}
 }
@@ -5,15 +5,23 @@ type Plan struct {
 	Aggregate    *AggregatePlan    `json:"aggregate,omitempty"`
 	Get          *GetPlan          `json:"get,omitempty"`
 	Put          *PutPlan          `json:"put,omitempty"`
+	Delete       *DeletePlan       `json:"delete,omitempty"`
 	Task         *TaskPlan         `json:"task,omitempty"`
 	Conditional  *ConditionalPlan  `json:"conditional,omitempty"`
 	Ensure       *EnsurePlan       `json:"ensure,omitempty"`
 	OnSuccess    *OnSuccessPlan    `json:"on_success,omitempty"`
 	OnFailure    *OnFailurePlan    `json:"on_failure,omitempty"`
+	OnAbort      *OnAbortPlan      `json:"on_abort,omitempty"`
 	Try          *TryPlan          `json:"try,omitempty"`
 	Location     *Location         `json:"location,omitempty"`
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
+	Retry        *RetryPlan        `json:"retry,omitempty"`
+	Error        *ErrorPlan        `json:"error,omitempty"`
+
+	// constrain how an Aggregate plan runs its steps
+	AggregateLimit    int  `json:"aggregate_limit,omitempty"`
+	AggregateFailFast bool `json:"aggregate_fail_fast,omitempty"`
 }
 
 type DependentGetPlan struct {
@@ -25,6 +33,8 @@ type DependentGetPlan struct {
 	Tags     Tags   `json:"tags,omitempty"`
 	Source   Source `json:"source"`
 	Timeout  string `json:"timeout,omitempty"`
+
+	SensitiveParams []string `json:"sensitive_params,omitempty"`
 }
 
 type Location struct {
@@ -32,6 +42,7 @@ type Location struct {
 	ParallelGroup uint `json:"parallel_group,omitempty"`
 	ID            uint `json:"id,omitempty"`
 	Hook          string
+	Attempt       uint `json:"attempt,omitempty"`
 }
 
 type ComposePlan struct {
@@ -51,6 +62,16 @@ type OnSuccessPlan struct {
 	Step Plan `json: "step"`
 	Next Plan `json: "on_success"`
 }
+type OnAbortPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"on_abort"`
+}
+
+// An ErrorPlan errors the build with the given message. It is used for builds
+// that errored before their plan could run, once the job's hooks have run.
+type ErrorPlan struct {
+	Message string `json:"message"`
+}
 
 type TimeoutPlan struct {
 	Step     Plan   `json: "step"`
@@ -61,6 +82,10 @@ type TryPlan struct {
 	Step Plan `json: "step"`
 }
 
+// A RetryPlan holds a copy of the same plan for each attempt, each with its
+// attempt number set on its locations.
+type RetryPlan []Plan
+
 type AggregatePlan []Plan
 
 type GetPlan struct {
@@ -73,6 +98,8 @@ type GetPlan struct {
 	Version  Version `json:"version,omitempty"`
 	Tags     Tags    `json:"tags,omitempty"`
 	Timeout  string  `json:"timeout,omitempty"`
+
+	SensitiveParams []string `json:"sensitive_params,omitempty"`
 }
 
 type PutPlan struct {
@@ -84,18 +111,34 @@ type PutPlan struct {
 	Params   Params `json:"params,omitempty"`
 	Tags     Tags   `json:"tags,omitempty"`
 	Timeout  string `json:"timeout,omitempty"`
+
+	SensitiveParams []string `json:"sensitive_params,omitempty"`
+}
+
+type DeletePlan struct {
+	Type     string `json:"type"`
+	Name     string `json:"name,omitempty"`
+	Resource string `json:"resource"`
+	Pipeline string `json:"pipeline"`
+	Source   Source `json:"source"`
+	Params   Params `json:"params,omitempty"`
+	Tags     Tags   `json:"tags,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+
+	SensitiveParams []string `json:"sensitive_params,omitempty"`
 }
 
 func (plan DependentGetPlan) GetPlan() GetPlan {
 	return GetPlan{
-		Type:     plan.Type,
-		Name:     plan.Name,
-		Resource: plan.Resource,
-		Pipeline: plan.Pipeline,
-		Source:   plan.Source,
-		Tags:     plan.Tags,
-		Timeout:  plan.Timeout,
-		Params:   plan.Params,
+		Type:            plan.Type,
+		Name:            plan.Name,
+		Resource:        plan.Resource,
+		Pipeline:        plan.Pipeline,
+		Source:          plan.Source,
+		Tags:            plan.Tags,
+		Timeout:         plan.Timeout,
+		Params:          plan.Params,
+		SensitiveParams: plan.SensitiveParams,
 	}
 }
 
@@ -108,6 +151,11 @@ type TaskPlan struct {
 
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
+
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
+
+	SensitiveParams []string `json:"sensitive_params,omitempty"`
 }
 
 type ConditionalPlan struct {
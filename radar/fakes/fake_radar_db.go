@@ -73,14 +73,15 @@ type FakeRadarDB struct {
 	unpauseResourceReturns struct {
 		result1 error
 	}
-	SaveResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) error
-	saveResourceVersionsMutex       sync.RWMutex
-	saveResourceVersionsArgsForCall []struct {
+	SaveNewResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) ([]atc.Version, error)
+	saveNewResourceVersionsMutex       sync.RWMutex
+	saveNewResourceVersionsArgsForCall []struct {
 		arg1 atc.ResourceConfig
 		arg2 []atc.Version
 	}
-	saveResourceVersionsReturns struct {
-		result1 error
+	saveNewResourceVersionsReturns struct {
+		result1 []atc.Version
+		result2 error
 	}
 	SetResourceCheckErrorStub        func(resource db.SavedResource, err error) error
 	setResourceCheckErrorMutex       sync.RWMutex
@@ -330,37 +331,38 @@ func (fake *FakeRadarDB) UnpauseResourceReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRadarDB) SaveResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) error {
-	fake.saveResourceVersionsMutex.Lock()
-	fake.saveResourceVersionsArgsForCall = append(fake.saveResourceVersionsArgsForCall, struct {
+func (fake *FakeRadarDB) SaveNewResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) ([]atc.Version, error) {
+	fake.saveNewResourceVersionsMutex.Lock()
+	fake.saveNewResourceVersionsArgsForCall = append(fake.saveNewResourceVersionsArgsForCall, struct {
 		arg1 atc.ResourceConfig
 		arg2 []atc.Version
 	}{arg1, arg2})
-	fake.saveResourceVersionsMutex.Unlock()
-	if fake.SaveResourceVersionsStub != nil {
-		return fake.SaveResourceVersionsStub(arg1, arg2)
+	fake.saveNewResourceVersionsMutex.Unlock()
+	if fake.SaveNewResourceVersionsStub != nil {
+		return fake.SaveNewResourceVersionsStub(arg1, arg2)
 	} else {
-		return fake.saveResourceVersionsReturns.result1
+		return fake.saveNewResourceVersionsReturns.result1, fake.saveNewResourceVersionsReturns.result2
 	}
 }
 
-func (fake *FakeRadarDB) SaveResourceVersionsCallCount() int {
-	fake.saveResourceVersionsMutex.RLock()
-	defer fake.saveResourceVersionsMutex.RUnlock()
-	return len(fake.saveResourceVersionsArgsForCall)
+func (fake *FakeRadarDB) SaveNewResourceVersionsCallCount() int {
+	fake.saveNewResourceVersionsMutex.RLock()
+	defer fake.saveNewResourceVersionsMutex.RUnlock()
+	return len(fake.saveNewResourceVersionsArgsForCall)
 }
 
-func (fake *FakeRadarDB) SaveResourceVersionsArgsForCall(i int) (atc.ResourceConfig, []atc.Version) {
-	fake.saveResourceVersionsMutex.RLock()
-	defer fake.saveResourceVersionsMutex.RUnlock()
-	return fake.saveResourceVersionsArgsForCall[i].arg1, fake.saveResourceVersionsArgsForCall[i].arg2
+func (fake *FakeRadarDB) SaveNewResourceVersionsArgsForCall(i int) (atc.ResourceConfig, []atc.Version) {
+	fake.saveNewResourceVersionsMutex.RLock()
+	defer fake.saveNewResourceVersionsMutex.RUnlock()
+	return fake.saveNewResourceVersionsArgsForCall[i].arg1, fake.saveNewResourceVersionsArgsForCall[i].arg2
 }
 
-func (fake *FakeRadarDB) SaveResourceVersionsReturns(result1 error) {
-	fake.SaveResourceVersionsStub = nil
-	fake.saveResourceVersionsReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeRadarDB) SaveNewResourceVersionsReturns(result1 []atc.Version, result2 error) {
+	fake.SaveNewResourceVersionsStub = nil
+	fake.saveNewResourceVersionsReturns = struct {
+		result1 []atc.Version
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeRadarDB) SetResourceCheckError(resource db.SavedResource, err error) error {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -22,6 +23,14 @@ func (err resourceNotConfiguredError) Error() string {
 	return fmt.Sprintf("resource '%s' was not found in config", err.ResourceName)
 }
 
+type CheckTimedOutError struct {
+	Timeout time.Duration
+}
+
+func (err CheckTimedOutError) Error() string {
+	return fmt.Sprintf("check timed out after %s", err.Timeout)
+}
+
 //go:generate counterfeiter . RadarDB
 
 type RadarDB interface {
@@ -37,7 +46,7 @@ type RadarDB interface {
 	PauseResource(resourceName string) error
 	UnpauseResource(resourceName string) error
 
-	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
+	SaveNewResourceVersions(atc.ResourceConfig, []atc.Version) ([]atc.Version, error)
 	SetResourceCheckError(resource db.SavedResource, err error) error
 }
 
@@ -50,6 +59,8 @@ type Radar struct {
 
 	locker Locker
 	db     RadarDB
+
+	credentials credentials.CredentialManager
 }
 
 func NewRadar(
@@ -57,18 +68,23 @@ func NewRadar(
 	interval time.Duration,
 	locker Locker,
 	db RadarDB,
+	credentials credentials.CredentialManager,
 ) *Radar {
 	return &Radar{
-		tracker:  tracker,
-		interval: interval,
-		locker:   locker,
-		db:       db,
+		tracker:     tracker,
+		interval:    interval,
+		locker:      locker,
+		db:          db,
+		credentials: credentials,
 	}
 }
 
 func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
-		ticker := time.NewTicker(radar.interval)
+		interval := radar.resourceInterval(logger, resourceName)
+
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
 
 		close(ready)
 
@@ -77,70 +93,106 @@ func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runn
 			case <-signals:
 				return nil
 
-			case <-ticker.C:
+			case <-timer.C:
 				lock := radar.checkLock(radar.db.ScopedName(resourceName))
 				resourceCheckingLock, err := radar.locker.AcquireWriteLockImmediately(lock)
 
 				if err != nil {
+					// another ATC is checking it; look again once it should be done
+					timer.Reset(radar.resourceInterval(logger, resourceName))
 					continue
 				}
 
-				err = radar.scan(logger.Session("tick"), resourceName)
+				_, interval, err = radar.scan(logger.Session("tick"), resourceName, nil)
 
 				resourceCheckingLock.Release()
 
 				if err != nil {
 					return err
 				}
+
+				timer.Reset(interval)
 			}
 		}
 	})
 }
 
 func (radar *Radar) Scan(logger lager.Logger, resourceName string) error {
+	_, err := radar.ScanFromVersion(logger, resourceName, nil)
+	return err
+}
+
+// ScanFromVersion checks the resource immediately, waiting for any check
+// already in progress, and returns the versions it found that were not
+// already known.
+//
+// If no version is given, the check starts from the latest known version.
+func (radar *Radar) ScanFromVersion(logger lager.Logger, resourceName string, from atc.Version) ([]atc.Version, error) {
 	lock, err := radar.locker.AcquireWriteLock(radar.checkLock(radar.db.ScopedName(resourceName)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer lock.Release()
 
-	return radar.scan(logger, resourceName)
+	newVersions, _, err := radar.scan(logger, resourceName, from)
+	return newVersions, err
 }
 
-func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
+// scan checks the resource for new versions, returning them along with how
+// long to wait before checking again.
+func (radar *Radar) scan(logger lager.Logger, resourceName string, from atc.Version) ([]atc.Version, time.Duration, error) {
 	pipelinePaused, err := radar.db.IsPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
-		return err
+		return nil, radar.interval, err
 	}
 
 	if pipelinePaused {
 		logger.Debug("pipeline-paused")
-		return nil
+		return nil, radar.resourceInterval(logger, resourceName), nil
 	}
 
 	config, _, err := radar.db.GetConfig()
 	if err != nil {
 		logger.Error("failed-to-get-config", err)
 		// don't propagate error; we can just retry next tick
-		return nil
+		return nil, radar.interval, nil
 	}
 
 	resourceConfig, found := config.Resources.Lookup(resourceName)
 	if !found {
 		logger.Info("resource-removed-from-configuration")
 		// return an error so that we exit
-		return resourceNotConfiguredError{ResourceName: resourceName}
+		return nil, radar.interval, resourceNotConfiguredError{ResourceName: resourceName}
 	}
 
+	interval := radar.checkInterval(logger, resourceConfig)
+
 	savedResource, err := radar.db.GetResource(resourceName)
 	if err != nil {
-		return err
+		return nil, interval, err
 	}
 
 	if savedResource.Paused {
-		return nil
+		return nil, interval, nil
+	}
+
+	evaluator := credentials.Evaluator{
+		Manager:      radar.credentials,
+		PipelineName: radar.db.GetPipelineName(),
+	}
+
+	source, err := evaluator.Source(resourceConfig.Source)
+	if err != nil {
+		logger.Error("failed-to-evaluate-credentials", err)
+
+		setErr := radar.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", setErr)
+		}
+
+		return nil, interval, err
 	}
 
 	typ := resource.ResourceType(resourceConfig.Type)
@@ -148,21 +200,22 @@ func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
 	res, err := radar.tracker.Init(checkIdentifier(radar.db.GetPipelineName(), resourceConfig), typ, []string{})
 	if err != nil {
 		logger.Error("failed-to-initialize-new-resource", err)
-		return err
+		return nil, interval, err
 	}
 
 	defer res.Release()
 
-	var from db.Version
-	if vr, err := radar.db.GetLatestVersionedResource(savedResource); err == nil {
-		from = vr.Version
+	if from == nil {
+		if vr, err := radar.db.GetLatestVersionedResource(savedResource); err == nil {
+			from = atc.Version(vr.Version)
+		}
 	}
 
 	logger.Debug("checking", lager.Data{
 		"from": from,
 	})
 
-	newVersions, err := res.Check(resourceConfig.Source, atc.Version(from))
+	checkedVersions, err := radar.check(logger, res, resourceConfig, source, from)
 	setErr := radar.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
 		logger.Error("failed-to-set-check-error", err)
@@ -171,12 +224,28 @@ func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
 	if err != nil {
 		logger.Error("failed-to-check", err)
 
-		return err
+		return nil, interval, err
+	}
+
+	if len(checkedVersions) == 0 {
+		logger.Debug("no-new-versions")
+		return nil, interval, nil
+	}
+
+	// checking from an older version finds versions that are already known,
+	// which are left as they are
+	newVersions, err := radar.db.SaveNewResourceVersions(resourceConfig, checkedVersions)
+	if err != nil {
+		logger.Error("failed-to-save-versions", err, lager.Data{
+			"versions": checkedVersions,
+		})
+
+		return nil, interval, nil
 	}
 
 	if len(newVersions) == 0 {
 		logger.Debug("no-new-versions")
-		return nil
+		return nil, interval, nil
 	}
 
 	logger.Info("versions-found", lager.Data{
@@ -184,14 +253,89 @@ func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
 		"total":    len(newVersions),
 	})
 
-	err = radar.db.SaveResourceVersions(resourceConfig, newVersions)
+	return newVersions, interval, nil
+}
+
+// check runs the resource's check, giving up if it takes longer than the
+// resource's configured check_timeout.
+func (radar *Radar) check(
+	logger lager.Logger,
+	res resource.Resource,
+	resourceConfig atc.ResourceConfig,
+	source atc.Source,
+	from atc.Version,
+) ([]atc.Version, error) {
+	if resourceConfig.CheckTimeout == "" {
+		return res.Check(source, from)
+	}
+
+	timeout, err := time.ParseDuration(resourceConfig.CheckTimeout)
 	if err != nil {
-		logger.Error("failed-to-save-versions", err, lager.Data{
-			"versions": newVersions,
-		})
+		logger.Error("failed-to-parse-check-timeout", err)
+		return res.Check(source, from)
+	}
+
+	type checkResult struct {
+		versions []atc.Version
+		err      error
+	}
+
+	results := make(chan checkResult, 1)
+
+	go func() {
+		versions, err := res.Check(source, from)
+		results <- checkResult{versions, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return result.versions, result.err
+
+	case <-timer.C:
+		logger.Info("check-timed-out", lager.Data{"timeout": timeout.String()})
+
+		// destroy the container so that the hung check does not linger
+		err := res.Destroy()
+		if err != nil {
+			logger.Error("failed-to-destroy-timed-out-check", err)
+		}
+
+		return nil, CheckTimedOutError{Timeout: timeout}
+	}
+}
+
+func (radar *Radar) checkInterval(logger lager.Logger, resourceConfig atc.ResourceConfig) time.Duration {
+	if resourceConfig.CheckEvery == "" {
+		return radar.interval
+	}
+
+	interval, err := time.ParseDuration(resourceConfig.CheckEvery)
+	if err != nil || interval <= 0 {
+		logger.Info("invalid-check-interval", lager.Data{"check-every": resourceConfig.CheckEvery})
+		return radar.interval
+	}
+
+	return interval
+}
+
+// resourceInterval is the resource's check interval, for when it is not being
+// checked by this ATC. Failing to look it up falls back to the default, as
+// the next check reports the failure.
+func (radar *Radar) resourceInterval(logger lager.Logger, resourceName string) time.Duration {
+	config, _, err := radar.db.GetConfig()
+	if err != nil {
+		return radar.interval
+	}
+
+	resourceConfig, found := config.Resources.Lookup(resourceName)
+	if !found {
+		return radar.interval
 	}
 
-	return nil
+	return radar.checkInterval(logger, resourceConfig)
 }
 
 func (radar *Radar) checkLock(resourceName string) []db.NamedLock {
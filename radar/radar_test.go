@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/credentials"
+	cfakes "github.com/concourse/atc/credentials/fakes"
 	"github.com/concourse/atc/db"
 	dbfakes "github.com/concourse/atc/db/fakes"
 	"github.com/concourse/atc/worker"
@@ -26,6 +28,8 @@ var _ = Describe("Radar", func() {
 		fakeRadarDB *fakes.FakeRadarDB
 		interval    time.Duration
 
+		fakeCredentialManager *cfakes.FakeCredentialManager
+
 		radar *Radar
 
 		resourceConfig atc.ResourceConfig
@@ -44,9 +48,10 @@ var _ = Describe("Radar", func() {
 		fakeRadarDB = new(fakes.FakeRadarDB)
 		locker = new(fakes.FakeLocker)
 		interval = 100 * time.Millisecond
+		fakeCredentialManager = new(cfakes.FakeCredentialManager)
 
 		fakeRadarDB.GetPipelineNameReturns("some-pipeline-name")
-		radar = NewRadar(fakeTracker, interval, locker, fakeRadarDB)
+		radar = NewRadar(fakeTracker, interval, locker, fakeRadarDB, fakeCredentialManager)
 
 		resourceConfig = atc.ResourceConfig{
 			Name:   "some-resource",
@@ -73,6 +78,10 @@ var _ = Describe("Radar", func() {
 
 		fakeRadarDB.GetResourceReturns(savedResource, nil)
 
+		fakeRadarDB.SaveNewResourceVersionsStub = func(config atc.ResourceConfig, versions []atc.Version) ([]atc.Version, error) {
+			return versions, nil
+		}
+
 		readLock = new(dbfakes.FakeLock)
 		locker.AcquireReadLockReturns(readLock, nil)
 
@@ -231,9 +240,9 @@ var _ = Describe("Radar", func() {
 			})
 
 			It("saves them all, in order", func() {
-				Eventually(fakeRadarDB.SaveResourceVersionsCallCount).Should(Equal(1))
+				Eventually(fakeRadarDB.SaveNewResourceVersionsCallCount).Should(Equal(1))
 
-				resourceConfig, versions := fakeRadarDB.SaveResourceVersionsArgsForCall(0)
+				resourceConfig, versions := fakeRadarDB.SaveNewResourceVersionsArgsForCall(0)
 				Ω(resourceConfig).Should(Equal(atc.ResourceConfig{
 					Name:   "some-resource",
 					Type:   "git",
@@ -373,6 +382,73 @@ var _ = Describe("Radar", func() {
 			})
 		})
 
+		Context("when the resource has its own check interval", func() {
+			BeforeEach(func() {
+				resourceConfig.CheckEvery = "50ms"
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+			})
+
+			It("checks on the resource's interval", func() {
+				var time1 time.Time
+				var time2 time.Time
+
+				Eventually(times).Should(Receive(&time1))
+				Eventually(times).Should(Receive(&time2))
+
+				Ω(time2.Sub(time1)).Should(BeNumerically("~", 50*time.Millisecond, 25*time.Millisecond))
+			})
+
+			Context("when it starts", func() {
+				var started time.Time
+
+				BeforeEach(func() {
+					started = time.Now()
+				})
+
+				It("checks first after the resource's interval", func() {
+					var time1 time.Time
+					Eventually(times).Should(Receive(&time1))
+
+					Ω(time1.Sub(started)).Should(BeNumerically("~", 50*time.Millisecond, 25*time.Millisecond))
+				})
+			})
+
+			Context("when the resource checking lock is held elsewhere", func() {
+				var attempts chan time.Time
+
+				BeforeEach(func() {
+					attempts = make(chan time.Time, 100)
+
+					held := true
+					locker.AcquireWriteLockImmediatelyStub = func([]db.NamedLock) (db.Lock, error) {
+						attempts <- time.Now()
+
+						if held {
+							held = false
+							return nil, errors.New("no lock for you")
+						}
+
+						return writeImmediatelyLock, nil
+					}
+				})
+
+				It("tries again after the resource's interval", func() {
+					var time1 time.Time
+					var time2 time.Time
+
+					Eventually(attempts).Should(Receive(&time1))
+					Eventually(attempts).Should(Receive(&time2))
+
+					Ω(time2.Sub(time1)).Should(BeNumerically("~", 50*time.Millisecond, 25*time.Millisecond))
+				})
+			})
+		})
+
 		Context("and checking takes a while", func() {
 			BeforeEach(func() {
 				checked := false
@@ -519,9 +595,9 @@ var _ = Describe("Radar", func() {
 			})
 
 			It("saves them all, in order", func() {
-				Ω(fakeRadarDB.SaveResourceVersionsCallCount()).Should(Equal(1))
+				Ω(fakeRadarDB.SaveNewResourceVersionsCallCount()).Should(Equal(1))
 
-				resourceConfig, versions := fakeRadarDB.SaveResourceVersionsArgsForCall(0)
+				resourceConfig, versions := fakeRadarDB.SaveNewResourceVersionsArgsForCall(0)
 				Ω(resourceConfig).Should(Equal(atc.ResourceConfig{
 					Name:   "some-resource",
 					Type:   "git",
@@ -554,5 +630,241 @@ var _ = Describe("Radar", func() {
 				Ω(err).Should(Equal(disaster))
 			})
 		})
+
+		Context("when the resource has a check timeout", func() {
+			var checking chan struct{}
+
+			BeforeEach(func() {
+				resourceConfig.CheckTimeout = "50ms"
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+
+				checking = make(chan struct{})
+			})
+
+			AfterEach(func() {
+				close(checking)
+			})
+
+			Context("and the check takes longer", func() {
+				BeforeEach(func() {
+					fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+						<-checking
+						return []atc.Version{{"version": "1"}}, nil
+					}
+				})
+
+				It("returns an error", func() {
+					Ω(scanErr).Should(Equal(CheckTimedOutError{Timeout: 50 * time.Millisecond}))
+				})
+
+				It("sets the resource's check error", func() {
+					Ω(fakeRadarDB.SetResourceCheckErrorCallCount()).Should(Equal(1))
+
+					savedResourceArg, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+					Ω(savedResourceArg).Should(Equal(savedResource))
+					Ω(err).Should(Equal(CheckTimedOutError{Timeout: 50 * time.Millisecond}))
+				})
+
+				It("destroys the resource", func() {
+					Ω(fakeResource.DestroyCallCount()).Should(Equal(1))
+				})
+
+				It("does not save any versions", func() {
+					Ω(fakeRadarDB.SaveNewResourceVersionsCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("and the check finishes in time", func() {
+				BeforeEach(func() {
+					fakeResource.CheckReturns([]atc.Version{{"version": "1"}}, nil)
+				})
+
+				It("saves the versions", func() {
+					Ω(fakeRadarDB.SaveNewResourceVersionsCallCount()).Should(Equal(1))
+				})
+
+				It("does not destroy the resource", func() {
+					Ω(fakeResource.DestroyCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("when the source references credentials", func() {
+			BeforeEach(func() {
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   "git",
+							Source: atc.Source{"uri": "http://example.com", "private_key": "((deploy-key))"},
+						},
+					},
+				}, 1, nil)
+			})
+
+			Context("when the credentials can be resolved", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns("some-private-key", true, nil)
+				})
+
+				It("looks them up for the pipeline", func() {
+					Ω(fakeCredentialManager.GetCallCount()).Should(Equal(1))
+
+					pipelineName, name := fakeCredentialManager.GetArgsForCall(0)
+					Ω(pipelineName).Should(Equal("some-pipeline-name"))
+					Ω(name).Should(Equal("deploy-key"))
+				})
+
+				It("checks with the resolved source", func() {
+					source, _ := fakeResource.CheckArgsForCall(0)
+					Ω(source).Should(Equal(atc.Source{
+						"uri":         "http://example.com",
+						"private_key": "some-private-key",
+					}))
+				})
+			})
+
+			Context("when the credentials are not defined", func() {
+				BeforeEach(func() {
+					fakeCredentialManager.GetReturns(nil, false, nil)
+				})
+
+				It("does not check", func() {
+					Ω(fakeResource.CheckCallCount()).Should(BeZero())
+				})
+
+				It("returns the error", func() {
+					Ω(scanErr).Should(HaveOccurred())
+				})
+
+				It("sets the resource's check error", func() {
+					Ω(fakeRadarDB.SetResourceCheckErrorCallCount()).Should(Equal(1))
+
+					_, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+					Ω(err).Should(Equal(credentials.UndefinedCredentialError{
+						PipelineName: "some-pipeline-name",
+						Name:         "deploy-key",
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("ScanFromVersion", func() {
+		var (
+			fakeResource *rfakes.FakeResource
+
+			fromVersion atc.Version
+
+			newVersions []atc.Version
+			scanErr     error
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeTracker.InitReturns(fakeResource, nil)
+
+			fakeRadarDB.GetLatestVersionedResourceReturns(
+				db.SavedVersionedResource{
+					ID: 1,
+					VersionedResource: db.VersionedResource{
+						Version: db.Version{
+							"version": "2",
+						},
+					},
+				}, nil)
+
+			fakeResource.CheckReturns([]atc.Version{
+				{"version": "1"},
+				{"version": "2"},
+			}, nil)
+
+			fromVersion = nil
+		})
+
+		JustBeforeEach(func() {
+			newVersions, scanErr = radar.ScanFromVersion(lagertest.NewTestLogger("test"), "some-resource", fromVersion)
+		})
+
+		It("grabs a resource checking lock before checking, releases after done", func() {
+			Ω(locker.AcquireWriteLockCallCount()).Should(Equal(1))
+
+			lockedInputs := locker.AcquireWriteLockArgsForCall(0)
+			Ω(lockedInputs).Should(Equal([]db.NamedLock{db.ResourceCheckingLock("pipeline:some-resource")}))
+
+			Ω(writeLock.ReleaseCallCount()).Should(Equal(1))
+		})
+
+		It("returns the versions that were found", func() {
+			Ω(scanErr).ShouldNot(HaveOccurred())
+			Ω(newVersions).Should(Equal([]atc.Version{
+				{"version": "1"},
+				{"version": "2"},
+			}))
+		})
+
+		It("saves the versions", func() {
+			Ω(fakeRadarDB.SaveNewResourceVersionsCallCount()).Should(Equal(1))
+		})
+
+		Context("when no version is given", func() {
+			It("checks from the current version", func() {
+				_, version := fakeResource.CheckArgsForCall(0)
+				Ω(version).Should(Equal(atc.Version{"version": "2"}))
+			})
+		})
+
+		Context("when a version is given", func() {
+			BeforeEach(func() {
+				fromVersion = atc.Version{"version": "1"}
+			})
+
+			It("checks from it", func() {
+				_, version := fakeResource.CheckArgsForCall(0)
+				Ω(version).Should(Equal(atc.Version{"version": "1"}))
+			})
+		})
+
+		Context("when some of the versions were already known", func() {
+			BeforeEach(func() {
+				fakeRadarDB.SaveNewResourceVersionsReturns([]atc.Version{{"version": "2"}}, nil)
+			})
+
+			It("returns only the ones that are new", func() {
+				Ω(scanErr).ShouldNot(HaveOccurred())
+				Ω(newVersions).Should(Equal([]atc.Version{
+					{"version": "2"},
+				}))
+			})
+		})
+
+		Context("when saving the versions fails", func() {
+			BeforeEach(func() {
+				fakeRadarDB.SaveNewResourceVersionsReturns(nil, errors.New("nope"))
+			})
+
+			It("returns no versions", func() {
+				Ω(scanErr).ShouldNot(HaveOccurred())
+				Ω(newVersions).Should(BeEmpty())
+			})
+		})
+
+		Context("when checking fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeResource.CheckReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(scanErr).Should(Equal(disaster))
+				Ω(newVersions).Should(BeEmpty())
+			})
+		})
 	})
 })
@@ -8,6 +8,20 @@ type Resource struct {
 
 	Paused bool `json:"paused,omitempty"`
 
+	PinnedVersion  Version `json:"pinned_version,omitempty"`
+	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
+
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
 }
+
+type CheckRequest struct {
+	From Version `json:"from,omitempty"`
+}
+
+type CheckResponse struct {
+	NewVersions int       `json:"new_versions"`
+	Versions    []Version `json:"versions,omitempty"`
+
+	CheckError string `json:"check_error,omitempty"`
+}
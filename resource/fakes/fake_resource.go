@@ -37,6 +37,17 @@ type FakeResource struct {
 	putReturns struct {
 		result1 resource.VersionedSource
 	}
+	DeleteStub        func(resource.IOConfig, atc.Source, atc.Params, resource.ArtifactSource) resource.VersionedSource
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 resource.IOConfig
+		arg2 atc.Source
+		arg3 atc.Params
+		arg4 resource.ArtifactSource
+	}
+	deleteReturns struct {
+		result1 resource.VersionedSource
+	}
 	CheckStub        func(atc.Source, atc.Version) ([]atc.Version, error)
 	checkMutex       sync.RWMutex
 	checkArgsForCall []struct {
@@ -152,6 +163,41 @@ func (fake *FakeResource) PutReturns(result1 resource.VersionedSource) {
 	}{result1}
 }
 
+func (fake *FakeResource) Delete(arg1 resource.IOConfig, arg2 atc.Source, arg3 atc.Params, arg4 resource.ArtifactSource) resource.VersionedSource {
+	fake.deleteMutex.Lock()
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 resource.IOConfig
+		arg2 atc.Source
+		arg3 atc.Params
+		arg4 resource.ArtifactSource
+	}{arg1, arg2, arg3, arg4})
+	fake.deleteMutex.Unlock()
+	if fake.DeleteStub != nil {
+		return fake.DeleteStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.deleteReturns.result1
+	}
+}
+
+func (fake *FakeResource) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeResource) DeleteArgsForCall(i int) (resource.IOConfig, atc.Source, atc.Params, resource.ArtifactSource) {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2, fake.deleteArgsForCall[i].arg3, fake.deleteArgsForCall[i].arg4
+}
+
+func (fake *FakeResource) DeleteReturns(result1 resource.VersionedSource) {
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 resource.VersionedSource
+	}{result1}
+}
+
 func (fake *FakeResource) Check(arg1 atc.Source, arg2 atc.Version) ([]atc.Version, error) {
 	fake.checkMutex.Lock()
 	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
@@ -18,6 +18,9 @@ type Resource interface {
 	Get(IOConfig, atc.Source, atc.Params, atc.Version) VersionedSource
 	Put(IOConfig, atc.Source, atc.Params, ArtifactSource) VersionedSource
 
+	// Delete runs /opt/resource/delete, which reports the version it deleted.
+	Delete(IOConfig, atc.Source, atc.Params, ArtifactSource) VersionedSource
+
 	Check(atc.Source, atc.Version) ([]atc.Version, error)
 
 	Release()
@@ -0,0 +1,40 @@
+package resource
+
+import (
+	"os"
+
+	"github.com/concourse/atc"
+	"github.com/tedsuo/ifrit"
+)
+
+type deleteRequest struct {
+	Source atc.Source `json:"source"`
+	Params atc.Params `json:"params,omitempty"`
+}
+
+func (resource *resource) Delete(ioConfig IOConfig, source atc.Source, params atc.Params, artifactSource ArtifactSource) VersionedSource {
+	resourceDir := ResourcesDir("delete")
+
+	vs := &versionedSource{
+		container:   resource.container,
+		resourceDir: resourceDir,
+	}
+
+	vs.Runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		return resource.runScript(
+			"/opt/resource/delete",
+			[]string{resourceDir},
+			deleteRequest{
+				Params: params,
+				Source: source,
+			},
+			&vs.versionResult,
+			ioConfig.Stderr,
+			artifactSource,
+			vs,
+			true,
+		).Run(signals, ready)
+	})
+
+	return vs
+}
@@ -0,0 +1,172 @@
+package resource_test
+
+import (
+	"errors"
+	"io/ioutil"
+
+	"github.com/cloudfoundry-incubator/garden"
+	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/resource"
+	"github.com/concourse/atc/resource/fakes"
+)
+
+var _ = Describe("Resource Delete", func() {
+	var (
+		source             atc.Source
+		params             atc.Params
+		fakeArtifactSource *fakes.FakeArtifactSource
+
+		deleteScriptStdout     string
+		deleteScriptStderr     string
+		deleteScriptExitStatus int
+		runDeleteError         error
+
+		deleteScriptProcess *gfakes.FakeProcess
+
+		versionedSource VersionedSource
+		deleteProcess   ifrit.Process
+
+		ioConfig  IOConfig
+		stdoutBuf *gbytes.Buffer
+		stderrBuf *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		source = atc.Source{"some": "source"}
+		params = atc.Params{"some": "params"}
+		fakeArtifactSource = new(fakes.FakeArtifactSource)
+
+		deleteScriptStdout = "{}"
+		deleteScriptStderr = ""
+		deleteScriptExitStatus = 0
+		runDeleteError = nil
+
+		deleteScriptProcess = new(gfakes.FakeProcess)
+		deleteScriptProcess.IDReturns(42)
+		deleteScriptProcess.WaitStub = func() (int, error) {
+			return deleteScriptExitStatus, nil
+		}
+
+		stdoutBuf = gbytes.NewBuffer()
+		stderrBuf = gbytes.NewBuffer()
+
+		ioConfig = IOConfig{
+			Stdout: stdoutBuf,
+			Stderr: stderrBuf,
+		}
+
+		fakeContainer.PropertyReturns("", errors.New("nope"))
+	})
+
+	JustBeforeEach(func() {
+		fakeContainer.RunStub = func(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+			if runDeleteError != nil {
+				return nil, runDeleteError
+			}
+
+			_, err := io.Stdout.Write([]byte(deleteScriptStdout))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = io.Stderr.Write([]byte(deleteScriptStderr))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			return deleteScriptProcess, nil
+		}
+
+		versionedSource = resource.Delete(ioConfig, source, params, fakeArtifactSource)
+		deleteProcess = ifrit.Invoke(versionedSource)
+	})
+
+	AfterEach(func() {
+		Eventually(deleteProcess.Wait()).Should(Receive())
+	})
+
+	It("runs /opt/resource/delete <source path> with the request on stdin", func() {
+		Eventually(deleteProcess.Wait()).Should(Receive(BeNil()))
+
+		spec, io := fakeContainer.RunArgsForCall(0)
+		Ω(spec.Path).Should(Equal("/opt/resource/delete"))
+		Ω(spec.Args).Should(ConsistOf("/tmp/build/delete"))
+		Ω(spec.User).Should(Equal("root"))
+
+		request, err := ioutil.ReadAll(io.Stdin)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(request).Should(MatchJSON(`{
+			"params": {"some":"params"},
+			"source": {"some":"source"}
+		}`))
+	})
+
+	It("streams the artifact source to the versioned source", func() {
+		Ω(fakeArtifactSource.StreamToCallCount()).Should(Equal(1))
+
+		dest := fakeArtifactSource.StreamToArgsForCall(0)
+		Ω(dest).Should(Equal(versionedSource))
+	})
+
+	Context("when /opt/resource/delete prints the deleted version and metadata", func() {
+		BeforeEach(func() {
+			deleteScriptStdout = `{
+				"version": {"some": "deleted-version"},
+				"metadata": [
+					{"name": "a", "value":"a-value"}
+				]
+			}`
+		})
+
+		It("can be accessed on the versioned source", func() {
+			Eventually(deleteProcess.Wait()).Should(Receive(BeNil()))
+
+			Ω(versionedSource.Version()).Should(Equal(atc.Version{"some": "deleted-version"}))
+			Ω(versionedSource.Metadata()).Should(Equal([]atc.MetadataField{
+				{Name: "a", Value: "a-value"},
+			}))
+		})
+	})
+
+	Context("when /opt/resource/delete outputs to stderr", func() {
+		BeforeEach(func() {
+			deleteScriptStderr = "some stderr data"
+		})
+
+		It("emits it to the log sink", func() {
+			Eventually(deleteProcess.Wait()).Should(Receive(BeNil()))
+
+			Ω(stderrBuf).Should(gbytes.Say("some stderr data"))
+		})
+	})
+
+	Context("when running /opt/resource/delete fails", func() {
+		disaster := errors.New("oh no!")
+
+		BeforeEach(func() {
+			runDeleteError = disaster
+		})
+
+		It("returns the error", func() {
+			Eventually(deleteProcess.Wait()).Should(Receive(Equal(disaster)))
+		})
+	})
+
+	Context("when /opt/resource/delete exits nonzero", func() {
+		BeforeEach(func() {
+			deleteScriptExitStatus = 9
+		})
+
+		It("returns an ErrResourceScriptFailed", func() {
+			var deleteErr error
+			Eventually(deleteProcess.Wait()).Should(Receive(&deleteErr))
+
+			Ω(deleteErr).Should(BeAssignableToTypeOf(ErrResourceScriptFailed{}))
+			Ω(deleteErr.Error()).Should(ContainSubstring("exit status 9"))
+		})
+	})
+})
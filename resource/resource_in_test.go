@@ -161,15 +161,47 @@ var _ = Describe("Resource In", func() {
 				}
 			})
 
-			It("stops the container", func() {
+			It("terminates the script without stopping the container", func() {
 				inProcess.Signal(os.Interrupt)
 
-				Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+				Eventually(inScriptProcess.SignalCallCount).Should(Equal(1))
+				Ω(inScriptProcess.SignalArgsForCall(0)).Should(Equal(garden.SignalTerminate))
 
-				kill := fakeContainer.StopArgsForCall(0)
-				Ω(kill).Should(BeFalse())
+				Consistently(fakeContainer.StopCallCount).Should(BeZero())
 
 				close(waited)
+
+				Eventually(inProcess.Wait()).Should(Receive(Equal(ErrAborted)))
+			})
+
+			Context("when signalled again", func() {
+				It("kills the container", func() {
+					inProcess.Signal(os.Interrupt)
+					Eventually(inScriptProcess.SignalCallCount).Should(Equal(1))
+
+					inProcess.Signal(os.Interrupt)
+					Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+
+					kill := fakeContainer.StopArgsForCall(0)
+					Ω(kill).Should(BeTrue())
+
+					close(waited)
+				})
+			})
+
+			Context("when killed", func() {
+				It("kills the container immediately", func() {
+					inProcess.Signal(os.Kill)
+
+					Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+
+					kill := fakeContainer.StopArgsForCall(0)
+					Ω(kill).Should(BeTrue())
+
+					Ω(inScriptProcess.SignalCallCount()).Should(BeZero())
+
+					close(waited)
+				})
 			})
 		})
 	}
@@ -162,15 +162,47 @@ var _ = Describe("Resource Out", func() {
 				}
 			})
 
-			It("stops the container", func() {
+			It("terminates the script without stopping the container", func() {
 				outProcess.Signal(os.Interrupt)
 
-				Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+				Eventually(outScriptProcess.SignalCallCount).Should(Equal(1))
+				Ω(outScriptProcess.SignalArgsForCall(0)).Should(Equal(garden.SignalTerminate))
 
-				kill := fakeContainer.StopArgsForCall(0)
-				Ω(kill).Should(BeFalse())
+				Consistently(fakeContainer.StopCallCount).Should(BeZero())
 
 				close(waited)
+
+				Eventually(outProcess.Wait()).Should(Receive(Equal(ErrAborted)))
+			})
+
+			Context("when signalled again", func() {
+				It("kills the container", func() {
+					outProcess.Signal(os.Interrupt)
+					Eventually(outScriptProcess.SignalCallCount).Should(Equal(1))
+
+					outProcess.Signal(os.Interrupt)
+					Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+
+					kill := fakeContainer.StopArgsForCall(0)
+					Ω(kill).Should(BeTrue())
+
+					close(waited)
+				})
+			})
+
+			Context("when killed", func() {
+				It("kills the container immediately", func() {
+					outProcess.Signal(os.Kill)
+
+					Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+
+					kill := fakeContainer.StopArgsForCall(0)
+					Ω(kill).Should(BeTrue())
+
+					Ω(outScriptProcess.SignalCallCount()).Should(BeZero())
+
+					close(waited)
+				})
 			})
 		})
 	}
@@ -139,33 +139,51 @@ func (resource *resource) runScript(
 			}
 		}()
 
-		select {
-		case status := <-statusCh:
-			if status != 0 {
-				return ErrResourceScriptFailed{
-					Path:       path,
-					Args:       args,
-					ExitStatus: status,
-
-					Stderr: stderr.String(),
+		var interrupted bool
+
+		for {
+			select {
+			case status := <-statusCh:
+				if interrupted {
+					return ErrAborted
 				}
-			}
 
-			if recoverable {
-				err := resource.container.SetProperty(resourceResultPropertyName, stdout.String())
-				if err != nil {
-					return err
+				if status != 0 {
+					return ErrResourceScriptFailed{
+						Path:       path,
+						Args:       args,
+						ExitStatus: status,
+
+						Stderr: stderr.String(),
+					}
 				}
-			}
 
-			return json.Unmarshal(stdout.Bytes(), output)
+				if recoverable {
+					err := resource.container.SetProperty(resourceResultPropertyName, stdout.String())
+					if err != nil {
+						return err
+					}
+				}
 
-		case err := <-errCh:
-			return err
+				return json.Unmarshal(stdout.Bytes(), output)
+
+			case err := <-errCh:
+				return err
 
-		case <-signals:
-			resource.container.Stop(false)
-			return ErrAborted
+			case sig := <-signals:
+				if sig != os.Kill && !interrupted {
+					// ask the script to terminate, leaving it to the caller to send
+					// os.Kill if it takes too long
+					err := process.Signal(garden.SignalTerminate)
+					if err == nil {
+						interrupted = true
+						continue
+					}
+				}
+
+				resource.container.Stop(true)
+				return ErrAborted
+			}
 		}
 	})
 }
@@ -13,19 +13,29 @@ const (
 	ListBuilds  = "ListBuilds"
 	BuildEvents = "BuildEvents"
 	AbortBuild  = "AbortBuild"
+	RerunBuild  = "RerunBuild"
 
-	GetJob        = "GetJob"
-	ListJobs      = "ListJobs"
-	ListJobBuilds = "ListJobBuilds"
-	GetJobBuild   = "GetJobBuild"
-	PauseJob      = "PauseJob"
-	UnpauseJob    = "UnpauseJob"
+	GetJob         = "GetJob"
+	ListJobs       = "ListJobs"
+	ListJobBuilds  = "ListJobBuilds"
+	CreateJobBuild = "CreateJobBuild"
+	GetJobBuild    = "GetJobBuild"
+	PauseJob       = "PauseJob"
+	UnpauseJob     = "UnpauseJob"
+	ClearJobCaches = "ClearJobCaches"
+
+	GetJobSchedulingStatus = "GetJobSchedulingStatus"
+	PreviewJobInputs       = "PreviewJobInputs"
 
 	ListResources          = "ListResources"
 	EnableResourceVersion  = "EnableResourceVersion"
 	DisableResourceVersion = "DisableResourceVersion"
+	PinResourceVersion     = "PinResourceVersion"
+	UnpinResource          = "UnpinResource"
 	PauseResource          = "PauseResource"
 	UnpauseResource        = "UnpauseResource"
+	CheckResource          = "CheckResource"
+	CheckResourceWebHook   = "CheckResourceWebHook"
 
 	ListPipelines   = "ListPipelines"
 	DeletePipeline  = "DeletePipeline"
@@ -55,14 +65,19 @@ var Routes = rata.Routes{
 	{Path: "/api/v1/builds", Method: "GET", Name: ListBuilds},
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/rerun", Method: "POST", Name: RerunBuild},
 	{Path: "/api/v1/hijack", Method: "POST", Name: Hijack},
 
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
+	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/caches", Method: "DELETE", Name: ClearJobCaches},
+	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/scheduling-status", Method: "GET", Name: GetJobSchedulingStatus},
+	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: PreviewJobInputs},
 
 	{Path: "/api/v1/pipelines", Method: "GET", Name: ListPipelines},
 	{Path: "/api/v1/pipelines/:pipeline_name", Method: "DELETE", Name: DeletePipeline},
@@ -73,8 +88,12 @@ var Routes = rata.Routes{
 	{Path: "/api/v1/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 
 	{Path: "/api/v1/pipes", Method: "POST", Name: CreatePipe},
 	{Path: "/api/v1/pipes/:pipe_id", Method: "PUT", Name: WritePipe},
@@ -20,26 +20,69 @@ func (factory *BuildFactory) Create(
 ) (atc.Plan, error) {
 
 	hasConditionals := factory.hasConditionals(job.Plan)
-	hasHooks := factory.hasHooks(job.Plan)
+	hasHooks := factory.hasHooks(job.Plan) || len(job.Hooks()) > 0
 
 	if hasHooks && hasConditionals {
 		return atc.Plan{}, errors.New("you cannot have a plan with hooks and conditionals")
 	}
 
+	var plan atc.Plan
 	if hasConditionals {
-		return factory.constructPlanSequenceBasedPlan(
+		plan = factory.constructPlanSequenceBasedPlan(
 			job.Plan,
 			resources,
-			inputs), nil
+			inputs)
 	} else {
-		populateLocations(&job.Plan)
+		stepCount := populateLocations(&job.Plan)
+		populateJobHookLocations(job, stepCount)
 
-		plan := factory.constructPlanHookBasedPlan(
+		plan = factory.constructPlanHookBasedPlan(
 			job.Plan,
 			resources,
 			inputs)
-		return plan, nil
+
+		plan = factory.constructJobHooks(plan, job, resources, inputs)
 	}
+
+	return plan, nil
+}
+
+// CreateErrored constructs the plan for a build of the job that errored before
+// its plan could run, e.g. because its inputs could not be determined. The
+// job's on_failure and ensure hooks are run, after which the build errors with
+// the given cause.
+func (factory *BuildFactory) CreateErrored(
+	job atc.JobConfig,
+	resources atc.ResourceConfigs,
+	cause error,
+) (atc.Plan, error) {
+	populateJobHookLocations(job, 1)
+
+	plan := atc.Plan{
+		Error: &atc.ErrorPlan{
+			Message: cause.Error(),
+		},
+	}
+
+	// work backwards, so that on_failure runs before ensure
+	for _, hook := range []*atc.PlanConfig{job.Ensure, job.Failure} {
+		if hook == nil {
+			continue
+		}
+
+		plan = atc.Plan{
+			Compose: &atc.ComposePlan{
+				A: atc.Plan{
+					Try: &atc.TryPlan{
+						Step: factory.constructPlanFromConfig(*hook, resources, nil, true),
+					},
+				},
+				B: plan,
+			},
+		}
+	}
+
+	return plan, nil
 }
 
 func (factory *BuildFactory) hasConditionals(planSequence atc.PlanSequence) bool {
@@ -50,7 +93,7 @@ func (factory *BuildFactory) hasConditionals(planSequence atc.PlanSequence) bool
 
 func (factory *BuildFactory) hasHooks(planSequence atc.PlanSequence) bool {
 	return factory.doesAnyStepMatch(planSequence, func(step atc.PlanConfig) bool {
-		return step.Failure != nil || step.Ensure != nil || step.Success != nil
+		return step.Failure != nil || step.Ensure != nil || step.Success != nil || step.Abort != nil
 	})
 }
 
@@ -117,7 +160,9 @@ func (factory *BuildFactory) constructPlanHookBasedPlan(
 	}
 }
 
-func populateLocations(planSequence *atc.PlanSequence) {
+// populateLocations numbers the steps of the plan, returning the next free
+// location ID.
+func populateLocations(planSequence *atc.PlanSequence) uint {
 	p := *planSequence
 	stepCount := uint(1)
 
@@ -131,6 +176,36 @@ func populateLocations(planSequence *atc.PlanSequence) {
 		stepCount = stepCount + populatePlanLocations(&plan, location)
 		p[i] = plan
 	}
+
+	return stepCount
+}
+
+// populateJobHookLocations numbers the job's hooks after the steps of its
+// plan, starting from the given location ID.
+func populateJobHookLocations(job atc.JobConfig, stepCount uint) {
+	hooks := []struct {
+		plan *atc.PlanConfig
+		hook string
+	}{
+		{job.Failure, "failure"},
+		{job.Success, "success"},
+		{job.Abort, "abort"},
+		{job.Ensure, "ensure"},
+	}
+
+	for _, hook := range hooks {
+		if hook.plan == nil {
+			continue
+		}
+
+		location := &atc.Location{
+			ID:            stepCount,
+			ParentID:      0,
+			ParallelGroup: 0,
+			Hook:          hook.hook,
+		}
+		stepCount = stepCount + populatePlanLocations(hook.plan, location)
+	}
 }
 
 func populatePlanLocations(planConfig *atc.PlanConfig, location *atc.Location) uint {
@@ -141,8 +216,11 @@ func populatePlanLocations(planConfig *atc.PlanConfig, location *atc.Location) u
 	switch {
 	case planConfig.Put != "":
 		planConfig.Location = location
-		// offset by one for the dependent get that will be added
-		stepCount = stepCount + 1
+
+		if !planConfig.NoGet {
+			// offset by one for the dependent get that will be added
+			stepCount = stepCount + 1
+		}
 
 	case planConfig.Do != nil:
 		children := *planConfig.Do
@@ -219,6 +297,16 @@ func populatePlanLocations(planConfig *atc.PlanConfig, location *atc.Location) u
 		}
 		stepCount = stepCount + populatePlanLocations(child, childLocation)
 	}
+	if planConfig.Abort != nil {
+		child := planConfig.Abort
+		childLocation := &atc.Location{
+			ID:            location.ID + stepCount + 1,
+			ParentID:      parentID,
+			ParallelGroup: 0,
+			Hook:          "abort",
+		}
+		stepCount = stepCount + populatePlanLocations(child, childLocation)
+	}
 	if planConfig.Ensure != nil {
 		child := planConfig.Ensure
 		childLocation := &atc.Location{
@@ -356,23 +444,14 @@ func (factory *BuildFactory) constructPlanFromConfig(
 		resource, _ := resources.Lookup(resourceName)
 
 		putPlan := &atc.PutPlan{
-			Type:     resource.Type,
-			Name:     logicalName,
-			Pipeline: factory.PipelineName,
-			Resource: resourceName,
-			Source:   resource.Source,
-			Params:   planConfig.Params,
-			Tags:     planConfig.Tags,
-		}
-
-		dependentGetPlan := &atc.DependentGetPlan{
-			Type:     resource.Type,
-			Name:     logicalName,
-			Pipeline: factory.PipelineName,
-			Resource: resourceName,
-			Params:   planConfig.GetParams,
-			Tags:     planConfig.Tags,
-			Source:   resource.Source,
+			Type:            resource.Type,
+			Name:            logicalName,
+			Pipeline:        factory.PipelineName,
+			Resource:        resourceName,
+			Source:          resource.Source,
+			Params:          planConfig.Params,
+			Tags:            planConfig.Tags,
+			SensitiveParams: planConfig.SensitiveParams,
 		}
 
 		stepLocation := &atc.Location{}
@@ -392,6 +471,26 @@ func (factory *BuildFactory) constructPlanFromConfig(
 			nextLocation.ParentID = stepLocation.ID
 		}
 
+		if planConfig.NoGet {
+			plan = atc.Plan{
+				Location: stepLocation,
+				Put:      putPlan,
+			}
+
+			break
+		}
+
+		dependentGetPlan := &atc.DependentGetPlan{
+			Type:            resource.Type,
+			Name:            logicalName,
+			Pipeline:        factory.PipelineName,
+			Resource:        resourceName,
+			Params:          planConfig.GetParams,
+			Tags:            planConfig.Tags,
+			Source:          resource.Source,
+			SensitiveParams: planConfig.SensitiveParams,
+		}
+
 		plan = atc.Plan{
 			// Location: planConfig.Location,
 			OnSuccess: &atc.OnSuccessPlan{
@@ -426,14 +525,37 @@ func (factory *BuildFactory) constructPlanFromConfig(
 		plan = atc.Plan{
 			Location: planConfig.Location,
 			Get: &atc.GetPlan{
-				Type:     resource.Type,
-				Name:     name,
-				Pipeline: factory.PipelineName,
-				Resource: resourceName,
-				Source:   resource.Source,
-				Params:   planConfig.Params,
-				Version:  atc.Version(version),
-				Tags:     planConfig.Tags,
+				Type:            resource.Type,
+				Name:            name,
+				Pipeline:        factory.PipelineName,
+				Resource:        resourceName,
+				Source:          resource.Source,
+				Params:          planConfig.Params,
+				Version:         atc.Version(version),
+				Tags:            planConfig.Tags,
+				SensitiveParams: planConfig.SensitiveParams,
+			},
+		}
+
+	case planConfig.Delete != "":
+		resourceName := planConfig.Resource
+		if resourceName == "" {
+			resourceName = planConfig.Delete
+		}
+
+		resource, _ := resources.Lookup(resourceName)
+
+		plan = atc.Plan{
+			Location: planConfig.Location,
+			Delete: &atc.DeletePlan{
+				Type:            resource.Type,
+				Name:            planConfig.Delete,
+				Pipeline:        factory.PipelineName,
+				Resource:        resourceName,
+				Source:          resource.Source,
+				Params:          planConfig.Params,
+				Tags:            planConfig.Tags,
+				SensitiveParams: planConfig.SensitiveParams,
 			},
 		}
 
@@ -441,11 +563,15 @@ func (factory *BuildFactory) constructPlanFromConfig(
 		plan = atc.Plan{
 			Location: planConfig.Location,
 			Task: &atc.TaskPlan{
-				Name:       planConfig.Task,
-				Privileged: planConfig.Privileged,
-				Config:     planConfig.TaskConfig,
-				ConfigPath: planConfig.TaskConfigPath,
-				Tags:       planConfig.Tags,
+				Name:            planConfig.Task,
+				Privileged:      planConfig.Privileged,
+				Config:          planConfig.TaskConfig,
+				ConfigPath:      planConfig.TaskConfigPath,
+				Tags:            planConfig.Tags,
+				SensitiveParams: planConfig.SensitiveParams,
+
+				InputMapping:  planConfig.InputMapping,
+				OutputMapping: planConfig.OutputMapping,
 			},
 		}
 
@@ -477,8 +603,10 @@ func (factory *BuildFactory) constructPlanFromConfig(
 		}
 
 		plan = atc.Plan{
-			Location:  planConfig.Location,
-			Aggregate: &aggregate,
+			Location:          planConfig.Location,
+			Aggregate:         &aggregate,
+			AggregateLimit:    planConfig.Limit,
+			AggregateFailFast: planConfig.FailFast,
 		}
 	}
 
@@ -500,14 +628,52 @@ func (factory *BuildFactory) constructPlanFromConfig(
 		}
 	}
 
-	constructionParams := factory.ensureIfPresent(factory.successIfPresent(factory.failureIfPresent(
+	if planConfig.Attempts > 1 {
+		retry := atc.RetryPlan{}
+
+		for attempt := 1; attempt <= planConfig.Attempts; attempt++ {
+			retry = append(retry, withAttempt(plan, uint(attempt)))
+		}
+
+		plan = atc.Plan{
+			Retry: &retry,
+		}
+	}
+
+	constructionParams := factory.ensureIfPresent(factory.abortIfPresent(factory.successIfPresent(factory.failureIfPresent(
 		constructionParams{
 			plan:       plan,
 			planConfig: planConfig,
 			resources:  resources,
 			inputs:     inputs,
 			hasHooks:   hasHooks,
-		})),
+		}))),
+	)
+
+	return constructionParams.plan
+}
+
+// constructJobHooks wraps the job's plan in the job's hooks, in the same way
+// as the hooks of a single step.
+func (factory *BuildFactory) constructJobHooks(
+	plan atc.Plan,
+	job atc.JobConfig,
+	resources atc.ResourceConfigs,
+	inputs []db.BuildInput,
+) atc.Plan {
+	constructionParams := factory.ensureIfPresent(factory.abortIfPresent(factory.successIfPresent(factory.failureIfPresent(
+		constructionParams{
+			plan: plan,
+			planConfig: atc.PlanConfig{
+				Success: job.Success,
+				Failure: job.Failure,
+				Abort:   job.Abort,
+				Ensure:  job.Ensure,
+			},
+			resources: resources,
+			inputs:    inputs,
+			hasHooks:  true,
+		}))),
 	)
 
 	return constructionParams.plan
@@ -559,6 +725,25 @@ func (factory *BuildFactory) failureIfPresent(constructionParams constructionPar
 	return constructionParams
 }
 
+func (factory *BuildFactory) abortIfPresent(constructionParams constructionParams) constructionParams {
+	if constructionParams.planConfig.Abort != nil {
+		nextPlan := factory.constructPlanFromConfig(
+			*constructionParams.planConfig.Abort,
+			constructionParams.resources,
+			constructionParams.inputs,
+			constructionParams.hasHooks)
+
+		constructionParams.plan = atc.Plan{
+			OnAbort: &atc.OnAbortPlan{
+				Step: constructionParams.plan,
+				Next: nextPlan,
+			},
+		}
+	}
+
+	return constructionParams
+}
+
 func (factory *BuildFactory) ensureIfPresent(constructionParams constructionParams) constructionParams {
 	if constructionParams.planConfig.Ensure != nil {
 		nextPlan := factory.constructPlanFromConfig(
@@ -576,3 +761,93 @@ func (factory *BuildFactory) ensureIfPresent(constructionParams constructionPara
 	}
 	return constructionParams
 }
+
+// withAttempt returns a copy of the plan with the given attempt number set on
+// every location within it, so that each attempt of a retried step gets its
+// own containers.
+func withAttempt(plan atc.Plan, attempt uint) atc.Plan {
+	if plan.Location != nil {
+		location := *plan.Location
+		location.Attempt = attempt
+		plan.Location = &location
+	} else if plan.Get != nil || plan.Put != nil || plan.Delete != nil || plan.Task != nil || plan.DependentGet != nil {
+		plan.Location = &atc.Location{Attempt: attempt}
+	}
+
+	if plan.Compose != nil {
+		plan.Compose = &atc.ComposePlan{
+			A: withAttempt(plan.Compose.A, attempt),
+			B: withAttempt(plan.Compose.B, attempt),
+		}
+	}
+
+	if plan.Aggregate != nil {
+		aggregate := atc.AggregatePlan{}
+		for _, step := range *plan.Aggregate {
+			aggregate = append(aggregate, withAttempt(step, attempt))
+		}
+
+		plan.Aggregate = &aggregate
+	}
+
+	if plan.Conditional != nil {
+		plan.Conditional = &atc.ConditionalPlan{
+			Conditions: plan.Conditional.Conditions,
+			Plan:       withAttempt(plan.Conditional.Plan, attempt),
+		}
+	}
+
+	if plan.Ensure != nil {
+		plan.Ensure = &atc.EnsurePlan{
+			Step: withAttempt(plan.Ensure.Step, attempt),
+			Next: withAttempt(plan.Ensure.Next, attempt),
+		}
+	}
+
+	if plan.OnSuccess != nil {
+		plan.OnSuccess = &atc.OnSuccessPlan{
+			Step: withAttempt(plan.OnSuccess.Step, attempt),
+			Next: withAttempt(plan.OnSuccess.Next, attempt),
+		}
+	}
+
+	if plan.OnFailure != nil {
+		plan.OnFailure = &atc.OnFailurePlan{
+			Step: withAttempt(plan.OnFailure.Step, attempt),
+			Next: withAttempt(plan.OnFailure.Next, attempt),
+		}
+	}
+
+	if plan.OnAbort != nil {
+		plan.OnAbort = &atc.OnAbortPlan{
+			Step: withAttempt(plan.OnAbort.Step, attempt),
+			Next: withAttempt(plan.OnAbort.Next, attempt),
+		}
+	}
+
+	if plan.Try != nil {
+		plan.Try = &atc.TryPlan{
+			Step: withAttempt(plan.Try.Step, attempt),
+		}
+	}
+
+	if plan.Timeout != nil {
+		plan.Timeout = &atc.TimeoutPlan{
+			Duration: plan.Timeout.Duration,
+			Step:     withAttempt(plan.Timeout.Step, attempt),
+		}
+	}
+
+	// the attempts of a nested retry are numbered on from those of earlier
+	// attempts of the outer one, so that they still get their own containers
+	if plan.Retry != nil {
+		retry := atc.RetryPlan{}
+		for i, step := range *plan.Retry {
+			retry = append(retry, withAttempt(step, (attempt-1)*uint(len(*plan.Retry))+uint(i)+1))
+		}
+
+		plan.Retry = &retry
+	}
+
+	return plan
+}
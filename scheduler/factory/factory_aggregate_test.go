@@ -74,6 +74,56 @@ var _ = Describe("Factory Aggregate", func() {
 		})
 	})
 
+	Context("when I have an aggregate with a limit that fails fast", func() {
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Aggregate: &atc.PlanSequence{
+							{
+								Task: "some thing",
+							},
+							{
+								Task: "some other thing",
+							},
+						},
+						Limit:    1,
+						FailFast: true,
+					},
+				},
+			}, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				Aggregate: &atc.AggregatePlan{
+					{
+						Location: &atc.Location{
+							ParentID:      0,
+							ID:            3,
+							ParallelGroup: 2,
+						},
+						Task: &atc.TaskPlan{
+							Name: "some thing",
+						},
+					},
+					{
+						Location: &atc.Location{
+							ParentID:      0,
+							ID:            4,
+							ParallelGroup: 2,
+						},
+						Task: &atc.TaskPlan{
+							Name: "some other thing",
+						},
+					},
+				},
+				AggregateLimit:    1,
+				AggregateFailFast: true,
+			}
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+
 	Context("when I have nested aggregates", func() {
 		It("returns the correct plan", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
@@ -0,0 +1,115 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Credentials", func() {
+	var (
+		buildFactory *factory.BuildFactory
+
+		resources atc.ResourceConfigs
+
+		input atc.JobConfig
+	)
+
+	BeforeEach(func() {
+		buildFactory = &factory.BuildFactory{
+			PipelineName: "some-pipeline",
+		}
+
+		resources = atc.ResourceConfigs{
+			{
+				Name: "some-resource",
+				Type: "git",
+				Source: atc.Source{
+					"uri":         "git://some-resource",
+					"private_key": "((private-key))",
+				},
+			},
+		}
+	})
+
+	Context("when a get references credentials", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:    "some-resource",
+						Params: atc.Params{"token": "((token))"},
+					},
+				},
+			}
+		})
+
+		It("leaves the references in the plan, to be resolved as the build runs", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(actual.Get).ShouldNot(BeNil())
+			Ω(actual.Get.Source).Should(Equal(atc.Source{
+				"uri":         "git://some-resource",
+				"private_key": "((private-key))",
+			}))
+			Ω(actual.Get.Params).Should(Equal(atc.Params{"token": "((token))"}))
+		})
+	})
+
+	Context("when a task marks params as sensitive", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+						TaskConfig: &atc.TaskConfig{
+							Params: map[string]string{
+								"PASSWORD": "hunter2",
+								"TOKEN":    "((token))",
+								"USER":     "some-user",
+							},
+						},
+						SensitiveParams: []string{"PASSWORD", "TOKEN"},
+					},
+				},
+			}
+		})
+
+		It("names them in the plan, without their values", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(actual.Task).ShouldNot(BeNil())
+			Ω(actual.Task.Config.Params["TOKEN"]).Should(Equal("((token))"))
+			Ω(actual.Task.SensitiveParams).Should(Equal([]string{"PASSWORD", "TOKEN"}))
+		})
+	})
+
+	Context("when a put marks params as sensitive", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Put:             "some-resource",
+						Params:          atc.Params{"password": "hunter2"},
+						SensitiveParams: []string{"password"},
+					},
+				},
+			}
+		})
+
+		It("names them in the put and the get that follows it", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			put := actual.OnSuccess.Step.Put
+			Ω(put).ShouldNot(BeNil())
+			Ω(put.Source["private_key"]).Should(Equal("((private-key))"))
+			Ω(put.SensitiveParams).Should(Equal([]string{"password"}))
+
+			Ω(actual.OnSuccess.Next.DependentGet.SensitiveParams).Should(Equal([]string{"password"}))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Delete", func() {
+	var (
+		buildFactory *factory.BuildFactory
+
+		resources atc.ResourceConfigs
+
+		input atc.JobConfig
+	)
+
+	BeforeEach(func() {
+		buildFactory = &factory.BuildFactory{
+			PipelineName: "some-pipeline",
+		}
+
+		resources = atc.ResourceConfigs{
+			{
+				Name:   "some-resource",
+				Type:   "git",
+				Source: atc.Source{"uri": "git://some-resource"},
+			},
+		}
+	})
+
+	Context("when I have a delete at the top-level", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Delete:          "some-delete",
+						Resource:        "some-resource",
+						Params:          atc.Params{"branch": "some-branch", "secret": "hidden"},
+						SensitiveParams: []string{"secret"},
+						Tags:            atc.Tags{"some-tag"},
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				Location: &atc.Location{
+					ParentID:      0,
+					ID:            1,
+					ParallelGroup: 0,
+				},
+				Delete: &atc.DeletePlan{
+					Type:     "git",
+					Name:     "some-delete",
+					Resource: "some-resource",
+					Pipeline: "some-pipeline",
+					Source: atc.Source{
+						"uri": "git://some-resource",
+					},
+					Params:          atc.Params{"branch": "some-branch", "secret": "hidden"},
+					Tags:            atc.Tags{"some-tag"},
+					SensitiveParams: []string{"secret"},
+				},
+			}
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+
+	Context("when I have a delete in an ensure hook", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+						Ensure: &atc.PlanConfig{
+							Delete: "some-resource",
+						},
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				Ensure: &atc.EnsurePlan{
+					Step: atc.Plan{
+						Location: &atc.Location{
+							ParentID:      0,
+							ID:            1,
+							ParallelGroup: 0,
+						},
+						Task: &atc.TaskPlan{
+							Name: "some-task",
+						},
+					},
+					Next: atc.Plan{
+						Location: &atc.Location{
+							ParentID:      1,
+							ID:            2,
+							ParallelGroup: 0,
+							Hook:          "ensure",
+						},
+						Delete: &atc.DeletePlan{
+							Type:     "git",
+							Name:     "some-resource",
+							Resource: "some-resource",
+							Pipeline: "some-pipeline",
+							Source: atc.Source{
+								"uri": "git://some-resource",
+							},
+						},
+					},
+				},
+			}
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package factory_test
 
 import (
+	"errors"
+
 	"github.com/concourse/atc"
 	. "github.com/concourse/atc/scheduler/factory"
 
@@ -816,6 +818,48 @@ var _ = Describe("Factory Hooks", func() {
 			Ω(actual).Should(Equal(expected))
 		})
 
+		It("can build a job with an abort hook", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+						Abort: &atc.PlanConfig{
+							Task: "those who were stopped from resisting our will",
+						},
+					},
+				},
+			}, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				OnAbort: &atc.OnAbortPlan{
+					Step: atc.Plan{
+						Location: &atc.Location{
+							ID:            1,
+							ParentID:      0,
+							ParallelGroup: 0,
+						},
+						Task: &atc.TaskPlan{
+							Name: "those who resist our will",
+						},
+					},
+					Next: atc.Plan{
+						Location: &atc.Location{
+							ID:            2,
+							ParentID:      1,
+							ParallelGroup: 0,
+							Hook:          "abort",
+						},
+						Task: &atc.TaskPlan{
+							Name: "those who were stopped from resisting our will",
+						},
+					},
+				},
+			}
+
+			Ω(actual).Should(Equal(expected))
+		})
+
 		It("can build a job with failure, success and ensure hooks at the same level", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
 				Plan: atc.PlanSequence{
@@ -1241,4 +1285,172 @@ var _ = Describe("Factory Hooks", func() {
 			})
 		})
 	})
+
+	Context("when the job has hooks", func() {
+		var input atc.JobConfig
+
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "those who resist our will",
+					},
+				},
+				Success: &atc.PlanConfig{
+					Task: "some success",
+				},
+				Failure: &atc.PlanConfig{
+					Task: "some failure",
+				},
+				Abort: &atc.PlanConfig{
+					Task: "some abort",
+				},
+				Ensure: &atc.PlanConfig{
+					Task: "some ensure",
+				},
+			}
+		})
+
+		It("wraps the entire plan in the hooks", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				Ensure: &atc.EnsurePlan{
+					Step: atc.Plan{
+						OnAbort: &atc.OnAbortPlan{
+							Step: atc.Plan{
+								OnSuccess: &atc.OnSuccessPlan{
+									Step: atc.Plan{
+										OnFailure: &atc.OnFailurePlan{
+											Step: atc.Plan{
+												Location: &atc.Location{
+													ParentID:      0,
+													ID:            1,
+													ParallelGroup: 0,
+												},
+												Task: &atc.TaskPlan{
+													Name: "those who resist our will",
+												},
+											},
+											Next: atc.Plan{
+												Location: &atc.Location{
+													ParentID:      0,
+													ID:            2,
+													ParallelGroup: 0,
+													Hook:          "failure",
+												},
+												Task: &atc.TaskPlan{
+													Name: "some failure",
+												},
+											},
+										},
+									},
+									Next: atc.Plan{
+										Location: &atc.Location{
+											ParentID:      0,
+											ID:            3,
+											ParallelGroup: 0,
+											Hook:          "success",
+										},
+										Task: &atc.TaskPlan{
+											Name: "some success",
+										},
+									},
+								},
+							},
+							Next: atc.Plan{
+								Location: &atc.Location{
+									ParentID:      0,
+									ID:            4,
+									ParallelGroup: 0,
+									Hook:          "abort",
+								},
+								Task: &atc.TaskPlan{
+									Name: "some abort",
+								},
+							},
+						},
+					},
+					Next: atc.Plan{
+						Location: &atc.Location{
+							ParentID:      0,
+							ID:            5,
+							ParallelGroup: 0,
+							Hook:          "ensure",
+						},
+						Task: &atc.TaskPlan{
+							Name: "some ensure",
+						},
+					},
+				},
+			}
+
+			Ω(actual).Should(Equal(expected))
+		})
+
+		Context("when the plan has conditionals", func() {
+			BeforeEach(func() {
+				input.Plan[0].Conditions = &atc.Conditions{atc.ConditionSuccess}
+			})
+
+			It("errors", func() {
+				_, err := buildFactory.Create(input, resources, nil)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+
+		Describe("creating a plan for an errored build", func() {
+			It("runs the failure and ensure hooks before erroring", func() {
+				actual, err := buildFactory.CreateErrored(input, resources, errors.New("nope"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				expected := atc.Plan{
+					Compose: &atc.ComposePlan{
+						A: atc.Plan{
+							Try: &atc.TryPlan{
+								Step: atc.Plan{
+									Location: &atc.Location{
+										ParentID:      0,
+										ID:            1,
+										ParallelGroup: 0,
+										Hook:          "failure",
+									},
+									Task: &atc.TaskPlan{
+										Name: "some failure",
+									},
+								},
+							},
+						},
+						B: atc.Plan{
+							Compose: &atc.ComposePlan{
+								A: atc.Plan{
+									Try: &atc.TryPlan{
+										Step: atc.Plan{
+											Location: &atc.Location{
+												ParentID:      0,
+												ID:            4,
+												ParallelGroup: 0,
+												Hook:          "ensure",
+											},
+											Task: &atc.TaskPlan{
+												Name: "some ensure",
+											},
+										},
+									},
+								},
+								B: atc.Plan{
+									Error: &atc.ErrorPlan{
+										Message: "nope",
+									},
+								},
+							},
+						},
+					},
+				}
+
+				Ω(actual).Should(Equal(expected))
+			})
+		})
+	})
 })
@@ -86,6 +86,60 @@ var _ = Describe("Factory Put", func() {
 		})
 	})
 
+	Context("when I have a put that skips its get", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Put:      "some-put",
+						Resource: "some-resource",
+						NoGet:    true,
+					},
+					{
+						Task: "some-task",
+					},
+				},
+			}
+		})
+
+		It("returns the put without a dependent get", func() {
+			actual, err := buildFactory.Create(input, resources, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				OnSuccess: &atc.OnSuccessPlan{
+					Step: atc.Plan{
+						Location: &atc.Location{
+							ParentID:      0,
+							ID:            1,
+							ParallelGroup: 0,
+						},
+						Put: &atc.PutPlan{
+							Type:     "git",
+							Name:     "some-put",
+							Resource: "some-resource",
+							Pipeline: "some-pipeline",
+							Source: atc.Source{
+								"uri": "git://some-resource",
+							},
+						},
+					},
+					Next: atc.Plan{
+						Location: &atc.Location{
+							ParentID:      0,
+							ID:            2,
+							ParallelGroup: 0,
+						},
+						Task: &atc.TaskPlan{
+							Name: "some-task",
+						},
+					},
+				},
+			}
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+
 	Context("when I have a put in a hook", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
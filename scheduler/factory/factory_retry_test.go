@@ -0,0 +1,177 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Retry Step", func() {
+	var (
+		buildFactory *BuildFactory
+	)
+
+	BeforeEach(func() {
+		buildFactory = &BuildFactory{
+			PipelineName: "some-pipeline",
+		}
+	})
+
+	Context("When there is a task with attempts", func() {
+		It("builds a copy of the task for each attempt", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "first task",
+						Attempts: 2,
+					},
+				},
+			}, nil, nil)
+
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				Retry: &atc.RetryPlan{
+					{
+						Location: &atc.Location{
+							ID:      1,
+							Attempt: 1,
+						},
+						Task: &atc.TaskPlan{
+							Name: "first task",
+						},
+					},
+					{
+						Location: &atc.Location{
+							ID:      1,
+							Attempt: 2,
+						},
+						Task: &atc.TaskPlan{
+							Name: "first task",
+						},
+					},
+				},
+			}
+
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+
+	Context("When there is a single attempt", func() {
+		It("does not wrap the step in a retry", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "first task",
+						Attempts: 1,
+					},
+				},
+			}, nil, nil)
+
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				Location: &atc.Location{
+					ID: 1,
+				},
+				Task: &atc.TaskPlan{
+					Name: "first task",
+				},
+			}
+
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+
+	Context("When a step with attempts is within another", func() {
+		It("numbers the attempts of the inner step on from those of earlier outer attempts", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Try: &atc.PlanConfig{
+							Task:     "first task",
+							Attempts: 2,
+						},
+						Attempts: 2,
+					},
+				},
+			}, nil, nil)
+
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(actual.Retry).ShouldNot(BeNil())
+			Ω(*actual.Retry).Should(HaveLen(2))
+
+			attempts := []uint{}
+			for _, outer := range *actual.Retry {
+				Ω(outer.Try).ShouldNot(BeNil())
+				Ω(outer.Try.Step.Retry).ShouldNot(BeNil())
+
+				for _, inner := range *outer.Try.Step.Retry {
+					attempts = append(attempts, inner.Location.Attempt)
+				}
+			}
+
+			Ω(attempts).Should(Equal([]uint{1, 2, 3, 4}))
+		})
+	})
+
+	Context("When a step with attempts has a hook", func() {
+		It("runs the hook after all of the attempts", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "first task",
+						Attempts: 2,
+						Failure: &atc.PlanConfig{
+							Task: "second task",
+						},
+					},
+				},
+			}, nil, nil)
+
+			Ω(err).ShouldNot(HaveOccurred())
+
+			expected := atc.Plan{
+				OnFailure: &atc.OnFailurePlan{
+					Step: atc.Plan{
+						Retry: &atc.RetryPlan{
+							{
+								Location: &atc.Location{
+									ID:      1,
+									Attempt: 1,
+								},
+								Task: &atc.TaskPlan{
+									Name: "first task",
+								},
+							},
+							{
+								Location: &atc.Location{
+									ID:      1,
+									Attempt: 2,
+								},
+								Task: &atc.TaskPlan{
+									Name: "first task",
+								},
+							},
+						},
+					},
+					Next: atc.Plan{
+						Location: &atc.Location{
+							ID:       2,
+							ParentID: 1,
+							Hook:     "failure",
+						},
+						Task: &atc.TaskPlan{
+							Name: "second task",
+						},
+					},
+				},
+			}
+
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+})
@@ -372,6 +372,36 @@ var _ = Describe("Factory", func() {
 	// })
 	// })
 
+	Context("when a task has input and output mappings", func() {
+		It("passes them along on the task plan", func() {
+			input := atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:          "some-task",
+						InputMapping:  map[string]string{"source-code": "some-input"},
+						OutputMapping: map[string]string{"built-binary": "some-output"},
+					},
+				},
+			}
+
+			expected := atc.Plan{
+				Location: &atc.Location{
+					ID: 1,
+				},
+				Task: &atc.TaskPlan{
+					Name:          "some-task",
+					InputMapping:  map[string]string{"source-code": "some-input"},
+					OutputMapping: map[string]string{"built-binary": "some-output"},
+				},
+			}
+
+			actual, err := factory.Create(input, resources, nil)
+			Ω(err).NotTo(HaveOccurred())
+
+			Ω(actual).Should(Equal(expected))
+		})
+	})
+
 	Context("when a task plan follows a task plan", func() {
 		Context("when it has an explicit condition", func() {
 			It("runs with the given condition", func() {
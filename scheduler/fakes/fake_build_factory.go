@@ -21,6 +21,17 @@ type FakeBuildFactory struct {
 		result1 atc.Plan
 		result2 error
 	}
+	CreateErroredStub        func(atc.JobConfig, atc.ResourceConfigs, error) (atc.Plan, error)
+	createErroredMutex       sync.RWMutex
+	createErroredArgsForCall []struct {
+		arg1 atc.JobConfig
+		arg2 atc.ResourceConfigs
+		arg3 error
+	}
+	createErroredReturns struct {
+		result1 atc.Plan
+		result2 error
+	}
 }
 
 func (fake *FakeBuildFactory) Create(arg1 atc.JobConfig, arg2 atc.ResourceConfigs, arg3 []db.BuildInput) (atc.Plan, error) {
@@ -58,4 +69,39 @@ func (fake *FakeBuildFactory) CreateReturns(result1 atc.Plan, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) CreateErrored(arg1 atc.JobConfig, arg2 atc.ResourceConfigs, arg3 error) (atc.Plan, error) {
+	fake.createErroredMutex.Lock()
+	fake.createErroredArgsForCall = append(fake.createErroredArgsForCall, struct {
+		arg1 atc.JobConfig
+		arg2 atc.ResourceConfigs
+		arg3 error
+	}{arg1, arg2, arg3})
+	fake.createErroredMutex.Unlock()
+	if fake.CreateErroredStub != nil {
+		return fake.CreateErroredStub(arg1, arg2, arg3)
+	} else {
+		return fake.createErroredReturns.result1, fake.createErroredReturns.result2
+	}
+}
+
+func (fake *FakeBuildFactory) CreateErroredCallCount() int {
+	fake.createErroredMutex.RLock()
+	defer fake.createErroredMutex.RUnlock()
+	return len(fake.createErroredArgsForCall)
+}
+
+func (fake *FakeBuildFactory) CreateErroredArgsForCall(i int) (atc.JobConfig, atc.ResourceConfigs, error) {
+	fake.createErroredMutex.RLock()
+	defer fake.createErroredMutex.RUnlock()
+	return fake.createErroredArgsForCall[i].arg1, fake.createErroredArgsForCall[i].arg2, fake.createErroredArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildFactory) CreateErroredReturns(result1 atc.Plan, result2 error) {
+	fake.CreateErroredStub = nil
+	fake.createErroredReturns = struct {
+		result1 atc.Plan
+		result2 error
+	}{result1, result2}
+}
+
 var _ scheduler.BuildFactory = new(FakeBuildFactory)
@@ -4,6 +4,7 @@ package fakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/scheduler"
 )
@@ -25,6 +26,15 @@ type FakeBuildsDB struct {
 	errorBuildReturns struct {
 		result1 error
 	}
+	SaveBuildEventStub        func(buildID int, event atc.Event) error
+	saveBuildEventMutex       sync.RWMutex
+	saveBuildEventArgsForCall []struct {
+		buildID int
+		event   atc.Event
+	}
+	saveBuildEventReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeBuildsDB) GetAllStartedBuilds() ([]db.Build, error) {
@@ -85,4 +95,37 @@ func (fake *FakeBuildsDB) ErrorBuildReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildsDB) SaveBuildEvent(buildID int, event atc.Event) error {
+	fake.saveBuildEventMutex.Lock()
+	fake.saveBuildEventArgsForCall = append(fake.saveBuildEventArgsForCall, struct {
+		buildID int
+		event   atc.Event
+	}{buildID, event})
+	fake.saveBuildEventMutex.Unlock()
+	if fake.SaveBuildEventStub != nil {
+		return fake.SaveBuildEventStub(buildID, event)
+	} else {
+		return fake.saveBuildEventReturns.result1
+	}
+}
+
+func (fake *FakeBuildsDB) SaveBuildEventCallCount() int {
+	fake.saveBuildEventMutex.RLock()
+	defer fake.saveBuildEventMutex.RUnlock()
+	return len(fake.saveBuildEventArgsForCall)
+}
+
+func (fake *FakeBuildsDB) SaveBuildEventArgsForCall(i int) (int, atc.Event) {
+	fake.saveBuildEventMutex.RLock()
+	defer fake.saveBuildEventMutex.RUnlock()
+	return fake.saveBuildEventArgsForCall[i].buildID, fake.saveBuildEventArgsForCall[i].event
+}
+
+func (fake *FakeBuildsDB) SaveBuildEventReturns(result1 error) {
+	fake.SaveBuildEventStub = nil
+	fake.saveBuildEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ scheduler.BuildsDB = new(FakeBuildsDB)
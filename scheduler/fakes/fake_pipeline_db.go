@@ -19,6 +19,25 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateJobBuildWithInputsStub        func(job string, inputs []db.BuildInput) (db.Build, error)
+	createJobBuildWithInputsMutex       sync.RWMutex
+	createJobBuildWithInputsArgsForCall []struct {
+		job    string
+		inputs []db.BuildInput
+	}
+	createJobBuildWithInputsReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	CreateJobRerunBuildStub        func(buildID int) (db.Build, error)
+	createJobRerunBuildMutex       sync.RWMutex
+	createJobRerunBuildArgsForCall []struct {
+		buildID int
+	}
+	createJobRerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
 	CreateJobBuildForCandidateInputsStub        func(job string) (db.Build, bool, error)
 	createJobBuildForCandidateInputsMutex       sync.RWMutex
 	createJobBuildForCandidateInputsArgsForCall []struct {
@@ -68,6 +87,15 @@ type FakePipelineDB struct {
 		result1 []db.BuildInput
 		result2 error
 	}
+	GetVersionedResourceStub        func(versionedResourceID int) (db.SavedVersionedResource, error)
+	getVersionedResourceMutex       sync.RWMutex
+	getVersionedResourceArgsForCall []struct {
+		versionedResourceID int
+	}
+	getVersionedResourceReturns struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}
 	SaveResourceVersionsStub        func(atc.ResourceConfig, []atc.Version) error
 	saveResourceVersionsMutex       sync.RWMutex
 	saveResourceVersionsArgsForCall []struct {
@@ -86,6 +114,34 @@ type FakePipelineDB struct {
 	useInputsForBuildReturns struct {
 		result1 error
 	}
+	GetBuildResourcesStub        func(buildID int) ([]db.BuildInput, []db.BuildOutput, error)
+	getBuildResourcesMutex       sync.RWMutex
+	getBuildResourcesArgsForCall []struct {
+		buildID int
+	}
+	getBuildResourcesReturns struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}
+	GetResourceStub        func(resourceName string) (db.SavedResource, error)
+	getResourceMutex       sync.RWMutex
+	getResourceArgsForCall []struct {
+		resourceName string
+	}
+	getResourceReturns struct {
+		result1 db.SavedResource
+		result2 error
+	}
+	SaveJobSchedulingStatusStub        func(job string, status db.SchedulingStatus) error
+	saveJobSchedulingStatusMutex       sync.RWMutex
+	saveJobSchedulingStatusArgsForCall []struct {
+		job    string
+		status db.SchedulingStatus
+	}
+	saveJobSchedulingStatusReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakePipelineDB) CreateJobBuild(job string) (db.Build, error) {
@@ -121,6 +177,73 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) CreateJobBuildWithInputs(job string, inputs []db.BuildInput) (db.Build, error) {
+	fake.createJobBuildWithInputsMutex.Lock()
+	fake.createJobBuildWithInputsArgsForCall = append(fake.createJobBuildWithInputsArgsForCall, struct {
+		job    string
+		inputs []db.BuildInput
+	}{job, inputs})
+	fake.createJobBuildWithInputsMutex.Unlock()
+	if fake.CreateJobBuildWithInputsStub != nil {
+		return fake.CreateJobBuildWithInputsStub(job, inputs)
+	} else {
+		return fake.createJobBuildWithInputsReturns.result1, fake.createJobBuildWithInputsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputsCallCount() int {
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
+	return len(fake.createJobBuildWithInputsArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputsArgsForCall(i int) (string, []db.BuildInput) {
+	fake.createJobBuildWithInputsMutex.RLock()
+	defer fake.createJobBuildWithInputsMutex.RUnlock()
+	return fake.createJobBuildWithInputsArgsForCall[i].job, fake.createJobBuildWithInputsArgsForCall[i].inputs
+}
+
+func (fake *FakePipelineDB) CreateJobBuildWithInputsReturns(result1 db.Build, result2 error) {
+	fake.CreateJobBuildWithInputsStub = nil
+	fake.createJobBuildWithInputsReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuild(buildID int) (db.Build, error) {
+	fake.createJobRerunBuildMutex.Lock()
+	fake.createJobRerunBuildArgsForCall = append(fake.createJobRerunBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.createJobRerunBuildMutex.Unlock()
+	if fake.CreateJobRerunBuildStub != nil {
+		return fake.CreateJobRerunBuildStub(buildID)
+	} else {
+		return fake.createJobRerunBuildReturns.result1, fake.createJobRerunBuildReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuildCallCount() int {
+	fake.createJobRerunBuildMutex.RLock()
+	defer fake.createJobRerunBuildMutex.RUnlock()
+	return len(fake.createJobRerunBuildArgsForCall)
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuildArgsForCall(i int) int {
+	fake.createJobRerunBuildMutex.RLock()
+	defer fake.createJobRerunBuildMutex.RUnlock()
+	return fake.createJobRerunBuildArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) CreateJobRerunBuildReturns(result1 db.Build, result2 error) {
+	fake.CreateJobRerunBuildStub = nil
+	fake.createJobRerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputs(job string) (db.Build, bool, error) {
 	fake.createJobBuildForCandidateInputsMutex.Lock()
 	fake.createJobBuildForCandidateInputsArgsForCall = append(fake.createJobBuildForCandidateInputsArgsForCall, struct {
@@ -290,6 +413,39 @@ func (fake *FakePipelineDB) GetLatestInputVersionsReturns(result1 []db.BuildInpu
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) GetVersionedResource(versionedResourceID int) (db.SavedVersionedResource, error) {
+	fake.getVersionedResourceMutex.Lock()
+	fake.getVersionedResourceArgsForCall = append(fake.getVersionedResourceArgsForCall, struct {
+		versionedResourceID int
+	}{versionedResourceID})
+	fake.getVersionedResourceMutex.Unlock()
+	if fake.GetVersionedResourceStub != nil {
+		return fake.GetVersionedResourceStub(versionedResourceID)
+	} else {
+		return fake.getVersionedResourceReturns.result1, fake.getVersionedResourceReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetVersionedResourceCallCount() int {
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
+	return len(fake.getVersionedResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetVersionedResourceArgsForCall(i int) int {
+	fake.getVersionedResourceMutex.RLock()
+	defer fake.getVersionedResourceMutex.RUnlock()
+	return fake.getVersionedResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) GetVersionedResourceReturns(result1 db.SavedVersionedResource, result2 error) {
+	fake.GetVersionedResourceStub = nil
+	fake.getVersionedResourceReturns = struct {
+		result1 db.SavedVersionedResource
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) SaveResourceVersions(arg1 atc.ResourceConfig, arg2 []atc.Version) error {
 	fake.saveResourceVersionsMutex.Lock()
 	fake.saveResourceVersionsArgsForCall = append(fake.saveResourceVersionsArgsForCall, struct {
@@ -356,4 +512,104 @@ func (fake *FakePipelineDB) UseInputsForBuildReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetBuildResources(buildID int) ([]db.BuildInput, []db.BuildOutput, error) {
+	fake.getBuildResourcesMutex.Lock()
+	fake.getBuildResourcesArgsForCall = append(fake.getBuildResourcesArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.getBuildResourcesMutex.Unlock()
+	if fake.GetBuildResourcesStub != nil {
+		return fake.GetBuildResourcesStub(buildID)
+	} else {
+		return fake.getBuildResourcesReturns.result1, fake.getBuildResourcesReturns.result2, fake.getBuildResourcesReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetBuildResourcesCallCount() int {
+	fake.getBuildResourcesMutex.RLock()
+	defer fake.getBuildResourcesMutex.RUnlock()
+	return len(fake.getBuildResourcesArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetBuildResourcesArgsForCall(i int) int {
+	fake.getBuildResourcesMutex.RLock()
+	defer fake.getBuildResourcesMutex.RUnlock()
+	return fake.getBuildResourcesArgsForCall[i].buildID
+}
+
+func (fake *FakePipelineDB) GetBuildResourcesReturns(result1 []db.BuildInput, result2 []db.BuildOutput, result3 error) {
+	fake.GetBuildResourcesStub = nil
+	fake.getBuildResourcesReturns = struct {
+		result1 []db.BuildInput
+		result2 []db.BuildOutput
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipelineDB) GetResource(resourceName string) (db.SavedResource, error) {
+	fake.getResourceMutex.Lock()
+	fake.getResourceArgsForCall = append(fake.getResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.getResourceMutex.Unlock()
+	if fake.GetResourceStub != nil {
+		return fake.GetResourceStub(resourceName)
+	} else {
+		return fake.getResourceReturns.result1, fake.getResourceReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetResourceCallCount() int {
+	fake.getResourceMutex.RLock()
+	defer fake.getResourceMutex.RUnlock()
+	return len(fake.getResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetResourceArgsForCall(i int) string {
+	fake.getResourceMutex.RLock()
+	defer fake.getResourceMutex.RUnlock()
+	return fake.getResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) GetResourceReturns(result1 db.SavedResource, result2 error) {
+	fake.GetResourceStub = nil
+	fake.getResourceReturns = struct {
+		result1 db.SavedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatus(job string, status db.SchedulingStatus) error {
+	fake.saveJobSchedulingStatusMutex.Lock()
+	fake.saveJobSchedulingStatusArgsForCall = append(fake.saveJobSchedulingStatusArgsForCall, struct {
+		job    string
+		status db.SchedulingStatus
+	}{job, status})
+	fake.saveJobSchedulingStatusMutex.Unlock()
+	if fake.SaveJobSchedulingStatusStub != nil {
+		return fake.SaveJobSchedulingStatusStub(job, status)
+	} else {
+		return fake.saveJobSchedulingStatusReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatusCallCount() int {
+	fake.saveJobSchedulingStatusMutex.RLock()
+	defer fake.saveJobSchedulingStatusMutex.RUnlock()
+	return len(fake.saveJobSchedulingStatusArgsForCall)
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatusArgsForCall(i int) (string, db.SchedulingStatus) {
+	fake.saveJobSchedulingStatusMutex.RLock()
+	defer fake.saveJobSchedulingStatusMutex.RUnlock()
+	return fake.saveJobSchedulingStatusArgsForCall[i].job, fake.saveJobSchedulingStatusArgsForCall[i].status
+}
+
+func (fake *FakePipelineDB) SaveJobSchedulingStatusReturns(result1 error) {
+	fake.SaveJobSchedulingStatusStub = nil
+	fake.saveJobSchedulingStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ scheduler.PipelineDB = new(FakePipelineDB)
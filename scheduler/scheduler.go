@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/pivotal-golang/lager"
@@ -8,12 +9,16 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/worker"
 )
 
 //go:generate counterfeiter . PipelineDB
 
 type PipelineDB interface {
 	CreateJobBuild(job string) (db.Build, error)
+	CreateJobBuildWithInputs(job string, inputs []db.BuildInput) (db.Build, error)
+	CreateJobRerunBuild(buildID int) (db.Build, error)
 	CreateJobBuildForCandidateInputs(job string) (db.Build, bool, error)
 	ScheduleBuild(buildID int, jobConfig atc.JobConfig) (bool, error)
 
@@ -21,8 +26,13 @@ type PipelineDB interface {
 	GetNextPendingBuild(job string) (db.Build, error)
 
 	GetLatestInputVersions(job string, inputs []atc.JobInput) ([]db.BuildInput, error)
+	GetVersionedResource(versionedResourceID int) (db.SavedVersionedResource, error)
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	UseInputsForBuild(buildID int, inputs []db.BuildInput) error
+	GetBuildResources(buildID int) ([]db.BuildInput, []db.BuildOutput, error)
+
+	GetResource(resourceName string) (db.SavedResource, error)
+	SaveJobSchedulingStatus(job string, status db.SchedulingStatus) error
 }
 
 //go:generate counterfeiter . BuildsDB
@@ -30,12 +40,14 @@ type PipelineDB interface {
 type BuildsDB interface {
 	GetAllStartedBuilds() ([]db.Build, error)
 	ErrorBuild(buildID int, err error) error
+	SaveBuildEvent(buildID int, event atc.Event) error
 }
 
 //go:generate counterfeiter . BuildFactory
 
 type BuildFactory interface {
 	Create(atc.JobConfig, atc.ResourceConfigs, []db.BuildInput) (atc.Plan, error)
+	CreateErrored(atc.JobConfig, atc.ResourceConfigs, error) (atc.Plan, error)
 }
 
 type Waiter interface {
@@ -54,8 +66,14 @@ type Scheduler struct {
 	Factory    BuildFactory
 	Engine     engine.Engine
 	Scanner    Scanner
+
+	// used to explain jobs that no worker can build; optional
+	Workers worker.WorkerProvider
 }
 
+// BuildLatestInputs creates and schedules a build of the job if there are new
+// versions of its trigger inputs, recording why it did or did not for the
+// job's scheduling status.
 func (s *Scheduler) BuildLatestInputs(logger lager.Logger, job atc.JobConfig, resources atc.ResourceConfigs) error {
 	logger = logger.Session("build-latest")
 
@@ -63,18 +81,46 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, job atc.JobConfig, re
 
 	if len(inputs) == 0 {
 		// no inputs; no-op
+		s.saveSchedulingStatus(logger, job, db.SchedulingStatus{
+			Reason: db.SchedulingReasonNoInputs,
+		})
+
 		return nil
 	}
 
+	if !hasEveryVersionInput(inputs) {
+		_, err := s.buildLatestInputs(logger, job, inputs, resources)
+		return err
+	}
+
+	// every version of an input gets its own build, so keep going until all
+	// versions have been built or a build cannot be scheduled yet (e.g. due
+	// to serial groups)
+	for {
+		build, err := s.buildLatestInputs(logger, job, inputs, resources)
+		if err != nil || build == nil {
+			return err
+		}
+	}
+}
+
+func (s *Scheduler) buildLatestInputs(logger lager.Logger, job atc.JobConfig, inputs []atc.JobInput, resources atc.ResourceConfigs) (engine.Build, error) {
 	latestInputs, err := s.PipelineDB.GetLatestInputVersions(job.Name, inputs)
 	if err != nil {
 		if err == db.ErrNoVersions {
 			logger.Debug("no-input-versions-available")
-			return nil
+
+			// which input is missing versions is worked out when the status is
+			// asked for, rather than on every tick
+			s.saveSchedulingStatus(logger, job, db.SchedulingStatus{
+				Reason: db.SchedulingReasonNoVersions,
+			})
+
+			return nil, nil
 		}
 
 		logger.Error("failed-to-get-latest-input-versions", err)
-		return err
+		return nil, err
 	}
 
 	checkInputs := []db.BuildInput{}
@@ -92,7 +138,12 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, job atc.JobConfig, re
 
 	if len(checkInputs) == 0 {
 		logger.Debug("no-triggered-input-versions")
-		return nil
+
+		s.saveSchedulingStatus(logger, job, db.SchedulingStatus{
+			Reason: db.SchedulingReasonNoTriggerInputs,
+		})
+
+		return nil, nil
 	}
 
 	existingBuild, err := s.PipelineDB.GetJobBuildForInputs(job.Name, checkInputs)
@@ -101,20 +152,25 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, job atc.JobConfig, re
 			"existing-build": existingBuild.ID,
 		})
 
-		return nil
+		// a build that is still waiting to be scheduled has recorded why
+		if existingBuild.Scheduled || existingBuild.Status != db.StatusPending {
+			s.explainUpToDate(logger, job, inputs, existingBuild)
+		}
+
+		return nil, nil
 	}
 
 	build, created, err := s.PipelineDB.CreateJobBuildForCandidateInputs(job.Name)
 	if err != nil {
 		logger.Error("failed-to-create-build", err)
-		return err
+		return nil, err
 	}
 
 	if !created {
 		logger.Debug("waiting-for-existing-build-to-determine-inputs", lager.Data{
 			"existing-build": build.ID,
 		})
-		return nil
+		return nil, nil
 	}
 
 	logger.Debug("created-build", lager.Data{"build": build.ID})
@@ -122,9 +178,214 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, job atc.JobConfig, re
 	// NOTE: this is intentionally serial within a scheduler tick, so that
 	// multiple ATCs don't do redundant work to determine a build's inputs.
 
-	s.scheduleAndResumePendingBuild(logger, build, job, resources)
+	createdBuild, determined := s.scheduleAndResumePendingBuild(logger, build, job, resources)
+	if !determined {
+		// the build errored without using a version, so trying again this tick
+		// would only error another
+		return nil, nil
+	}
+
+	return createdBuild, nil
+}
+
+// ExplainSchedulingStatus adds the detail to the job's recorded scheduling
+// status that is too costly to work out on every tick: whether any worker can
+// fetch each of its inputs, and which input no versions satisfy.
+func (s *Scheduler) ExplainSchedulingStatus(job atc.JobConfig, resources atc.ResourceConfigs, status db.SchedulingStatus) (db.SchedulingStatus, error) {
+	// builds of the job error if no worker can fetch its inputs, which
+	// explains the job better than whatever was recorded while scheduling it
+	incompatible, found, err := s.explainIncompatibleWorkers(job, resources)
+	if err != nil {
+		return db.SchedulingStatus{}, err
+	}
+
+	if found {
+		incompatible.Time = status.Time
+		return incompatible, nil
+	}
+
+	if status.Reason == db.SchedulingReasonNoVersions && status.Input == "" {
+		missing, err := s.explainMissingVersions(job, job.Inputs())
+		if err != nil {
+			return db.SchedulingStatus{}, err
+		}
+
+		missing.Time = status.Time
+		return missing, nil
+	}
+
+	return status, nil
+}
+
+// explainMissingVersions determines why no versions satisfy the inputs,
+// checking each input on its own and then each of its passed constraints.
+func (s *Scheduler) explainMissingVersions(job atc.JobConfig, inputs []atc.JobInput) (db.SchedulingStatus, error) {
+	for _, input := range inputs {
+		unconstrained := input
+		unconstrained.Passed = nil
+
+		_, err := s.PipelineDB.GetLatestInputVersions(job.Name, []atc.JobInput{unconstrained})
+		if err == db.ErrNoVersions {
+			resource, err := s.PipelineDB.GetResource(input.Resource)
+			if err != nil {
+				return db.SchedulingStatus{}, err
+			}
+
+			reason := db.SchedulingReasonNoVersions
+			if resource.Paused {
+				reason = db.SchedulingReasonResourcePaused
+			}
+
+			return db.SchedulingStatus{
+				Reason:   reason,
+				Input:    input.Name,
+				Resource: input.Resource,
+			}, nil
+		}
+
+		if err != nil {
+			return db.SchedulingStatus{}, err
+		}
+
+		for _, passed := range input.Passed {
+			constrained := unconstrained
+			constrained.Passed = []string{passed}
+
+			_, err := s.PipelineDB.GetLatestInputVersions(job.Name, []atc.JobInput{constrained})
+			if err == db.ErrNoVersions {
+				return db.SchedulingStatus{
+					Reason:    db.SchedulingReasonPassedUnsatisfied,
+					Input:     input.Name,
+					Resource:  input.Resource,
+					PassedJob: passed,
+				}, nil
+			}
+
+			if err != nil {
+				return db.SchedulingStatus{}, err
+			}
+		}
+	}
+
+	// every input has versions on its own, so inputs passed through the same
+	// job must not have come out of the same build of it
+	passedJobs := map[string]bool{}
+	for _, input := range inputs {
+		for _, passed := range input.Passed {
+			if passedJobs[passed] {
+				return db.SchedulingStatus{
+					Reason:    db.SchedulingReasonPassedUnsatisfied,
+					Input:     input.Name,
+					Resource:  input.Resource,
+					PassedJob: passed,
+				}, nil
+			}
+
+			passedJobs[passed] = true
+		}
+	}
+
+	return db.SchedulingStatus{Reason: db.SchedulingReasonNoVersions}, nil
+}
+
+// explainUpToDate records that the job has already built the latest versions
+// of its trigger inputs, pointing out paused resources that will not get any
+// newer versions.
+func (s *Scheduler) explainUpToDate(logger lager.Logger, job atc.JobConfig, inputs []atc.JobInput, build db.Build) {
+	for _, input := range inputs {
+		if !input.Trigger {
+			continue
+		}
+
+		resource, err := s.PipelineDB.GetResource(input.Resource)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err, lager.Data{"resource": input.Resource})
+			return
+		}
+
+		if resource.Paused {
+			s.saveSchedulingStatus(logger, job, db.SchedulingStatus{
+				Reason:    db.SchedulingReasonResourcePaused,
+				Input:     input.Name,
+				Resource:  input.Resource,
+				BuildName: build.Name,
+			})
+
+			return
+		}
+	}
+
+	s.saveSchedulingStatus(logger, job, db.SchedulingStatus{
+		Reason:    db.SchedulingReasonUpToDate,
+		BuildName: build.Name,
+	})
+}
+
+// explainIncompatibleWorkers determines whether no worker can run the
+// resource of one of the job's inputs.
+func (s *Scheduler) explainIncompatibleWorkers(job atc.JobConfig, resources atc.ResourceConfigs) (db.SchedulingStatus, bool, error) {
+	if s.Workers == nil {
+		return db.SchedulingStatus{}, false, nil
+	}
+
+	inputs := job.Inputs()
+	if len(inputs) == 0 {
+		return db.SchedulingStatus{}, false, nil
+	}
+
+	workers, err := s.Workers.Workers()
+	if err != nil {
+		return db.SchedulingStatus{}, false, err
+	}
+
+	for _, input := range inputs {
+		resource, found := resources.Lookup(input.Resource)
+		if !found {
+			continue
+		}
+
+		spec := worker.ResourceTypeContainerSpec{
+			Type: resource.Type,
+			Tags: input.Tags,
+		}
+
+		if !anySatisfy(workers, spec) {
+			return db.SchedulingStatus{
+				Reason:   db.SchedulingReasonNoCompatibleWorkers,
+				Input:    input.Name,
+				Resource: input.Resource,
+			}, true, nil
+		}
+	}
+
+	return db.SchedulingStatus{}, false, nil
+}
+
+func anySatisfy(workers []worker.Worker, spec worker.ContainerSpec) bool {
+	for _, w := range workers {
+		if w.Satisfies(spec) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Scheduler) saveSchedulingStatus(logger lager.Logger, job atc.JobConfig, status db.SchedulingStatus) {
+	err := s.PipelineDB.SaveJobSchedulingStatus(job.Name, status)
+	if err != nil {
+		logger.Error("failed-to-save-scheduling-status", err)
+	}
+}
+
+func hasEveryVersionInput(inputs []atc.JobInput) bool {
+	for _, input := range inputs {
+		if input.Version.Every() {
+			return true
+		}
+	}
 
-	return nil
+	return false
 }
 
 func (s *Scheduler) TryNextPendingBuild(logger lager.Logger, job atc.JobConfig, resources atc.ResourceConfigs) Waiter {
@@ -168,58 +429,233 @@ func (s *Scheduler) TriggerImmediately(logger lager.Logger, job atc.JobConfig, r
 	return build, nil
 }
 
-func (s *Scheduler) scheduleAndResumePendingBuild(logger lager.Logger, build db.Build, job atc.JobConfig, resources atc.ResourceConfigs) engine.Build {
+// InputVersionsError is returned when the versions chosen for the inputs of a
+// build cannot be used.
+type InputVersionsError struct {
+	Reason string
+}
+
+func (err InputVersionsError) Error() string {
+	return "invalid input versions: " + err.Reason
+}
+
+// TriggerWithVersions creates a build like TriggerImmediately, but using the
+// chosen versions for some of the job's inputs. The chosen versions must have
+// passed through the jobs that their inputs' passed constraints require,
+// unless ignorePassed is true.
+//
+// The build is created with its inputs already determined, so that they are
+// still used if the build cannot be scheduled yet.
+func (s *Scheduler) TriggerWithVersions(logger lager.Logger, job atc.JobConfig, resources atc.ResourceConfigs, chosen []atc.JobBuildInput, ignorePassed bool) (db.Build, error) {
+	if len(chosen) == 0 {
+		return s.TriggerImmediately(logger, job, resources)
+	}
+
+	logger = logger.Session("trigger-with-versions")
+
+	inputs := job.Inputs()
+
+	for _, choice := range chosen {
+		err := s.pinInput(inputs, choice, ignorePassed)
+		if err != nil {
+			logger.Info("invalid-input-version", lager.Data{"input": choice.Name, "error": err.Error()})
+			return db.Build{}, err
+		}
+	}
+
+	buildInputs, err := s.PipelineDB.GetLatestInputVersions(job.Name, inputs)
+	if err != nil {
+		if err == db.ErrNoVersions {
+			return db.Build{}, InputVersionsError{"the chosen versions do not satisfy the inputs' passed constraints"}
+		}
+
+		logger.Error("failed-to-get-input-versions", err)
+		return db.Build{}, err
+	}
+
+	build, err := s.PipelineDB.CreateJobBuildWithInputs(job.Name, buildInputs)
+	if err != nil {
+		logger.Error("failed-to-create-build", err)
+		return db.Build{}, err
+	}
+
+	go s.scheduleAndResumePendingBuild(logger, build, job, resources)
+
+	return build, nil
+}
+
+func (s *Scheduler) RerunBuild(logger lager.Logger, buildID int, job atc.JobConfig, resources atc.ResourceConfigs) (db.Build, error) {
+	logger = logger.Session("rerun", lager.Data{"rerun-of": buildID})
+
+	build, err := s.PipelineDB.CreateJobRerunBuild(buildID)
+	if err != nil {
+		logger.Error("failed-to-create-rerun-build", err)
+		return db.Build{}, err
+	}
+
+	// the rerun's inputs were copied from the original build, so scheduling
+	// it plans the build with them rather than determining new ones
+	go s.scheduleAndResumePendingBuild(logger, build, job, resources)
+
+	return build, nil
+}
+
+func (s *Scheduler) pinInput(inputs []atc.JobInput, choice atc.JobBuildInput, ignorePassed bool) error {
+	for i, input := range inputs {
+		if input.Name != choice.Name {
+			continue
+		}
+
+		version := choice.Version
+
+		if choice.VersionID != 0 {
+			svr, err := s.PipelineDB.GetVersionedResource(choice.VersionID)
+			if err == db.ErrNoVersions {
+				return InputVersionsError{fmt.Sprintf("version %d of input '%s' does not exist", choice.VersionID, choice.Name)}
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if svr.Resource != input.Resource {
+				return InputVersionsError{fmt.Sprintf("version %d of input '%s' is not a version of resource '%s'", choice.VersionID, choice.Name, input.Resource)}
+			}
+
+			version = atc.Version(svr.Version)
+		}
+
+		if len(version) == 0 {
+			return InputVersionsError{fmt.Sprintf("no version chosen for input '%s'", choice.Name)}
+		}
+
+		pinned, err := atc.PinnedVersionConfig(version)
+		if err != nil {
+			return err
+		}
+
+		inputs[i].Version = pinned
+
+		if ignorePassed {
+			inputs[i].Passed = nil
+		}
+
+		return nil
+	}
+
+	return InputVersionsError{fmt.Sprintf("job has no input named '%s'", choice.Name)}
+}
+
+// scheduleAndResumePendingBuild schedules the build and starts running it,
+// determining its inputs first if they have not been. It returns false only
+// if determining them failed, in which case the build has been errored rather
+// than left pending, and what it returns is the build running the job's
+// hooks, if any.
+func (s *Scheduler) scheduleAndResumePendingBuild(logger lager.Logger, build db.Build, job atc.JobConfig, resources atc.ResourceConfigs) (engine.Build, bool) {
 	logger = logger.WithData(lager.Data{"build": build.ID})
 
 	scheduled, err := s.PipelineDB.ScheduleBuild(build.ID, job)
 	if err != nil {
 		logger.Error("failed-to-schedule-build", err)
-		return nil
+		return nil, true
 	}
 
 	if !scheduled {
 		logger.Debug("build-could-not-be-scheduled")
-		return nil
+		return nil, true
 	}
 
-	buildInputs := job.Inputs()
+	var inputs []db.BuildInput
 
-	for _, input := range buildInputs {
-		scanLog := logger.Session("scan", lager.Data{
-			"input":    input.Name,
-			"resource": input.Resource,
-		})
-
-		err := s.Scanner.Scan(scanLog, input.Resource)
+	if build.InputsDetermined {
+		// the inputs were chosen by whoever triggered the build or copied from
+		// the build being rerun
+		inputs, _, err = s.PipelineDB.GetBuildResources(build.ID)
 		if err != nil {
-			scanLog.Error("failed-to-scan", err)
+			logger.Error("failed-to-get-build-resources", err)
+			return nil, true
+		}
+	} else {
+		buildInputs := job.Inputs()
+
+		for _, input := range buildInputs {
+			scanLog := logger.Session("scan", lager.Data{
+				"input":    input.Name,
+				"resource": input.Resource,
+			})
 
-			err := s.BuildsDB.ErrorBuild(build.ID, err)
+			err := s.Scanner.Scan(scanLog, input.Resource)
 			if err != nil {
-				logger.Error("failed-to-mark-build-as-errored", err)
+				scanLog.Error("failed-to-scan", err)
+				return s.errorBuild(logger, build, job, resources, err), false
 			}
 
-			return nil
+			scanLog.Info("done")
+		}
+
+		inputs, err = s.PipelineDB.GetLatestInputVersions(job.Name, buildInputs)
+		if err != nil {
+			logger.Error("failed-to-get-latest-input-versions", err)
+			return s.errorBuild(logger, build, job, resources, err), false
+		}
+
+		err = s.PipelineDB.UseInputsForBuild(build.ID, inputs)
+		if err != nil {
+			logger.Error("failed-to-use-inputs-for-build", err)
+			return s.errorBuild(logger, build, job, resources, err), false
 		}
+	}
 
-		scanLog.Info("done")
+	plan, err := s.Factory.Create(job, resources, inputs)
+	if err != nil {
+		logger.Error("failed-to-create-build-plan", err)
+		return s.errorBuild(logger, build, job, resources, err), true
 	}
 
-	inputs, err := s.PipelineDB.GetLatestInputVersions(job.Name, buildInputs)
+	createdBuild, err := s.Engine.CreateBuild(build, plan)
 	if err != nil {
-		logger.Error("failed-to-get-latest-input-versions", err)
+		logger.Error("failed-to-create-build", err)
+		return nil, true
+	}
+
+	if createdBuild != nil {
+		logger.Info("building")
+		go createdBuild.Resume(logger)
+	}
+
+	return createdBuild, true
+}
+
+// errorBuild errors a build before its plan has run. If the job has hooks that
+// run on failure, a build is created to run them, which errors once they have
+// finished.
+func (s *Scheduler) errorBuild(logger lager.Logger, build db.Build, job atc.JobConfig, resources atc.ResourceConfigs, cause error) engine.Build {
+	if job.Failure == nil && job.Ensure == nil {
+		err := s.BuildsDB.ErrorBuild(build.ID, cause)
+		if err != nil {
+			logger.Error("failed-to-mark-build-as-errored", err)
+		}
+
 		return nil
 	}
 
-	err = s.PipelineDB.UseInputsForBuild(build.ID, inputs)
+	plan, err := s.Factory.CreateErrored(job, resources, cause)
 	if err != nil {
-		logger.Error("failed-to-use-inputs-for-build", err)
+		logger.Error("failed-to-create-errored-build-plan", err)
+
+		err := s.BuildsDB.ErrorBuild(build.ID, cause)
+		if err != nil {
+			logger.Error("failed-to-mark-build-as-errored", err)
+		}
+
 		return nil
 	}
 
-	plan, err := s.Factory.Create(job, resources, inputs)
+	err = s.BuildsDB.SaveBuildEvent(build.ID, event.Error{
+		Message: cause.Error(),
+	})
 	if err != nil {
-		logger.Error("failed-to-create-build-plan", err)
+		logger.Error("failed-to-save-error-event", err)
 		return nil
 	}
 
@@ -230,7 +666,7 @@ func (s *Scheduler) scheduleAndResumePendingBuild(logger lager.Logger, build db.
 	}
 
 	if createdBuild != nil {
-		logger.Info("building")
+		logger.Info("running-hooks")
 		go createdBuild.Resume(logger)
 	}
 
@@ -2,12 +2,16 @@ package scheduler_test
 
 import (
 	"errors"
+	"reflect"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	enginefakes "github.com/concourse/atc/engine/fakes"
+	"github.com/concourse/atc/event"
 	. "github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/fakes"
+	"github.com/concourse/atc/worker"
+	workerfakes "github.com/concourse/atc/worker/fakes"
 	"github.com/pivotal-golang/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
@@ -413,6 +417,482 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job gets every version of an input", func() {
+			var versions [][]db.BuildInput
+			var usedInputs [][]db.BuildInput
+
+			BeforeEach(func() {
+				job = atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:     "some-resource",
+							Trigger: true,
+							Version: atc.VersionEvery,
+						},
+					},
+				}
+
+				versions = nil
+				for i := 1; i <= 3; i++ {
+					versions = append(versions, []db.BuildInput{
+						{
+							Name: "some-resource",
+							VersionedResource: db.VersionedResource{
+								Resource: "some-resource", Version: db.Version{"version": i},
+							},
+						},
+					})
+				}
+
+				usedInputs = nil
+
+				// resolves the oldest version newer than the latest one used, or
+				// the latest once they have all been used
+				fakePipelineDB.GetLatestInputVersionsStub = func(string, []atc.JobInput) ([]db.BuildInput, error) {
+					next := 0
+					for _, used := range usedInputs {
+						for i, version := range versions {
+							if reflect.DeepEqual(used, version) && i+1 > next {
+								next = i + 1
+							}
+						}
+					}
+
+					if next < len(versions) {
+						return versions[next], nil
+					}
+
+					return versions[len(versions)-1], nil
+				}
+
+				fakePipelineDB.UseInputsForBuildStub = func(buildID int, inputs []db.BuildInput) error {
+					usedInputs = append(usedInputs, inputs)
+					return nil
+				}
+
+				fakePipelineDB.GetJobBuildForInputsStub = func(job string, inputs []db.BuildInput) (db.Build, error) {
+					for i, used := range usedInputs {
+						if reflect.DeepEqual(used, inputs) {
+							return db.Build{ID: i + 1}, nil
+						}
+					}
+
+					return db.Build{}, errors.New("no build")
+				}
+
+				fakePipelineDB.CreateJobBuildForCandidateInputsReturns(db.Build{ID: 128, Name: "42"}, true, nil)
+				fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+			})
+
+			Context("when the builds can be scheduled", func() {
+				BeforeEach(func() {
+					fakePipelineDB.ScheduleBuildReturns(true, nil)
+				})
+
+				It("creates a build for each version, in order", func() {
+					err := scheduler.BuildLatestInputs(logger, job, resources)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(3))
+					Ω(usedInputs).Should(Equal(versions))
+				})
+
+				Context("when an older version is rerun and then new versions appear", func() {
+					It("creates a build for each of the new versions", func() {
+						err := scheduler.BuildLatestInputs(logger, job, resources)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						rerun := versions[0]
+						usedInputs = append(usedInputs, rerun)
+
+						for i := 4; i <= 5; i++ {
+							versions = append(versions, []db.BuildInput{
+								{
+									Name: "some-resource",
+									VersionedResource: db.VersionedResource{
+										Resource: "some-resource", Version: db.Version{"version": i},
+									},
+								},
+							})
+						}
+
+						err = scheduler.BuildLatestInputs(logger, job, resources)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(5))
+						Ω(usedInputs[4:]).Should(Equal(versions[3:]))
+					})
+				})
+			})
+
+			Context("when a build's inputs cannot be determined", func() {
+				BeforeEach(func() {
+					fakePipelineDB.ScheduleBuildReturns(true, nil)
+					fakeScanner.ScanReturns(errors.New("nope"))
+
+					job.Failure = &atc.PlanConfig{
+						Task: "some-failure",
+					}
+				})
+
+				It("stops after erroring the build, even though its hooks run", func() {
+					err := scheduler.BuildLatestInputs(logger, job, resources)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(fakePipelineDB.CreateJobBuildForCandidateInputsCallCount()).Should(Equal(1))
+					Ω(factory.CreateErroredCallCount()).Should(Equal(1))
+					Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(1))
+				})
+			})
+
+			Context("when a build cannot be scheduled", func() {
+				BeforeEach(func() {
+					fakePipelineDB.ScheduleBuildReturns(false, nil)
+				})
+
+				It("stops after creating the pending build", func() {
+					err := scheduler.BuildLatestInputs(logger, job, resources)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(fakePipelineDB.CreateJobBuildForCandidateInputsCallCount()).Should(Equal(1))
+					Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(0))
+				})
+			})
+		})
+
+		Describe("recording the job's scheduling status", func() {
+			var savedStatus func() db.SchedulingStatus
+
+			BeforeEach(func() {
+				savedStatus = func() db.SchedulingStatus {
+					saves := fakePipelineDB.SaveJobSchedulingStatusCallCount()
+					Ω(saves).ShouldNot(BeZero())
+
+					jobName, status := fakePipelineDB.SaveJobSchedulingStatusArgsForCall(saves - 1)
+					Ω(jobName).Should(Equal("some-job"))
+
+					return status
+				}
+
+				fakePipelineDB.GetLatestInputVersionsReturns([]db.BuildInput{
+					{
+						Name: "some-input",
+						VersionedResource: db.VersionedResource{
+							Resource: "some-resource", Version: db.Version{"version": "1"},
+						},
+					},
+				}, nil)
+			})
+
+			JustBeforeEach(func() {
+				err := scheduler.BuildLatestInputs(logger, job, resources)
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			Context("when the job has no inputs", func() {
+				BeforeEach(func() {
+					job.InputConfigs = []atc.JobInputConfig{}
+				})
+
+				It("records that the job has no inputs", func() {
+					Ω(savedStatus()).Should(Equal(db.SchedulingStatus{
+						Reason: db.SchedulingReasonNoInputs,
+					}))
+				})
+			})
+
+			Context("when none of the inputs trigger the job", func() {
+				BeforeEach(func() {
+					for i := range job.InputConfigs {
+						job.InputConfigs[i].Trigger = false
+					}
+				})
+
+				It("records that the job has no trigger inputs", func() {
+					Ω(savedStatus()).Should(Equal(db.SchedulingStatus{
+						Reason: db.SchedulingReasonNoTriggerInputs,
+					}))
+				})
+			})
+
+			Context("when a build has already run with the latest versions", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobBuildForInputsReturns(db.Build{
+						ID:        128,
+						Name:      "42",
+						Status:    db.StatusSucceeded,
+						Scheduled: true,
+					}, nil)
+				})
+
+				It("records that the job is up to date", func() {
+					Ω(savedStatus()).Should(Equal(db.SchedulingStatus{
+						Reason:    db.SchedulingReasonUpToDate,
+						BuildName: "42",
+					}))
+				})
+
+				Context("when the resource of a trigger input is paused", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetResourceStub = func(name string) (db.SavedResource, error) {
+							return db.SavedResource{
+								Paused:   name == "some-other-resource",
+								Resource: db.Resource{Name: name},
+							}, nil
+						}
+					})
+
+					It("records that the resource is paused", func() {
+						Ω(savedStatus()).Should(Equal(db.SchedulingStatus{
+							Reason:    db.SchedulingReasonResourcePaused,
+							Input:     "some-other-input",
+							Resource:  "some-other-resource",
+							BuildName: "42",
+						}))
+					})
+				})
+			})
+
+			Context("when the build for the latest versions is waiting to be scheduled", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobBuildForInputsReturns(db.Build{
+						ID:     128,
+						Name:   "42",
+						Status: db.StatusPending,
+					}, nil)
+				})
+
+				It("leaves the status recorded when scheduling the build", func() {
+					Ω(fakePipelineDB.SaveJobSchedulingStatusCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when no versions satisfy the inputs", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetLatestInputVersionsReturns(nil, db.ErrNoVersions)
+				})
+
+				It("records that no versions satisfy them, without working out why", func() {
+					Ω(savedStatus()).Should(Equal(db.SchedulingStatus{
+						Reason: db.SchedulingReasonNoVersions,
+					}))
+
+					Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
+				})
+			})
+
+			Context("when workers are known", func() {
+				var fakeWorkerProvider *workerfakes.FakeWorkerProvider
+
+				BeforeEach(func() {
+					fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
+					scheduler.Workers = fakeWorkerProvider
+				})
+
+				It("does not check them on every tick", func() {
+					Ω(fakeWorkerProvider.WorkersCallCount()).Should(BeZero())
+				})
+			})
+		})
+	})
+
+	Describe("ExplainSchedulingStatus", func() {
+		var (
+			status db.SchedulingStatus
+
+			explained  db.SchedulingStatus
+			explainErr error
+		)
+
+		BeforeEach(func() {
+			status = db.SchedulingStatus{
+				Reason:    db.SchedulingReasonUpToDate,
+				BuildName: "42",
+			}
+		})
+
+		JustBeforeEach(func() {
+			explained, explainErr = scheduler.ExplainSchedulingStatus(job, resources, status)
+		})
+
+		It("leaves a status that needs no explaining alone", func() {
+			Ω(explainErr).ShouldNot(HaveOccurred())
+			Ω(explained).Should(Equal(status))
+		})
+
+		Context("when no versions satisfy the inputs", func() {
+			var unsatisfiable func([]atc.JobInput) bool
+
+			BeforeEach(func() {
+				status = db.SchedulingStatus{
+					Reason: db.SchedulingReasonNoVersions,
+				}
+
+				unsatisfiable = func(inputs []atc.JobInput) bool {
+					return len(inputs) > 1
+				}
+
+				fakePipelineDB.GetLatestInputVersionsStub = func(jobName string, inputs []atc.JobInput) ([]db.BuildInput, error) {
+					if unsatisfiable(inputs) {
+						return nil, db.ErrNoVersions
+					}
+
+					return []db.BuildInput{}, nil
+				}
+			})
+
+			Context("because an input's resource has no versions", func() {
+				BeforeEach(func() {
+					unsatisfiable = func(inputs []atc.JobInput) bool {
+						return len(inputs) > 1 || inputs[0].Name == "some-other-input"
+					}
+				})
+
+				It("explains that the input has no versions", func() {
+					Ω(explainErr).ShouldNot(HaveOccurred())
+					Ω(explained).Should(Equal(db.SchedulingStatus{
+						Reason:   db.SchedulingReasonNoVersions,
+						Input:    "some-other-input",
+						Resource: "some-other-resource",
+					}))
+				})
+
+				Context("and the resource is paused", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetResourceReturns(db.SavedResource{Paused: true}, nil)
+					})
+
+					It("explains that the resource is paused", func() {
+						Ω(fakePipelineDB.GetResourceArgsForCall(0)).Should(Equal("some-other-resource"))
+
+						Ω(explainErr).ShouldNot(HaveOccurred())
+						Ω(explained).Should(Equal(db.SchedulingStatus{
+							Reason:   db.SchedulingReasonResourcePaused,
+							Input:    "some-other-input",
+							Resource: "some-other-resource",
+						}))
+					})
+				})
+			})
+
+			Context("because an upstream job has not succeeded with a version", func() {
+				BeforeEach(func() {
+					job.InputConfigs[0].Passed = []string{"upstream-a", "upstream-b"}
+
+					unsatisfiable = func(inputs []atc.JobInput) bool {
+						if len(inputs) > 1 {
+							return true
+						}
+
+						return len(inputs[0].Passed) == 1 && inputs[0].Passed[0] == "upstream-b"
+					}
+				})
+
+				It("explains which upstream job", func() {
+					Ω(explainErr).ShouldNot(HaveOccurred())
+					Ω(explained).Should(Equal(db.SchedulingStatus{
+						Reason:    db.SchedulingReasonPassedUnsatisfied,
+						Input:     "some-input",
+						Resource:  "some-resource",
+						PassedJob: "upstream-b",
+					}))
+				})
+			})
+
+			Context("because inputs passed through the same job were built separately", func() {
+				BeforeEach(func() {
+					job.InputConfigs[0].Passed = []string{"upstream"}
+					job.InputConfigs[1].Passed = []string{"upstream"}
+				})
+
+				It("explains which upstream job they share", func() {
+					Ω(explainErr).ShouldNot(HaveOccurred())
+					Ω(explained).Should(Equal(db.SchedulingStatus{
+						Reason:    db.SchedulingReasonPassedUnsatisfied,
+						Input:     "some-other-input",
+						Resource:  "some-other-resource",
+						PassedJob: "upstream",
+					}))
+				})
+			})
+
+			Context("when determining the versions fails", func() {
+				disaster := errors.New("oh no!")
+
+				BeforeEach(func() {
+					fakePipelineDB.GetLatestInputVersionsStub = nil
+					fakePipelineDB.GetLatestInputVersionsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Ω(explainErr).Should(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when workers are known", func() {
+			var (
+				fakeWorker         *workerfakes.FakeWorker
+				fakeWorkerProvider *workerfakes.FakeWorkerProvider
+			)
+
+			BeforeEach(func() {
+				fakeWorker = new(workerfakes.FakeWorker)
+				fakeWorker.SatisfiesReturns(true)
+
+				fakeWorkerProvider = new(workerfakes.FakeWorkerProvider)
+				fakeWorkerProvider.WorkersReturns([]worker.Worker{fakeWorker}, nil)
+
+				scheduler.Workers = fakeWorkerProvider
+
+				job.InputConfigs = []atc.JobInputConfig{
+					{
+						RawName:  "some-input",
+						Resource: "some-resource",
+						Trigger:  true,
+					},
+				}
+			})
+
+			It("checks that they can fetch the inputs", func() {
+				Ω(fakeWorker.SatisfiesCallCount()).Should(Equal(1))
+				Ω(fakeWorker.SatisfiesArgsForCall(0)).Should(Equal(worker.ResourceTypeContainerSpec{
+					Type: "git",
+				}))
+
+				Ω(explainErr).ShouldNot(HaveOccurred())
+				Ω(explained).Should(Equal(status))
+			})
+
+			Context("when no worker can fetch an input", func() {
+				BeforeEach(func() {
+					fakeWorker.SatisfiesReturns(false)
+				})
+
+				It("explains that there are no compatible workers", func() {
+					Ω(explainErr).ShouldNot(HaveOccurred())
+					Ω(explained).Should(Equal(db.SchedulingStatus{
+						Reason:   db.SchedulingReasonNoCompatibleWorkers,
+						Input:    "some-input",
+						Resource: "some-resource",
+					}))
+				})
+			})
+
+			Context("when listing the workers fails", func() {
+				disaster := errors.New("oh no!")
+
+				BeforeEach(func() {
+					fakeWorkerProvider.WorkersReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Ω(explainErr).Should(Equal(disaster))
+				})
+			})
+		})
 	})
 
 	Describe("TryNextPendingBuild", func() {
@@ -507,6 +987,35 @@ var _ = Describe("Scheduler", func() {
 						Ω(plan).Should(Equal(createdPlan))
 					})
 
+					It("does not look for inputs the build already has", func() {
+						Ω(fakePipelineDB.GetBuildResourcesCallCount()).Should(BeZero())
+					})
+
+					Context("when the build's inputs have already been determined", func() {
+						BeforeEach(func() {
+							determinedBuild := pendingBuild
+							determinedBuild.InputsDetermined = true
+
+							fakePipelineDB.GetNextPendingBuildReturns(determinedBuild, nil)
+							fakePipelineDB.GetBuildResourcesReturns(pendingInputs, nil, nil)
+						})
+
+						It("builds with them, without scanning or determining them again", func() {
+							Ω(fakePipelineDB.GetBuildResourcesCallCount()).Should(Equal(1))
+							Ω(fakePipelineDB.GetBuildResourcesArgsForCall(0)).Should(Equal(128))
+
+							Ω(fakeScanner.ScanCallCount()).Should(BeZero())
+							Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(BeZero())
+							Ω(fakePipelineDB.UseInputsForBuildCallCount()).Should(BeZero())
+
+							Ω(factory.CreateCallCount()).Should(Equal(1))
+							_, _, createInputs := factory.CreateArgsForCall(0)
+							Ω(createInputs).Should(Equal(pendingInputs))
+
+							Eventually(createdBuild.ResumeCallCount).Should(Equal(1))
+						})
+					})
+
 					Context("when scanning fails", func() {
 						disaster := errors.New("nope")
 
@@ -521,6 +1030,134 @@ var _ = Describe("Scheduler", func() {
 							Ω(buildID).Should(Equal(128))
 							Ω(err).Should(Equal(disaster))
 						})
+
+						Context("when the job has hooks to run on failure", func() {
+							erroredPlan := atc.Plan{
+								Error: &atc.ErrorPlan{
+									Message: "nope",
+								},
+							}
+
+							BeforeEach(func() {
+								job.Ensure = &atc.PlanConfig{
+									Task: "some-ensure",
+								}
+
+								factory.CreateErroredReturns(erroredPlan, nil)
+							})
+
+							It("saves the error and runs the hooks in a build which errors once they've finished", func() {
+								Ω(fakeBuildsDB.ErrorBuildCallCount()).Should(Equal(0))
+
+								Ω(fakeBuildsDB.SaveBuildEventCallCount()).Should(Equal(1))
+								buildID, savedEvent := fakeBuildsDB.SaveBuildEventArgsForCall(0)
+								Ω(buildID).Should(Equal(128))
+								Ω(savedEvent).Should(Equal(event.Error{
+									Message: "nope",
+								}))
+
+								Ω(factory.CreateErroredCallCount()).Should(Equal(1))
+								createJob, createResources, cause := factory.CreateErroredArgsForCall(0)
+								Ω(createJob).Should(Equal(job))
+								Ω(createResources).Should(Equal(resources))
+								Ω(cause).Should(Equal(disaster))
+
+								Ω(factory.CreateCallCount()).Should(Equal(0))
+
+								Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(1))
+								builtBuild, plan := fakeEngine.CreateBuildArgsForCall(0)
+								Ω(builtBuild).Should(Equal(pendingBuild))
+								Ω(plan).Should(Equal(erroredPlan))
+
+								Eventually(createdBuild.ResumeCallCount).Should(Equal(1))
+							})
+
+							Context("when creating the plan fails", func() {
+								BeforeEach(func() {
+									factory.CreateErroredReturns(atc.Plan{}, errors.New("oh no"))
+								})
+
+								It("errors the build without running the hooks", func() {
+									Ω(fakeBuildsDB.ErrorBuildCallCount()).Should(Equal(1))
+									Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(0))
+								})
+							})
+						})
+					})
+
+					Context("when determining the build's inputs fails", func() {
+						BeforeEach(func() {
+							fakePipelineDB.GetLatestInputVersionsReturns(nil, db.ErrNoVersions)
+
+							job.Failure = &atc.PlanConfig{
+								Task: "some-failure",
+							}
+						})
+
+						It("errors the build, running the job's hooks", func() {
+							Ω(fakePipelineDB.UseInputsForBuildCallCount()).Should(BeZero())
+
+							Ω(factory.CreateErroredCallCount()).Should(Equal(1))
+							_, _, cause := factory.CreateErroredArgsForCall(0)
+							Ω(cause).Should(Equal(db.ErrNoVersions))
+
+							Ω(factory.CreateCallCount()).Should(BeZero())
+
+							Eventually(createdBuild.ResumeCallCount).Should(Equal(1))
+						})
+
+						Context("when the job has no hooks", func() {
+							BeforeEach(func() {
+								job.Failure = nil
+							})
+
+							It("errors the build rather than leaving it pending", func() {
+								Ω(fakeBuildsDB.ErrorBuildCallCount()).Should(Equal(1))
+
+								buildID, err := fakeBuildsDB.ErrorBuildArgsForCall(0)
+								Ω(buildID).Should(Equal(128))
+								Ω(err).Should(Equal(db.ErrNoVersions))
+
+								Ω(factory.CreateErroredCallCount()).Should(BeZero())
+								Ω(fakeEngine.CreateBuildCallCount()).Should(BeZero())
+							})
+						})
+					})
+
+					Context("when saving the build's inputs fails", func() {
+						disaster := errors.New("nope")
+
+						BeforeEach(func() {
+							fakePipelineDB.UseInputsForBuildReturns(disaster)
+						})
+
+						It("errors the build", func() {
+							Ω(fakeBuildsDB.ErrorBuildCallCount()).Should(Equal(1))
+
+							buildID, err := fakeBuildsDB.ErrorBuildArgsForCall(0)
+							Ω(buildID).Should(Equal(128))
+							Ω(err).Should(Equal(disaster))
+
+							Ω(factory.CreateCallCount()).Should(BeZero())
+						})
+					})
+
+					Context("when creating the build's plan fails", func() {
+						disaster := errors.New("bad plan")
+
+						BeforeEach(func() {
+							factory.CreateReturns(atc.Plan{}, disaster)
+						})
+
+						It("errors the build rather than leaving it scheduled", func() {
+							Ω(fakeBuildsDB.ErrorBuildCallCount()).Should(Equal(1))
+
+							buildID, err := fakeBuildsDB.ErrorBuildArgsForCall(0)
+							Ω(buildID).Should(Equal(128))
+							Ω(err).Should(Equal(disaster))
+
+							Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(0))
+						})
 					})
 				})
 			})
@@ -671,4 +1308,277 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 	})
+
+	Describe("TriggerWithVersions", func() {
+		var (
+			chosen       []atc.JobBuildInput
+			ignorePassed bool
+
+			build      db.Build
+			triggerErr error
+		)
+
+		chosenInputs := []db.BuildInput{
+			{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-resource", Version: db.Version{"version": "1"},
+				},
+			},
+			{
+				Name: "some-other-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-other-resource", Version: db.Version{"version": "2"},
+				},
+			},
+		}
+
+		BeforeEach(func() {
+			chosen = []atc.JobBuildInput{
+				{Name: "some-input", Version: atc.Version{"version": "1"}},
+			}
+
+			ignorePassed = false
+
+			job.InputConfigs[0].Passed = []string{"some-upstream-job"}
+
+			fakePipelineDB.GetLatestInputVersionsReturns(chosenInputs, nil)
+			fakePipelineDB.CreateJobBuildWithInputsReturns(db.Build{ID: 128, Name: "42", InputsDetermined: true}, nil)
+		})
+
+		JustBeforeEach(func() {
+			build, triggerErr = scheduler.TriggerWithVersions(logger, job, resources, chosen, ignorePassed)
+		})
+
+		It("creates the build with its inputs determined with the chosen versions pinned", func() {
+			Ω(triggerErr).ShouldNot(HaveOccurred())
+			Ω(build).Should(Equal(db.Build{ID: 128, Name: "42", InputsDetermined: true}))
+
+			Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
+			jobName, inputs := fakePipelineDB.GetLatestInputVersionsArgsForCall(0)
+			Ω(jobName).Should(Equal("some-job"))
+			Ω(inputs).Should(Equal([]atc.JobInput{
+				{
+					Name:     "some-input",
+					Resource: "some-resource",
+					Passed:   []string{"some-upstream-job"},
+					Trigger:  true,
+					Version:  atc.VersionConfig(`{"version":"1"}`),
+				},
+				{
+					Name:     "some-other-input",
+					Resource: "some-other-resource",
+					Trigger:  true,
+				},
+			}))
+
+			Ω(fakePipelineDB.CreateJobBuildCallCount()).Should(BeZero())
+			Ω(fakePipelineDB.CreateJobBuildWithInputsCallCount()).Should(Equal(1))
+			jobName, usedInputs := fakePipelineDB.CreateJobBuildWithInputsArgsForCall(0)
+			Ω(jobName).Should(Equal("some-job"))
+			Ω(usedInputs).Should(Equal(chosenInputs))
+		})
+
+		It("schedules the build", func() {
+			Eventually(fakePipelineDB.ScheduleBuildCallCount).Should(Equal(1))
+			scheduledBuildID, _ := fakePipelineDB.ScheduleBuildArgsForCall(0)
+			Ω(scheduledBuildID).Should(Equal(128))
+		})
+
+		Context("when the build can be scheduled", func() {
+			BeforeEach(func() {
+				fakePipelineDB.ScheduleBuildReturns(true, nil)
+				fakePipelineDB.GetBuildResourcesReturns(chosenInputs, nil, nil)
+			})
+
+			It("plans it with the inputs it was created with, without determining them again", func() {
+				Eventually(factory.CreateCallCount).Should(Equal(1))
+				_, _, createInputs := factory.CreateArgsForCall(0)
+				Ω(createInputs).Should(Equal(chosenInputs))
+
+				Ω(fakePipelineDB.GetBuildResourcesArgsForCall(0)).Should(Equal(128))
+				Ω(fakeScanner.ScanCallCount()).Should(BeZero())
+				Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
+				Ω(fakePipelineDB.UseInputsForBuildCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when creating the build fails", func() {
+			disaster := errors.New("oh no!")
+
+			BeforeEach(func() {
+				fakePipelineDB.CreateJobBuildWithInputsReturns(db.Build{}, disaster)
+			})
+
+			It("returns the error without scheduling anything", func() {
+				Ω(triggerErr).Should(Equal(disaster))
+				Consistently(fakePipelineDB.ScheduleBuildCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when ignoring passed constraints", func() {
+			BeforeEach(func() {
+				ignorePassed = true
+			})
+
+			It("does not constrain the chosen inputs", func() {
+				Ω(triggerErr).ShouldNot(HaveOccurred())
+
+				_, inputs := fakePipelineDB.GetLatestInputVersionsArgsForCall(0)
+				Ω(inputs[0].Passed).Should(BeEmpty())
+			})
+		})
+
+		Context("when a version is chosen by its ID", func() {
+			BeforeEach(func() {
+				chosen = []atc.JobBuildInput{
+					{Name: "some-input", VersionID: 42},
+				}
+			})
+
+			Context("when the version is of the input's resource", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetVersionedResourceReturns(db.SavedVersionedResource{
+						ID: 42,
+						VersionedResource: db.VersionedResource{
+							Resource: "some-resource",
+							Version:  db.Version{"version": "3"},
+						},
+					}, nil)
+				})
+
+				It("pins the input to the version", func() {
+					Ω(triggerErr).ShouldNot(HaveOccurred())
+
+					Ω(fakePipelineDB.GetVersionedResourceArgsForCall(0)).Should(Equal(42))
+
+					_, inputs := fakePipelineDB.GetLatestInputVersionsArgsForCall(0)
+					Ω(inputs[0].Version).Should(Equal(atc.VersionConfig(`{"version":"3"}`)))
+				})
+			})
+
+			Context("when the version is of another resource", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetVersionedResourceReturns(db.SavedVersionedResource{
+						ID: 42,
+						VersionedResource: db.VersionedResource{
+							Resource: "some-other-resource",
+							Version:  db.Version{"version": "3"},
+						},
+					}, nil)
+				})
+
+				It("returns an InputVersionsError without creating a build", func() {
+					Ω(triggerErr).Should(BeAssignableToTypeOf(InputVersionsError{}))
+					Ω(fakePipelineDB.CreateJobBuildWithInputsCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetVersionedResourceReturns(db.SavedVersionedResource{}, db.ErrNoVersions)
+				})
+
+				It("returns an InputVersionsError without creating a build", func() {
+					Ω(triggerErr).Should(BeAssignableToTypeOf(InputVersionsError{}))
+					Ω(fakePipelineDB.CreateJobBuildWithInputsCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("when the chosen input does not exist", func() {
+			BeforeEach(func() {
+				chosen = []atc.JobBuildInput{
+					{Name: "bogus-input", Version: atc.Version{"version": "1"}},
+				}
+			})
+
+			It("returns an InputVersionsError without creating a build", func() {
+				Ω(triggerErr).Should(Equal(InputVersionsError{Reason: "job has no input named 'bogus-input'"}))
+				Ω(fakePipelineDB.CreateJobBuildWithInputsCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when the chosen versions do not satisfy the passed constraints", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetLatestInputVersionsReturns(nil, db.ErrNoVersions)
+			})
+
+			It("returns an InputVersionsError without creating a build", func() {
+				Ω(triggerErr).Should(BeAssignableToTypeOf(InputVersionsError{}))
+				Ω(fakePipelineDB.CreateJobBuildWithInputsCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when no versions are chosen", func() {
+			BeforeEach(func() {
+				chosen = nil
+			})
+
+			It("triggers a build with the latest inputs", func() {
+				Ω(triggerErr).ShouldNot(HaveOccurred())
+
+				Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(BeZero())
+				Ω(fakePipelineDB.UseInputsForBuildCallCount()).Should(BeZero())
+				Ω(fakePipelineDB.CreateJobBuildCallCount()).Should(Equal(1))
+			})
+		})
+	})
+
+	Describe("RerunBuild", func() {
+		var (
+			build    db.Build
+			rerunErr error
+		)
+
+		rerunInputs := []db.BuildInput{
+			{
+				Name: "some-input",
+				VersionedResource: db.VersionedResource{
+					Resource: "some-resource", Version: db.Version{"version": "1"},
+				},
+			},
+		}
+
+		BeforeEach(func() {
+			fakePipelineDB.CreateJobRerunBuildReturns(db.Build{ID: 128, Name: "43", RerunOf: 42, InputsDetermined: true}, nil)
+			fakePipelineDB.GetBuildResourcesReturns(rerunInputs, nil, nil)
+			fakePipelineDB.ScheduleBuildReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			build, rerunErr = scheduler.RerunBuild(logger, 42, job, resources)
+		})
+
+		It("creates a rerun of the build", func() {
+			Ω(rerunErr).ShouldNot(HaveOccurred())
+			Ω(build).Should(Equal(db.Build{ID: 128, Name: "43", RerunOf: 42, InputsDetermined: true}))
+
+			Ω(fakePipelineDB.CreateJobRerunBuildCallCount()).Should(Equal(1))
+			Ω(fakePipelineDB.CreateJobRerunBuildArgsForCall(0)).Should(Equal(42))
+		})
+
+		It("plans the rerun with the inputs copied from the original build", func() {
+			Eventually(factory.CreateCallCount).Should(Equal(1))
+			_, _, createInputs := factory.CreateArgsForCall(0)
+			Ω(createInputs).Should(Equal(rerunInputs))
+
+			Ω(fakePipelineDB.GetBuildResourcesArgsForCall(0)).Should(Equal(128))
+			Ω(fakeScanner.ScanCallCount()).Should(BeZero())
+			Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(BeZero())
+		})
+
+		Context("when creating the rerun fails", func() {
+			disaster := errors.New("oh no!")
+
+			BeforeEach(func() {
+				fakePipelineDB.CreateJobRerunBuildReturns(db.Build{}, disaster)
+			})
+
+			It("returns the error without scheduling anything", func() {
+				Ω(rerunErr).Should(Equal(disaster))
+				Consistently(fakePipelineDB.ScheduleBuildCallCount).Should(BeZero())
+			})
+		})
+	})
 })
@@ -11,6 +11,7 @@ type Build struct {
 	Status  string `json:"status"`
 	JobName string `json:"job_name"`
 	URL     string `json:"url"`
+	RerunOf int    `json:"rerun_of,omitempty"`
 }
 
 type BuildStatus string
@@ -34,6 +35,9 @@ type TaskConfig struct {
 	// platform, this may or may not be required (e.g. Windows/OS X vs. Linux).
 	Image string `json:"image,omitempty"   yaml:"image,omitempty"`
 
+	// Optional resource to fetch the image from, in place of a static image.
+	ImageResource *TaskImageConfig `json:"image_resource,omitempty" yaml:"image_resource,omitempty" mapstructure:"image_resource"`
+
 	// Parameters to pass to the task via environment variables.
 	Params map[string]string `json:"params,omitempty"  yaml:"params,omitempty"`
 
@@ -42,6 +46,17 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) inputs required by the task.
 	Inputs []TaskInputConfig `json:"inputs,omitempty"  yaml:"inputs,omitempty"`
+
+	// The set of directories produced by the task, each of which is made
+	// available to later steps as an artifact of the same name.
+	Outputs []TaskOutputConfig `json:"outputs,omitempty"  yaml:"outputs,omitempty"`
+
+	// The set of directories to keep between builds of the job on the same
+	// worker, e.g. dependency caches.
+	Caches []CacheConfig `json:"caches,omitempty"  yaml:"caches,omitempty"`
+
+	// Resource limits to apply to the task's container.
+	ContainerLimits ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
 }
 
 func (a TaskConfig) Merge(b TaskConfig) TaskConfig {
@@ -51,6 +66,12 @@ func (a TaskConfig) Merge(b TaskConfig) TaskConfig {
 
 	if b.Image != "" {
 		a.Image = b.Image
+		a.ImageResource = nil
+	}
+
+	if b.ImageResource != nil {
+		a.Image = ""
+		a.ImageResource = b.ImageResource
 	}
 
 	if len(a.Params) > 0 {
@@ -94,6 +115,16 @@ func (a TaskConfig) Merge(b TaskConfig) TaskConfig {
 		a.Inputs = b.Inputs
 	}
 
+	if len(b.Outputs) != 0 {
+		a.Outputs = b.Outputs
+	}
+
+	if len(b.Caches) != 0 {
+		a.Caches = b.Caches
+	}
+
+	a.ContainerLimits = b.ContainerLimits.WithDefaults(a.ContainerLimits)
+
 	if b.Run.Path != "" {
 		a.Run = b.Run
 	}
@@ -115,6 +146,52 @@ func (config TaskConfig) Validate() error {
 		invalid = true
 	}
 
+	if config.Image != "" && config.ImageResource != nil {
+		messages = append(messages, "  specifies both 'image' and 'image_resource'")
+		invalid = true
+	}
+
+	if config.ImageResource != nil && config.ImageResource.Type == "" {
+		messages = append(messages, "  missing 'type' of 'image_resource'")
+		invalid = true
+	}
+
+	for _, cache := range config.Caches {
+		if cache.Path == "" {
+			messages = append(messages, "  cache has no path")
+			invalid = true
+		} else if strings.HasPrefix(cache.Path, "/") {
+			messages = append(messages, fmt.Sprintf("  cache path '%s' must be relative to the task's working directory", cache.Path))
+			invalid = true
+		}
+	}
+
+	inputNames := map[string]bool{}
+	for _, input := range config.Inputs {
+		inputNames[input.Name] = true
+	}
+
+	outputNames := map[string]bool{}
+	for _, output := range config.Outputs {
+		if output.Name == "" {
+			messages = append(messages, "  output has no name")
+			invalid = true
+			continue
+		}
+
+		if inputNames[output.Name] {
+			messages = append(messages, fmt.Sprintf("  output '%s' has the same name as an input", output.Name))
+			invalid = true
+		}
+
+		if outputNames[output.Name] {
+			messages = append(messages, fmt.Sprintf("  output '%s' is declared more than once", output.Name))
+			invalid = true
+		}
+
+		outputNames[output.Name] = true
+	}
+
 	if invalid {
 		return fmt.Errorf(strings.Join(messages, "\n"))
 	}
@@ -132,6 +209,97 @@ type TaskInputConfig struct {
 	Path string `json:"path,omitempty" yaml:"path"`
 }
 
+// TaskImageConfig is a resource from which a task's image is fetched.
+//
+// The latest version of the resource is chosen when the task first runs, and
+// is recorded so that reruns of the build fetch the same version. Its source
+// may refer to credentials, like the source of any other resource. What the
+// resource fetches must contain a file named rootfs_uri at its top, holding
+// the URI of the rootfs to run the task in (e.g. docker:///some/image#tag).
+type TaskImageConfig struct {
+	Type   string `json:"type" yaml:"type"`
+	Source Source `json:"source" yaml:"source"`
+}
+
+// ContainerLimits are the resource limits of a container. A zero value means
+// the resource is not limited.
+type ContainerLimits struct {
+	// Memory limit, in bytes.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// CPU limit, in shares relative to other containers on the worker.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+
+	// Disk limit, in bytes.
+	Disk uint64 `json:"disk,omitempty" yaml:"disk,omitempty"`
+}
+
+// WithDefaults fills in any unset limits from the given defaults.
+func (limits ContainerLimits) WithDefaults(defaults ContainerLimits) ContainerLimits {
+	if limits.Memory == 0 {
+		limits.Memory = defaults.Memory
+	}
+
+	if limits.CPU == 0 {
+		limits.CPU = defaults.CPU
+	}
+
+	if limits.Disk == 0 {
+		limits.Disk = defaults.Disk
+	}
+
+	return limits
+}
+
+// Exceeding returns a description of each limit that is greater than the
+// given maximum. Limits with no maximum are never exceeded.
+func (limits ContainerLimits) Exceeding(max ContainerLimits) []string {
+	exceeding := []string{}
+
+	if max.Memory != 0 && limits.Memory > max.Memory {
+		exceeding = append(exceeding, fmt.Sprintf("memory (%d > %d)", limits.Memory, max.Memory))
+	}
+
+	if max.CPU != 0 && limits.CPU > max.CPU {
+		exceeding = append(exceeding, fmt.Sprintf("cpu (%d > %d)", limits.CPU, max.CPU))
+	}
+
+	if max.Disk != 0 && limits.Disk > max.Disk {
+		exceeding = append(exceeding, fmt.Sprintf("disk (%d > %d)", limits.Disk, max.Disk))
+	}
+
+	return exceeding
+}
+
+// Unlimited returns the name of each limit that is unset although the given
+// maximum sets it, as an unset limit leaves the resource unlimited.
+func (limits ContainerLimits) Unlimited(max ContainerLimits) []string {
+	unlimited := []string{}
+
+	if max.Memory != 0 && limits.Memory == 0 {
+		unlimited = append(unlimited, "memory")
+	}
+
+	if max.CPU != 0 && limits.CPU == 0 {
+		unlimited = append(unlimited, "cpu")
+	}
+
+	if max.Disk != 0 && limits.Disk == 0 {
+		unlimited = append(unlimited, "disk")
+	}
+
+	return unlimited
+}
+
+type TaskOutputConfig struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path,omitempty" yaml:"path"`
+}
+
+type CacheConfig struct {
+	Path string `json:"path" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
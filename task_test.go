@@ -41,6 +41,96 @@ var _ = Describe("TaskConfig", func() {
 				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("missing path to executable to run")))
 			})
 		})
+
+		Context("when both image and image_resource are specified", func() {
+			BeforeEach(func() {
+				invalidConfig.Image = "some-image"
+				invalidConfig.ImageResource = &TaskImageConfig{
+					Type: "docker-image",
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("specifies both 'image' and 'image_resource'")))
+			})
+		})
+
+		Context("when image_resource has no type", func() {
+			BeforeEach(func() {
+				invalidConfig.ImageResource = &TaskImageConfig{
+					Source: Source{"repository": "some/image"},
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("missing 'type' of 'image_resource'")))
+			})
+		})
+
+		Context("when a cache has no path", func() {
+			BeforeEach(func() {
+				invalidConfig.Caches = []CacheConfig{
+					{Path: ""},
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("cache has no path")))
+			})
+		})
+
+		Context("when a cache has an absolute path", func() {
+			BeforeEach(func() {
+				invalidConfig.Caches = []CacheConfig{
+					{Path: "/root/.m2"},
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("cache path '/root/.m2' must be relative to the task's working directory")))
+			})
+		})
+
+		Context("when an output has the same name as an input", func() {
+			BeforeEach(func() {
+				invalidConfig.Inputs = []TaskInputConfig{
+					{Name: "some-name"},
+				}
+
+				invalidConfig.Outputs = []TaskOutputConfig{
+					{Name: "some-name"},
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("output 'some-name' has the same name as an input")))
+			})
+		})
+
+		Context("when an output is declared more than once", func() {
+			BeforeEach(func() {
+				invalidConfig.Outputs = []TaskOutputConfig{
+					{Name: "some-name", Path: "some-path"},
+					{Name: "some-name", Path: "some-other-path"},
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("output 'some-name' is declared more than once")))
+			})
+		})
+
+		Context("when an output has no name", func() {
+			BeforeEach(func() {
+				invalidConfig.Outputs = []TaskOutputConfig{
+					{Path: "some-path"},
+				}
+			})
+
+			It("returns an error", func() {
+				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("output has no name")))
+			})
+		})
 	})
 
 	Describe("merging", func() {
@@ -94,6 +184,34 @@ var _ = Describe("TaskConfig", func() {
 			}))
 		})
 
+		It("overrides the image with an image resource", func() {
+			Ω(TaskConfig{
+				Image: "some-image",
+			}.Merge(TaskConfig{
+				ImageResource: &TaskImageConfig{
+					Type:   "docker-image",
+					Source: Source{"repository": "some/image"},
+				},
+			})).Should(Equal(TaskConfig{
+				ImageResource: &TaskImageConfig{
+					Type:   "docker-image",
+					Source: Source{"repository": "some/image"},
+				},
+			}))
+		})
+
+		It("overrides the image resource with an image", func() {
+			Ω(TaskConfig{
+				ImageResource: &TaskImageConfig{
+					Type: "docker-image",
+				},
+			}.Merge(TaskConfig{
+				Image: "better-image",
+			})).Should(Equal(TaskConfig{
+				Image: "better-image",
+			}))
+		})
+
 		It("overrides the run config", func() {
 			Ω(TaskConfig{
 				Run: TaskRunConfig{
@@ -149,5 +267,91 @@ var _ = Describe("TaskConfig", func() {
 				},
 			}))
 		})
+
+		It("merges container limits", func() {
+			Ω(TaskConfig{
+				ContainerLimits: ContainerLimits{
+					Memory: 1024,
+					CPU:    512,
+				},
+			}.Merge(TaskConfig{
+				ContainerLimits: ContainerLimits{
+					Memory: 2048,
+					Disk:   4096,
+				},
+			})).Should(Equal(TaskConfig{
+				ContainerLimits: ContainerLimits{
+					Memory: 2048,
+					CPU:    512,
+					Disk:   4096,
+				},
+			}))
+		})
+
+		It("overrides cache configuration", func() {
+			Ω(TaskConfig{
+				Caches: []CacheConfig{
+					{Path: "some-cache"},
+				},
+			}.Merge(TaskConfig{
+				Caches: []CacheConfig{
+					{Path: "another-cache"},
+				},
+			})).Should(Equal(TaskConfig{
+				Caches: []CacheConfig{
+					{Path: "another-cache"},
+				},
+			}))
+		})
+
+		It("overrides output configuration", func() {
+			Ω(TaskConfig{
+				Outputs: []TaskOutputConfig{
+					{Name: "some-output", Path: "some-destination"},
+				},
+			}.Merge(TaskConfig{
+				Outputs: []TaskOutputConfig{
+					{Name: "another-output"},
+				},
+			})).Should(Equal(TaskConfig{
+				Outputs: []TaskOutputConfig{
+					{Name: "another-output"},
+				},
+			}))
+		})
+	})
+
+	Describe("ContainerLimits", func() {
+		It("fills in unset limits from defaults", func() {
+			Ω(ContainerLimits{
+				Memory: 1024,
+			}.WithDefaults(ContainerLimits{
+				Memory: 2048,
+				CPU:    512,
+			})).Should(Equal(ContainerLimits{
+				Memory: 1024,
+				CPU:    512,
+			}))
+		})
+
+		It("describes the limits which exceed the maximum", func() {
+			Ω(ContainerLimits{
+				Memory: 2048,
+				CPU:    512,
+				Disk:   4096,
+			}.Exceeding(ContainerLimits{
+				Memory: 1024,
+				CPU:    1024,
+			})).Should(Equal([]string{"memory (2048 > 1024)"}))
+		})
+
+		It("names the limits which are unset although the maximum sets them", func() {
+			Ω(ContainerLimits{
+				Memory: 2048,
+			}.Unlimited(ContainerLimits{
+				Memory: 4096,
+				CPU:    1024,
+			})).Should(Equal([]string{"cpu"}))
+		})
 	})
 })
@@ -0,0 +1,194 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Variables are the values substituted for {{placeholder}} references in a
+// pipeline config.
+type Variables map[string]interface{}
+
+var placeholderRegexp = regexp.MustCompile(`\{\{([-\w\p{L}]+)\}\}`)
+
+type UnresolvedVar struct {
+	Name     string
+	Location string
+}
+
+type UnresolvedVarsError struct {
+	Vars []UnresolvedVar
+}
+
+func (err UnresolvedVarsError) Error() string {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintln(msg, "unresolved template variables:")
+	for _, v := range err.Vars {
+		fmt.Fprintf(msg, "  - %s (at %s)\n", v.Name, v.Location)
+	}
+
+	return msg.String()
+}
+
+// Evaluate walks a decoded config structure and substitutes every
+// {{placeholder}} it finds in a string value.
+//
+// A string consisting solely of a placeholder is replaced by the variable's
+// value as-is, so that e.g. `trigger: "{{trigger}}"` can become a boolean.
+// Placeholders embedded in a larger string are replaced by their formatted
+// value.
+//
+// If any placeholders have no corresponding variable, an UnresolvedVarsError
+// is returned listing each of them along with where it occurred.
+func Evaluate(structure interface{}, vars Variables) (interface{}, error) {
+	evaluator := &evaluator{vars: vars}
+
+	evaluated, err := evaluator.evaluate("", structure)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(evaluator.unresolved) > 0 {
+		sort.Sort(byLocation(evaluator.unresolved))
+		return nil, UnresolvedVarsError{Vars: evaluator.unresolved}
+	}
+
+	return evaluated, nil
+}
+
+type evaluator struct {
+	vars       Variables
+	unresolved []UnresolvedVar
+}
+
+func (e *evaluator) evaluate(location string, val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		evaluated := make(map[string]interface{}, len(v))
+
+		for key, sub := range v {
+			subVal, err := e.evaluate(childLocation(location, key), sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = subVal
+		}
+
+		return evaluated, nil
+
+	case map[interface{}]interface{}:
+		evaluated := make(map[interface{}]interface{}, len(v))
+
+		for key, sub := range v {
+			subVal, err := e.evaluate(childLocation(location, fmt.Sprintf("%v", key)), sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[key] = subVal
+		}
+
+		return evaluated, nil
+
+	case []interface{}:
+		evaluated := make([]interface{}, len(v))
+
+		for i, sub := range v {
+			subVal, err := e.evaluate(fmt.Sprintf("%s[%d]", location, i), sub)
+			if err != nil {
+				return nil, err
+			}
+
+			evaluated[i] = subVal
+		}
+
+		return evaluated, nil
+
+	case string:
+		return e.evaluateString(location, v)
+
+	default:
+		return val, nil
+	}
+}
+
+func (e *evaluator) evaluateString(location string, str string) (interface{}, error) {
+	if match := placeholderRegexp.FindStringSubmatch(str); match != nil && match[0] == str {
+		value, found := e.vars[match[1]]
+		if !found {
+			e.unresolved = append(e.unresolved, UnresolvedVar{Name: match[1], Location: location})
+			return str, nil
+		}
+
+		return value, nil
+	}
+
+	var formatErr error
+
+	evaluated := placeholderRegexp.ReplaceAllStringFunc(str, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+
+		value, found := e.vars[name]
+		if !found {
+			e.unresolved = append(e.unresolved, UnresolvedVar{Name: name, Location: location})
+			return placeholder
+		}
+
+		formatted, err := FormatValue(value)
+		if err != nil {
+			formatErr = fmt.Errorf("cannot interpolate '%s' at %s: %s", name, location, err)
+			return placeholder
+		}
+
+		return formatted
+	})
+
+	if formatErr != nil {
+		return nil, formatErr
+	}
+
+	return evaluated, nil
+}
+
+// FormatValue renders a value for interpolation into a larger string. Strings
+// are used as-is, scalars are formatted, and anything else becomes JSON.
+func FormatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, int, int64, float64, nil:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		payload, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		return string(payload), nil
+	}
+}
+
+func childLocation(location string, key string) string {
+	if location == "" {
+		return key
+	}
+
+	return location + "." + key
+}
+
+type byLocation []UnresolvedVar
+
+func (vs byLocation) Len() int      { return len(vs) }
+func (vs byLocation) Swap(i, j int) { vs[i], vs[j] = vs[j], vs[i] }
+func (vs byLocation) Less(i, j int) bool {
+	if vs[i].Location == vs[j].Location {
+		return vs[i].Name < vs[j].Name
+	}
+
+	return vs[i].Location < vs[j].Location
+}
@@ -0,0 +1,115 @@
+package template_test
+
+import (
+	. "github.com/concourse/atc/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evaluate", func() {
+	var (
+		structure interface{}
+		vars      Variables
+
+		evaluated   interface{}
+		evaluateErr error
+	)
+
+	BeforeEach(func() {
+		vars = Variables{
+			"branch":  "master",
+			"trigger": true,
+			"source": map[string]interface{}{
+				"uri": "https://example.com/repo.git",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		evaluated, evaluateErr = Evaluate(structure, vars)
+	})
+
+	Context("when the structure has no placeholders", func() {
+		BeforeEach(func() {
+			structure = map[string]interface{}{
+				"resources": []interface{}{
+					map[string]interface{}{"name": "some-resource"},
+				},
+			}
+		})
+
+		It("returns it unchanged", func() {
+			Ω(evaluateErr).ShouldNot(HaveOccurred())
+			Ω(evaluated).Should(Equal(structure))
+		})
+	})
+
+	Context("when a value consists solely of a placeholder", func() {
+		BeforeEach(func() {
+			structure = map[interface{}]interface{}{
+				"trigger": "{{trigger}}",
+				"source":  "{{source}}",
+			}
+		})
+
+		It("replaces it with the variable's value, preserving its type", func() {
+			Ω(evaluateErr).ShouldNot(HaveOccurred())
+			Ω(evaluated).Should(Equal(map[interface{}]interface{}{
+				"trigger": true,
+				"source": map[string]interface{}{
+					"uri": "https://example.com/repo.git",
+				},
+			}))
+		})
+	})
+
+	Context("when placeholders are embedded in a string", func() {
+		BeforeEach(func() {
+			structure = []interface{}{
+				"refs/heads/{{branch}}",
+				"trigger={{trigger}}",
+			}
+		})
+
+		It("substitutes their formatted values", func() {
+			Ω(evaluateErr).ShouldNot(HaveOccurred())
+			Ω(evaluated).Should(Equal([]interface{}{
+				"refs/heads/master",
+				"trigger=true",
+			}))
+		})
+	})
+
+	Context("when placeholders are not provided", func() {
+		BeforeEach(func() {
+			structure = map[string]interface{}{
+				"jobs": []interface{}{
+					map[string]interface{}{
+						"name": "{{job-name}}",
+						"plan": []interface{}{
+							map[string]interface{}{
+								"get":    "repo",
+								"params": map[string]interface{}{"ref": "{{branch}}-{{ref}}"},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("returns an error listing each missing var and where it was found", func() {
+			Ω(evaluateErr).Should(Equal(UnresolvedVarsError{
+				Vars: []UnresolvedVar{
+					{Name: "job-name", Location: "jobs[0].name"},
+					{Name: "ref", Location: "jobs[0].plan[0].params.ref"},
+				},
+			}))
+		})
+
+		It("names them in the error message", func() {
+			Ω(evaluateErr.Error()).Should(ContainSubstring("job-name (at jobs[0].name)"))
+			Ω(evaluateErr.Error()).Should(ContainSubstring("ref (at jobs[0].plan[0].params.ref)"))
+		})
+	})
+})
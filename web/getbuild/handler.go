@@ -31,6 +31,7 @@ type TemplateData struct {
 	Builds []db.Build
 
 	Build        db.Build
+	RerunOf      *db.Build
 	Inputs       []db.BuildInput
 	Outputs      []db.BuildOutput
 	PipelineName string
@@ -89,6 +90,16 @@ func (server *server) GetBuild(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
+		var rerunOf *db.Build
+		if build.RerunOf != 0 {
+			for i, b := range bs {
+				if b.ID == build.RerunOf {
+					rerunOf = &bs[i]
+					break
+				}
+			}
+		}
+
 		templateData := TemplateData{
 			GroupStates: group.States(config.Groups, func(g atc.GroupConfig) bool {
 				for _, groupJob := range g.Jobs {
@@ -104,6 +115,7 @@ func (server *server) GetBuild(pipelineDB db.PipelineDB) http.Handler {
 			Builds: bs,
 
 			Build:        build,
+			RerunOf:      rerunOf,
 			Inputs:       inputs,
 			Outputs:      outputs,
 			PipelineName: pipelineDB.GetPipelineName(),
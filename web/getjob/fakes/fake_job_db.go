@@ -61,6 +61,46 @@ type FakeJobDB struct {
 		result2 []db.BuildOutput
 		result3 error
 	}
+	GetResourceStub        func(string) (db.SavedResource, error)
+	getResourceMutex       sync.RWMutex
+	getResourceArgsForCall []struct {
+		arg1 string
+	}
+	getResourceReturns struct {
+		result1 db.SavedResource
+		result2 error
+	}
+	GetResourceHistoryCursorStub        func(string, int, bool, int) ([]*db.VersionHistory, bool, error)
+	getResourceHistoryCursorMutex       sync.RWMutex
+	getResourceHistoryCursorArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 bool
+		arg4 int
+	}
+	getResourceHistoryCursorReturns struct {
+		result1 []*db.VersionHistory
+		result2 bool
+		result3 error
+	}
+	GetResourceHistoryMaxIDStub        func(int) (int, error)
+	getResourceHistoryMaxIDMutex       sync.RWMutex
+	getResourceHistoryMaxIDArgsForCall []struct {
+		arg1 int
+	}
+	getResourceHistoryMaxIDReturns struct {
+		result1 int
+		result2 error
+	}
+	GetJobSchedulingStatusStub        func(job string) (db.SchedulingStatus, error)
+	getJobSchedulingStatusMutex       sync.RWMutex
+	getJobSchedulingStatusArgsForCall []struct {
+		job string
+	}
+	getJobSchedulingStatusReturns struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}
 }
 
 func (fake *FakeJobDB) GetConfig() (atc.Config, db.ConfigVersion, error) {
@@ -246,4 +286,140 @@ func (fake *FakeJobDB) GetBuildResourcesReturns(result1 []db.BuildInput, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJobDB) GetResource(arg1 string) (db.SavedResource, error) {
+	fake.getResourceMutex.Lock()
+	fake.getResourceArgsForCall = append(fake.getResourceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getResourceMutex.Unlock()
+	if fake.GetResourceStub != nil {
+		return fake.GetResourceStub(arg1)
+	} else {
+		return fake.getResourceReturns.result1, fake.getResourceReturns.result2
+	}
+}
+
+func (fake *FakeJobDB) GetResourceCallCount() int {
+	fake.getResourceMutex.RLock()
+	defer fake.getResourceMutex.RUnlock()
+	return len(fake.getResourceArgsForCall)
+}
+
+func (fake *FakeJobDB) GetResourceArgsForCall(i int) string {
+	fake.getResourceMutex.RLock()
+	defer fake.getResourceMutex.RUnlock()
+	return fake.getResourceArgsForCall[i].arg1
+}
+
+func (fake *FakeJobDB) GetResourceReturns(result1 db.SavedResource, result2 error) {
+	fake.GetResourceStub = nil
+	fake.getResourceReturns = struct {
+		result1 db.SavedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobDB) GetResourceHistoryCursor(arg1 string, arg2 int, arg3 bool, arg4 int) ([]*db.VersionHistory, bool, error) {
+	fake.getResourceHistoryCursorMutex.Lock()
+	fake.getResourceHistoryCursorArgsForCall = append(fake.getResourceHistoryCursorArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 bool
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	fake.getResourceHistoryCursorMutex.Unlock()
+	if fake.GetResourceHistoryCursorStub != nil {
+		return fake.GetResourceHistoryCursorStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.getResourceHistoryCursorReturns.result1, fake.getResourceHistoryCursorReturns.result2, fake.getResourceHistoryCursorReturns.result3
+	}
+}
+
+func (fake *FakeJobDB) GetResourceHistoryCursorCallCount() int {
+	fake.getResourceHistoryCursorMutex.RLock()
+	defer fake.getResourceHistoryCursorMutex.RUnlock()
+	return len(fake.getResourceHistoryCursorArgsForCall)
+}
+
+func (fake *FakeJobDB) GetResourceHistoryCursorArgsForCall(i int) (string, int, bool, int) {
+	fake.getResourceHistoryCursorMutex.RLock()
+	defer fake.getResourceHistoryCursorMutex.RUnlock()
+	return fake.getResourceHistoryCursorArgsForCall[i].arg1, fake.getResourceHistoryCursorArgsForCall[i].arg2, fake.getResourceHistoryCursorArgsForCall[i].arg3, fake.getResourceHistoryCursorArgsForCall[i].arg4
+}
+
+func (fake *FakeJobDB) GetResourceHistoryCursorReturns(result1 []*db.VersionHistory, result2 bool, result3 error) {
+	fake.GetResourceHistoryCursorStub = nil
+	fake.getResourceHistoryCursorReturns = struct {
+		result1 []*db.VersionHistory
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJobDB) GetResourceHistoryMaxID(arg1 int) (int, error) {
+	fake.getResourceHistoryMaxIDMutex.Lock()
+	fake.getResourceHistoryMaxIDArgsForCall = append(fake.getResourceHistoryMaxIDArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.getResourceHistoryMaxIDMutex.Unlock()
+	if fake.GetResourceHistoryMaxIDStub != nil {
+		return fake.GetResourceHistoryMaxIDStub(arg1)
+	} else {
+		return fake.getResourceHistoryMaxIDReturns.result1, fake.getResourceHistoryMaxIDReturns.result2
+	}
+}
+
+func (fake *FakeJobDB) GetResourceHistoryMaxIDCallCount() int {
+	fake.getResourceHistoryMaxIDMutex.RLock()
+	defer fake.getResourceHistoryMaxIDMutex.RUnlock()
+	return len(fake.getResourceHistoryMaxIDArgsForCall)
+}
+
+func (fake *FakeJobDB) GetResourceHistoryMaxIDArgsForCall(i int) int {
+	fake.getResourceHistoryMaxIDMutex.RLock()
+	defer fake.getResourceHistoryMaxIDMutex.RUnlock()
+	return fake.getResourceHistoryMaxIDArgsForCall[i].arg1
+}
+
+func (fake *FakeJobDB) GetResourceHistoryMaxIDReturns(result1 int, result2 error) {
+	fake.GetResourceHistoryMaxIDStub = nil
+	fake.getResourceHistoryMaxIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobDB) GetJobSchedulingStatus(job string) (db.SchedulingStatus, error) {
+	fake.getJobSchedulingStatusMutex.Lock()
+	fake.getJobSchedulingStatusArgsForCall = append(fake.getJobSchedulingStatusArgsForCall, struct {
+		job string
+	}{job})
+	fake.getJobSchedulingStatusMutex.Unlock()
+	if fake.GetJobSchedulingStatusStub != nil {
+		return fake.GetJobSchedulingStatusStub(job)
+	} else {
+		return fake.getJobSchedulingStatusReturns.result1, fake.getJobSchedulingStatusReturns.result2
+	}
+}
+
+func (fake *FakeJobDB) GetJobSchedulingStatusCallCount() int {
+	fake.getJobSchedulingStatusMutex.RLock()
+	defer fake.getJobSchedulingStatusMutex.RUnlock()
+	return len(fake.getJobSchedulingStatusArgsForCall)
+}
+
+func (fake *FakeJobDB) GetJobSchedulingStatusArgsForCall(i int) string {
+	fake.getJobSchedulingStatusMutex.RLock()
+	defer fake.getJobSchedulingStatusMutex.RUnlock()
+	return fake.getJobSchedulingStatusArgsForCall[i].job
+}
+
+func (fake *FakeJobDB) GetJobSchedulingStatusReturns(result1 db.SchedulingStatus, result2 error) {
+	fake.GetJobSchedulingStatusStub = nil
+	fake.getJobSchedulingStatusReturns = struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}{result1, result2}
+}
+
 var _ getjob.JobDB = new(FakeJobDB)
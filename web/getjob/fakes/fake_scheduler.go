@@ -0,0 +1,61 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/web/getjob"
+)
+
+type FakeScheduler struct {
+	ExplainSchedulingStatusStub        func(atc.JobConfig, atc.ResourceConfigs, db.SchedulingStatus) (db.SchedulingStatus, error)
+	explainSchedulingStatusMutex       sync.RWMutex
+	explainSchedulingStatusArgsForCall []struct {
+		arg1 atc.JobConfig
+		arg2 atc.ResourceConfigs
+		arg3 db.SchedulingStatus
+	}
+	explainSchedulingStatusReturns struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatus(arg1 atc.JobConfig, arg2 atc.ResourceConfigs, arg3 db.SchedulingStatus) (db.SchedulingStatus, error) {
+	fake.explainSchedulingStatusMutex.Lock()
+	fake.explainSchedulingStatusArgsForCall = append(fake.explainSchedulingStatusArgsForCall, struct {
+		arg1 atc.JobConfig
+		arg2 atc.ResourceConfigs
+		arg3 db.SchedulingStatus
+	}{arg1, arg2, arg3})
+	fake.explainSchedulingStatusMutex.Unlock()
+	if fake.ExplainSchedulingStatusStub != nil {
+		return fake.ExplainSchedulingStatusStub(arg1, arg2, arg3)
+	} else {
+		return fake.explainSchedulingStatusReturns.result1, fake.explainSchedulingStatusReturns.result2
+	}
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatusCallCount() int {
+	fake.explainSchedulingStatusMutex.RLock()
+	defer fake.explainSchedulingStatusMutex.RUnlock()
+	return len(fake.explainSchedulingStatusArgsForCall)
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatusArgsForCall(i int) (atc.JobConfig, atc.ResourceConfigs, db.SchedulingStatus) {
+	fake.explainSchedulingStatusMutex.RLock()
+	defer fake.explainSchedulingStatusMutex.RUnlock()
+	return fake.explainSchedulingStatusArgsForCall[i].arg1, fake.explainSchedulingStatusArgsForCall[i].arg2, fake.explainSchedulingStatusArgsForCall[i].arg3
+}
+
+func (fake *FakeScheduler) ExplainSchedulingStatusReturns(result1 db.SchedulingStatus, result2 error) {
+	fake.ExplainSchedulingStatusStub = nil
+	fake.explainSchedulingStatusReturns = struct {
+		result1 db.SchedulingStatus
+		result2 error
+	}{result1, result2}
+}
+
+var _ getjob.Scheduler = new(FakeScheduler)
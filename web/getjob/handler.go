@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/web/group"
 	"github.com/pivotal-golang/lager"
 )
@@ -18,6 +19,12 @@ type BuildWithInputsOutputs struct {
 	Outputs []db.BuildOutput
 }
 
+//go:generate counterfeiter . Scheduler
+
+type Scheduler interface {
+	ExplainSchedulingStatus(atc.JobConfig, atc.ResourceConfigs, db.SchedulingStatus) (db.SchedulingStatus, error)
+}
+
 type server struct {
 	logger lager.Logger
 
@@ -25,13 +32,21 @@ type server struct {
 	configDB db.ConfigDB
 
 	template *template.Template
+
+	radarSchedulerFactory pipelines.RadarSchedulerFactory
+
+	allowIgnoringPassed bool
 }
 
-func NewServer(logger lager.Logger, template *template.Template) *server {
+func NewServer(logger lager.Logger, template *template.Template, radarSchedulerFactory pipelines.RadarSchedulerFactory, allowIgnoringPassed bool) *server {
 	return &server{
 		logger: logger,
 
 		template: template,
+
+		radarSchedulerFactory: radarSchedulerFactory,
+
+		allowIgnoringPassed: allowIgnoringPassed,
 	}
 }
 
@@ -44,8 +59,26 @@ type TemplateData struct {
 
 	CurrentBuild db.Build
 	PipelineName string
+
+	InputVersions []InputVersions
+
+	// whether the versions chosen may ignore their inputs' passed constraints
+	AllowIgnoringPassed bool
+
+	// nil until the job has been scheduled
+	SchedulingStatus *db.SchedulingStatus
+}
+
+// InputVersions are the most recent enabled versions of one of the job's
+// inputs, any of which can be chosen when triggering a build.
+type InputVersions struct {
+	Input    atc.JobInput
+	Versions []db.SavedVersionedResource
 }
 
+// how many versions of each input can be chosen from when triggering a build
+const choosableVersions = 20
+
 //go:generate counterfeiter . JobDB
 
 type JobDB interface {
@@ -55,12 +88,16 @@ type JobDB interface {
 	GetCurrentBuild(job string) (db.Build, error)
 	GetPipelineName() string
 	GetBuildResources(buildID int) ([]db.BuildInput, []db.BuildOutput, error)
+	GetResource(string) (db.SavedResource, error)
+	GetResourceHistoryCursor(string, int, bool, int) ([]*db.VersionHistory, bool, error)
+	GetResourceHistoryMaxID(int) (int, error)
+	GetJobSchedulingStatus(job string) (db.SchedulingStatus, error)
 }
 
 var ErrJobConfigNotFound = errors.New("could not find job")
 var Err = errors.New("could not find job")
 
-func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
+func FetchTemplateData(jobDB JobDB, scheduler Scheduler, jobName string) (TemplateData, error) {
 	config, _, err := jobDB.GetConfig()
 	if err != nil {
 		return TemplateData{}, err
@@ -101,6 +138,27 @@ func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
 		return TemplateData{}, err
 	}
 
+	inputVersions, err := fetchInputVersions(jobDB, job)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var schedulingStatus *db.SchedulingStatus
+
+	status, err := jobDB.GetJobSchedulingStatus(job.Name)
+	switch err {
+	case nil:
+		status, err = scheduler.ExplainSchedulingStatus(job, config.Resources, status)
+		if err != nil {
+			return TemplateData{}, err
+		}
+
+		schedulingStatus = &status
+	case db.ErrNoSchedulingStatus:
+	default:
+		return TemplateData{}, err
+	}
+
 	return TemplateData{
 		Job:    job,
 		DBJob:  dbJob,
@@ -118,9 +176,47 @@ func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
 
 		CurrentBuild: currentBuild,
 		PipelineName: jobDB.GetPipelineName(),
+
+		InputVersions:    inputVersions,
+		SchedulingStatus: schedulingStatus,
 	}, nil
 }
 
+func fetchInputVersions(jobDB JobDB, job atc.JobConfig) ([]InputVersions, error) {
+	var inputVersions []InputVersions
+
+	for _, input := range job.Inputs() {
+		resource, err := jobDB.GetResource(input.Resource)
+		if err != nil {
+			return nil, err
+		}
+
+		maxID, err := jobDB.GetResourceHistoryMaxID(resource.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		history, _, err := jobDB.GetResourceHistoryCursor(input.Resource, maxID, false, choosableVersions)
+		if err != nil {
+			return nil, err
+		}
+
+		versions := []db.SavedVersionedResource{}
+		for _, vh := range history {
+			if vh.VersionedResource.Enabled {
+				versions = append(versions, vh.VersionedResource)
+			}
+		}
+
+		inputVersions = append(inputVersions, InputVersions{
+			Input:    input,
+			Versions: versions,
+		})
+	}
+
+	return inputVersions, nil
+}
+
 func (server *server) GetJob(pipelineDB db.PipelineDB) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		jobName := r.FormValue(":job")
@@ -129,7 +225,7 @@ func (server *server) GetJob(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		templateData, err := FetchTemplateData(pipelineDB, jobName)
+		templateData, err := FetchTemplateData(pipelineDB, server.radarSchedulerFactory.BuildScheduler(pipelineDB), jobName)
 		switch err {
 		case ErrJobConfigNotFound:
 			server.logger.Error("could-not-find-job-in-config", ErrJobConfigNotFound, lager.Data{
@@ -147,6 +243,8 @@ func (server *server) GetJob(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
+		templateData.AllowIgnoringPassed = server.allowIgnoringPassed
+
 		err = server.template.Execute(w, templateData)
 		if err != nil {
 			log.Fatal("failed-to-task-template", err, lager.Data{
@@ -17,9 +17,15 @@ import (
 
 var _ = Describe("FetchTemplateData", func() {
 	var fakeDB *fakes.FakeJobDB
+	var fakeScheduler *fakes.FakeScheduler
 
 	BeforeEach(func() {
 		fakeDB = new(fakes.FakeJobDB)
+
+		fakeScheduler = new(fakes.FakeScheduler)
+		fakeScheduler.ExplainSchedulingStatusStub = func(job atc.JobConfig, resources atc.ResourceConfigs, status db.SchedulingStatus) (db.SchedulingStatus, error) {
+			return status, nil
+		}
 	})
 
 	Context("when the config database returns an error", func() {
@@ -28,7 +34,7 @@ var _ = Describe("FetchTemplateData", func() {
 		})
 
 		It("returns an error if the config could not be loaded", func() {
-			_, err := FetchTemplateData(fakeDB, "job-name")
+			_, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 			Ω(err).Should(HaveOccurred())
 		})
 	})
@@ -59,7 +65,7 @@ var _ = Describe("FetchTemplateData", func() {
 		})
 
 		It("returns not found if the job cannot be found in the config", func() {
-			_, err := FetchTemplateData(fakeDB, "not-a-job-name")
+			_, err := FetchTemplateData(fakeDB, fakeScheduler, "not-a-job-name")
 			Ω(err).Should(HaveOccurred())
 			Ω(err).Should(MatchError(ErrJobConfigNotFound))
 		})
@@ -69,7 +75,7 @@ var _ = Describe("FetchTemplateData", func() {
 
 				It("returns an error if the jobs's builds could not be retreived", func() {
 					fakeDB.GetAllJobBuildsReturns([]db.Build{}, errors.New("disaster"))
-					_, err := FetchTemplateData(fakeDB, "job-name")
+					_, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 					Ω(err).Should(HaveOccurred())
 				})
 			})
@@ -104,7 +110,7 @@ var _ = Describe("FetchTemplateData", func() {
 				Context("when the get job lookup returns an error", func() {
 					It("returns an error", func() {
 						fakeDB.GetJobReturns(db.SavedJob{}, errors.New("disaster"))
-						_, err := FetchTemplateData(fakeDB, "job-name")
+						_, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 						Ω(err).Should(HaveOccurred())
 					})
 
@@ -142,7 +148,7 @@ var _ = Describe("FetchTemplateData", func() {
 							})
 
 							It("returns an error", func() {
-								templateData, err := FetchTemplateData(fakeDB, "job-name")
+								templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 								Ω(err).Should(HaveOccurred())
 								Ω(templateData).Should(Equal(TemplateData{}))
 							})
@@ -154,7 +160,7 @@ var _ = Describe("FetchTemplateData", func() {
 							})
 
 							It("populates the inputs and outputs for the builds returned", func() {
-								templateData, err := FetchTemplateData(fakeDB, "job-name")
+								templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 								Ω(err).ShouldNot(HaveOccurred())
 								Ω(fakeDB.GetBuildResourcesCallCount()).Should(Equal(1))
 
@@ -198,7 +204,7 @@ var _ = Describe("FetchTemplateData", func() {
 							It("has the correct template data and sets the current build status to pending", func() {
 								fakeDB.GetCurrentBuildReturns(db.Build{}, errors.New("No current build"))
 
-								templateData, err := FetchTemplateData(fakeDB, "job-name")
+								templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 								Ω(err).ShouldNot(HaveOccurred())
 
 								Ω(templateData.GroupStates).Should(ConsistOf(groupStates))
@@ -228,7 +234,7 @@ var _ = Describe("FetchTemplateData", func() {
 							})
 
 							It("has the correct template data", func() {
-								templateData, err := FetchTemplateData(fakeDB, "job-name")
+								templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 								Ω(err).ShouldNot(HaveOccurred())
 
 								Ω(templateData.GroupStates).Should(ConsistOf(groupStates))
@@ -238,6 +244,161 @@ var _ = Describe("FetchTemplateData", func() {
 								Ω(templateData.CurrentBuild).Should(Equal(currentBuild))
 							})
 
+							Context("when the job's scheduling status has been recorded", func() {
+								status := db.SchedulingStatus{
+									Reason:    db.SchedulingReasonPassedUnsatisfied,
+									Input:     "some-input",
+									Resource:  "some-resource",
+									PassedJob: "some-upstream-job",
+								}
+
+								BeforeEach(func() {
+									fakeDB.GetJobSchedulingStatusReturns(status, nil)
+								})
+
+								It("includes it", func() {
+									templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+									Ω(err).ShouldNot(HaveOccurred())
+
+									Ω(fakeDB.GetJobSchedulingStatusArgsForCall(0)).Should(Equal("job-name"))
+									Ω(templateData.SchedulingStatus).Should(Equal(&status))
+								})
+
+								It("has the scheduler explain it", func() {
+									explained := db.SchedulingStatus{
+										Reason:   db.SchedulingReasonNoCompatibleWorkers,
+										Input:    "some-input",
+										Resource: "some-resource",
+									}
+
+									fakeScheduler.ExplainSchedulingStatusStub = nil
+									fakeScheduler.ExplainSchedulingStatusReturns(explained, nil)
+
+									templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+									Ω(err).ShouldNot(HaveOccurred())
+
+									explainedJob, _, explainedStatus := fakeScheduler.ExplainSchedulingStatusArgsForCall(0)
+									Ω(explainedJob).Should(Equal(job))
+									Ω(explainedStatus).Should(Equal(status))
+
+									Ω(templateData.SchedulingStatus).Should(Equal(&explained))
+								})
+
+								Context("when explaining it fails", func() {
+									BeforeEach(func() {
+										fakeScheduler.ExplainSchedulingStatusStub = nil
+										fakeScheduler.ExplainSchedulingStatusReturns(db.SchedulingStatus{}, errors.New("disaster"))
+									})
+
+									It("returns an error", func() {
+										_, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+										Ω(err).Should(HaveOccurred())
+									})
+								})
+							})
+
+							Context("when the job has not been scheduled yet", func() {
+								BeforeEach(func() {
+									fakeDB.GetJobSchedulingStatusReturns(db.SchedulingStatus{}, db.ErrNoSchedulingStatus)
+								})
+
+								It("has no scheduling status", func() {
+									templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+									Ω(err).ShouldNot(HaveOccurred())
+
+									Ω(templateData.SchedulingStatus).Should(BeNil())
+								})
+							})
+
+							Context("when getting the job's scheduling status fails", func() {
+								BeforeEach(func() {
+									fakeDB.GetJobSchedulingStatusReturns(db.SchedulingStatus{}, errors.New("disaster"))
+								})
+
+								It("returns an error", func() {
+									_, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+									Ω(err).Should(HaveOccurred())
+								})
+							})
+
+							Context("when the job has inputs", func() {
+								var enabledVR db.SavedVersionedResource
+
+								BeforeEach(func() {
+									job.InputConfigs = []atc.JobInputConfig{
+										{
+											RawName:  "some-input",
+											Resource: "some-resource",
+										},
+									}
+
+									fakeDB.GetConfigReturns(atc.Config{
+										Jobs: []atc.JobConfig{job},
+									}, db.ConfigVersion(1), nil)
+
+									fakeDB.GetResourceReturns(db.SavedResource{ID: 7}, nil)
+									fakeDB.GetResourceHistoryMaxIDReturns(42, nil)
+
+									enabledVR = db.SavedVersionedResource{
+										ID:      42,
+										Enabled: true,
+										VersionedResource: db.VersionedResource{
+											Resource: "some-resource",
+											Version:  db.Version{"version": "2"},
+										},
+									}
+
+									fakeDB.GetResourceHistoryCursorReturns([]*db.VersionHistory{
+										{VersionedResource: enabledVR},
+										{
+											VersionedResource: db.SavedVersionedResource{
+												ID:      41,
+												Enabled: false,
+												VersionedResource: db.VersionedResource{
+													Resource: "some-resource",
+													Version:  db.Version{"version": "1"},
+												},
+											},
+										},
+									}, false, nil)
+								})
+
+								It("includes the most recent enabled versions of each input to choose from", func() {
+									templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+									Ω(err).ShouldNot(HaveOccurred())
+
+									Ω(fakeDB.GetResourceArgsForCall(0)).Should(Equal("some-resource"))
+									Ω(fakeDB.GetResourceHistoryMaxIDArgsForCall(0)).Should(Equal(7))
+
+									resourceName, startingID, searchUpwards, numResults := fakeDB.GetResourceHistoryCursorArgsForCall(0)
+									Ω(resourceName).Should(Equal("some-resource"))
+									Ω(startingID).Should(Equal(42))
+									Ω(searchUpwards).Should(BeFalse())
+									Ω(numResults).Should(Equal(20))
+
+									Ω(templateData.InputVersions).Should(Equal([]InputVersions{
+										{
+											Input: atc.JobInput{
+												Name:     "some-input",
+												Resource: "some-resource",
+											},
+											Versions: []db.SavedVersionedResource{enabledVR},
+										},
+									}))
+								})
+
+								Context("when getting the versions fails", func() {
+									BeforeEach(func() {
+										fakeDB.GetResourceHistoryCursorReturns(nil, false, errors.New("disaster"))
+									})
+
+									It("returns an error", func() {
+										_, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
+										Ω(err).Should(HaveOccurred())
+									})
+								})
+							})
+
 							Context("when the job is paused", func() {
 								BeforeEach(func() {
 									dbJob = db.SavedJob{
@@ -250,7 +411,7 @@ var _ = Describe("FetchTemplateData", func() {
 								})
 
 								It("has the correct template data and sets the current build status to paused", func() {
-									templateData, err := FetchTemplateData(fakeDB, "job-name")
+									templateData, err := FetchTemplateData(fakeDB, fakeScheduler, "job-name")
 									Ω(err).ShouldNot(HaveOccurred())
 
 									Ω(templateData.GroupStates).Should(ConsistOf(groupStates))
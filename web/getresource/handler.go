@@ -40,6 +40,9 @@ type TemplateData struct {
 	FailingToCheck bool
 	CheckError     error
 
+	// whether the resource can be checked manually
+	Authenticated bool
+
 	GroupStates  []group.State
 	PipelineName string
 
@@ -125,7 +128,8 @@ func FetchTemplateData(resourceDB ResourcesDB, authenticated bool, resourceName
 			OlderStartID:  olderStartID,
 			NewerStartID:  newerStartID,
 		},
-		PipelineName: resourceDB.GetPipelineName(),
+		PipelineName:  resourceDB.GetPipelineName(),
+		Authenticated: authenticated,
 		GroupStates: group.States(config.Groups, func(g atc.GroupConfig) bool {
 			for _, groupResource := range g.Resources {
 				if groupResource == configResource.Name {
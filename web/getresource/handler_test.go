@@ -342,6 +342,7 @@ var _ = Describe("FetchTemplateData", func() {
 									CheckError:     "a disaster!",
 								}))
 								Ω(templateData.PaginationData.HasPagination).Should(BeTrue())
+								Ω(templateData.Authenticated).Should(BeTrue())
 							})
 						})
 					})
@@ -378,6 +379,7 @@ var _ = Describe("FetchTemplateData", func() {
 							FailingToCheck: true,
 							CheckError:     "",
 						}))
+						Ω(templateData.Authenticated).Should(BeFalse())
 					})
 				})
 			})
@@ -43,6 +43,7 @@ var _ = Describe("Handler", func() {
 			"templatefixtures",
 			"../public",
 			engine,
+			false,
 		)
 		Ω(err).ShouldNot(HaveOccurred())
 	})
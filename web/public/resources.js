@@ -25,4 +25,56 @@ $(document).ready(function() {
 
     return false;
   });
+
+  $(".js-pinResource").on("click", function() {
+    $.ajax({
+      method: "PUT",
+      url: $(this).data("pin-url")
+    }).done(function() {
+      window.location.reload();
+    });
+
+    return false;
+  });
+
+  $(".js-unpinResource").on("click", function() {
+    $.ajax({
+      method: "PUT",
+      url: $(this).data("unpin-url")
+    }).done(function() {
+      window.location.reload();
+    });
+
+    return false;
+  });
+
+  $(".js-checkNow").on("click", function() {
+    var $resource = $(this).closest(".js-resource");
+    var $icon = $(this).find("i");
+
+    $icon.addClass("fa-spin");
+
+    $.ajax({
+      method: "POST",
+      url: "/api/v1/" + $resource.data("endpoint") + "/check",
+      dataType: "json"
+    }).done(function() {
+      window.location.reload();
+    }).fail(function(xhr) {
+      $icon.removeClass("fa-spin");
+      $resource.find(".js-resourceStatusText").text("checking failed");
+
+      var checkError = xhr.responseJSON && xhr.responseJSON.check_error;
+      if (checkError) {
+        var $body = $resource.find(".step-body");
+        if (!$body.length) {
+          $body = $("<div class='step-body'><pre></pre></div>").appendTo($resource.find(".build-step"));
+        }
+
+        $body.find("pre").text(checkError);
+      }
+    });
+
+    return false;
+  });
 });
@@ -40,6 +40,7 @@ func NewHandler(
 	configDB db.ConfigDB,
 	templatesDir, publicDir string,
 	engine engine.Engine,
+	allowIgnoringPassed bool,
 ) (http.Handler, error) {
 	tfuncs := &templateFuncs{
 		assetsDir: publicDir,
@@ -93,11 +94,11 @@ func NewHandler(
 		return nil, err
 	}
 
-	jobServer := getjob.NewServer(logger, jobTemplate)
+	jobServer := getjob.NewServer(logger, jobTemplate, radarSchedulerFactory, allowIgnoringPassed)
 	resourceServer := getresource.NewServer(logger, resourceTemplate, validator)
 	pipelineServer := pipeline.NewServer(logger, pipelineTemplate)
 	buildServer := getbuild.NewServer(logger, buildTemplate)
-	triggerBuildServer := triggerbuild.NewServer(logger, radarSchedulerFactory)
+	triggerBuildServer := triggerbuild.NewServer(logger, radarSchedulerFactory, allowIgnoringPassed)
 
 	handlers := map[string]http.Handler{
 		// public
@@ -113,7 +113,7 @@ func PathFor(route string, args ...interface{}) (string, error) {
 			"build_id": fmt.Sprintf("%d", args[0].(db.Build).ID),
 		})
 
-	case atc.EnableResourceVersion, atc.DisableResourceVersion:
+	case atc.EnableResourceVersion, atc.DisableResourceVersion, atc.PinResourceVersion:
 		versionedResource := args[1].(db.SavedVersionedResource)
 
 		return atc.Routes.CreatePathForRoute(route, rata.Params{
@@ -122,6 +122,12 @@ func PathFor(route string, args ...interface{}) (string, error) {
 			"resource_version_id": fmt.Sprintf("%d", versionedResource.ID),
 		})
 
+	case atc.UnpinResource:
+		return atc.Routes.CreatePathForRoute(route, rata.Params{
+			"pipeline_name": args[0].(string),
+			"resource_name": args[1].(string),
+		})
+
 	case routes.LogIn:
 		return routes.Routes.CreatePathForRoute(route, rata.Params{})
 
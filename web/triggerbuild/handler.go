@@ -3,27 +3,33 @@ package triggerbuild
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/pivotal-golang/lager"
 	"github.com/tedsuo/rata"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/pipelines"
+	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/web/routes"
 )
 
 type server struct {
 	logger                lager.Logger
 	radarSchedulerFactory pipelines.RadarSchedulerFactory
+	allowIgnoringPassed   bool
 }
 
 func NewServer(
 	logger lager.Logger,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
+	allowIgnoringPassed bool,
 ) *server {
 	return &server{
 		logger:                logger,
 		radarSchedulerFactory: radarSchedulerFactory,
+		allowIgnoringPassed:   allowIgnoringPassed,
 	}
 }
 
@@ -46,12 +52,49 @@ func (server *server) TriggerBuild(pipelineDB db.PipelineDB) http.Handler {
 			"job": job.Name,
 		})
 
-		log.Debug("triggering")
+		// versions chosen with the job page's version picker, by ID
+		var chosen []atc.JobBuildInput
+		for _, input := range job.Inputs() {
+			versionID := r.FormValue("version-" + input.Name)
+			if versionID == "" {
+				continue
+			}
 
-		scheduler := server.radarSchedulerFactory.BuildScheduler(pipelineDB)
+			id, err := strconv.Atoi(versionID)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "invalid version for input '%s': %s", input.Name, versionID)
+				return
+			}
 
-		build, err := scheduler.TriggerImmediately(log, job, config.Resources)
+			chosen = append(chosen, atc.JobBuildInput{
+				Name:      input.Name,
+				VersionID: id,
+			})
+		}
+
+		ignorePassed := r.FormValue("ignore_passed") != ""
+		if ignorePassed && !server.allowIgnoringPassed {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "ignoring passed constraints is not allowed")
+			return
+		}
+
+		log.Debug("triggering", lager.Data{
+			"chosen-versions": chosen,
+			"ignore-passed":   ignorePassed,
+		})
+
+		jobScheduler := server.radarSchedulerFactory.BuildScheduler(pipelineDB)
+
+		build, err := jobScheduler.TriggerWithVersions(log, job, config.Resources, chosen, ignorePassed)
 		if err != nil {
+			if _, ok := err.(scheduler.InputVersionsError); ok {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "failed to trigger: %s", err)
+				return
+			}
+
 			log.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			fmt.Fprintf(w, "failed to trigger: %s", err)
@@ -51,6 +51,31 @@ var _ = Describe("URLs", func() {
 		})
 	})
 
+	Describe("PinResourceVersion", func() {
+		It("returns the correct URL", func() {
+			versionedResource := db.SavedVersionedResource{
+				ID: 123,
+				VersionedResource: db.VersionedResource{
+					Resource: "resource-name",
+				},
+			}
+
+			path, err := web.PathFor(atc.PinResourceVersion, "some-pipeline", versionedResource)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/api/v1/pipelines/some-pipeline/resources/resource-name/versions/123/pin"))
+		})
+	})
+
+	Describe("UnpinResource", func() {
+		It("returns the correct URL", func() {
+			path, err := web.PathFor(atc.UnpinResource, "some-pipeline", "resource-name")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/api/v1/pipelines/some-pipeline/resources/resource-name/unpin"))
+		})
+	})
+
 	Describe("Jobs Patch", func() {
 		It("returns the correct URL", func() {
 			job := atc.JobConfig{
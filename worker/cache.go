@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"path"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/garden"
+)
+
+// caches are kept in a container of their own on the worker, so that they
+// outlive the containers of the builds that use them
+const cacheRoot = "/tmp/cache"
+
+const cacheGraceTime = 24 * time.Hour
+
+func (worker *gardenWorker) findOrCreateCache(id Identifier, image string) (garden.Container, error) {
+	properties := id.gardenProperties()
+
+	containers, err := worker.gardenClient.Containers(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(containers) > 0 {
+		return containers[0], nil
+	}
+
+	return worker.gardenClient.Create(garden.ContainerSpec{
+		RootFSPath: image,
+		GraceTime:  cacheGraceTime,
+		Properties: properties,
+	})
+}
+
+func (worker *gardenWorker) ClearCaches(pipelineName string, jobName string) error {
+	containers, err := worker.gardenClient.Containers(Identifier{
+		PipelineName: pipelineName,
+		JobName:      jobName,
+		Type:         ContainerTypeCache,
+	}.gardenProperties())
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		err := worker.gardenClient.Destroy(container.Handle())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreCaches copies each cached path into the container, under dir. A
+// path that has never been saved is skipped; caches are only an optimization,
+// so nothing here fails the container's creation.
+func restoreCaches(cache garden.Container, container garden.Container, dir string, paths []string) {
+	for _, p := range paths {
+		copyDir(cache, path.Join(cacheRoot, p), container, path.Join(dir, p))
+	}
+}
+
+func saveCaches(container garden.Container, dir string, cache garden.Container, paths []string) {
+	for _, p := range paths {
+		copyDir(container, path.Join(dir, p), cache, path.Join(cacheRoot, p))
+	}
+}
+
+func copyDir(src garden.Container, srcPath string, dst garden.Container, dstPath string) error {
+	out, err := src.StreamOut(garden.StreamOutSpec{
+		Path: srcPath + "/",
+	})
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	return dst.StreamIn(garden.StreamInSpec{
+		Path:      dstPath,
+		TarStream: out,
+	})
+}
+
+// cachingContainer saves the container's cached paths when it is first
+// released, before releasing the container itself.
+type cachingContainer struct {
+	Container
+
+	gardenContainer garden.Container
+	cache           garden.Container
+	dir             string
+	paths           []string
+
+	saveOnce sync.Once
+}
+
+func (container *cachingContainer) Release() {
+	container.saveOnce.Do(func() {
+		saveCaches(container.gardenContainer, container.dir, container.cache, container.paths)
+	})
+
+	container.Container.Release()
+}
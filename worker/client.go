@@ -16,6 +16,9 @@ type Client interface {
 	CreateContainer(Identifier, ContainerSpec) (Container, error)
 	LookupContainer(Identifier) (Container, error)
 	AddResources([]atc.WorkerResourceType) (bool, error)
+
+	// ClearCaches destroys the task caches kept for the given job.
+	ClearCaches(pipelineName string, jobName string) error
 }
 
 //go:generate counterfeiter . Container
@@ -33,12 +36,17 @@ type Identifier struct {
 
 	PipelineName string
 
+	JobName string
+
 	BuildID int
 
 	Type ContainerType
 
 	StepLocation uint
 
+	// distinguishes the containers of each attempt of a retried step
+	Attempt uint
+
 	CheckType   string
 	CheckSource atc.Source
 }
@@ -56,6 +64,10 @@ func (id Identifier) gardenProperties() garden.Properties {
 		props[propertyPrefix+"pipeline-name"] = id.PipelineName
 	}
 
+	if id.JobName != "" {
+		props[propertyPrefix+"job-name"] = id.JobName
+	}
+
 	if id.BuildID != 0 {
 		props[propertyPrefix+"build-id"] = strconv.Itoa(id.BuildID)
 	}
@@ -68,6 +80,10 @@ func (id Identifier) gardenProperties() garden.Properties {
 		props[propertyPrefix+"location"] = fmt.Sprintf("%v", id.StepLocation)
 	}
 
+	if id.Attempt != 0 {
+		props[propertyPrefix+"attempt"] = fmt.Sprintf("%v", id.Attempt)
+	}
+
 	if id.CheckType != "" {
 		props[propertyPrefix+"check-type"] = id.CheckType
 	}
@@ -83,10 +99,12 @@ func (id Identifier) gardenProperties() garden.Properties {
 type ContainerType string
 
 const (
-	ContainerTypeCheck ContainerType = "check"
-	ContainerTypeGet   ContainerType = "get"
-	ContainerTypePut   ContainerType = "put"
-	ContainerTypeTask  ContainerType = "task"
+	ContainerTypeCheck  ContainerType = "check"
+	ContainerTypeGet    ContainerType = "get"
+	ContainerTypePut    ContainerType = "put"
+	ContainerTypeDelete ContainerType = "delete"
+	ContainerTypeTask   ContainerType = "task"
+	ContainerTypeCache  ContainerType = "cache"
 )
 
 type MultipleContainersError struct {
@@ -3,6 +3,8 @@ package worker
 import (
 	"fmt"
 	"strings"
+
+	"github.com/concourse/atc"
 )
 
 type ContainerSpec interface {
@@ -25,6 +27,19 @@ type TaskContainerSpec struct {
 
 	Image      string
 	Privileged bool
+
+	Limits atc.ContainerLimits
+
+	// If set, Caches are restored from the cache identified by CacheID when the
+	// container is created, and saved back when it is released. Workers which
+	// already hold the cache are preferred.
+	//
+	// Caches are paths relative to CacheDir, the container's working directory,
+	// and are kept by those relative paths, so that they are shared by
+	// containers whose working directories differ.
+	CacheID  *Identifier
+	CacheDir string
+	Caches   []string
 }
 
 func (spec TaskContainerSpec) Description() string {
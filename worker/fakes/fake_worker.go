@@ -4,6 +4,7 @@ package fakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
 )
 
@@ -27,6 +28,24 @@ type FakeWorker struct {
 		result1 worker.Container
 		result2 error
 	}
+	AddResourcesStub        func([]atc.WorkerResourceType) (bool, error)
+	addResourcesMutex       sync.RWMutex
+	addResourcesArgsForCall []struct {
+		arg1 []atc.WorkerResourceType
+	}
+	addResourcesReturns struct {
+		result1 bool
+		result2 error
+	}
+	ClearCachesStub        func(pipelineName string, jobName string) error
+	clearCachesMutex       sync.RWMutex
+	clearCachesArgsForCall []struct {
+		pipelineName string
+		jobName      string
+	}
+	clearCachesReturns struct {
+		result1 error
+	}
 	ActiveContainersStub        func() int
 	activeContainersMutex       sync.RWMutex
 	activeContainersArgsForCall []struct{}
@@ -116,6 +135,72 @@ func (fake *FakeWorker) LookupContainerReturns(result1 worker.Container, result2
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) AddResources(arg1 []atc.WorkerResourceType) (bool, error) {
+	fake.addResourcesMutex.Lock()
+	fake.addResourcesArgsForCall = append(fake.addResourcesArgsForCall, struct {
+		arg1 []atc.WorkerResourceType
+	}{arg1})
+	fake.addResourcesMutex.Unlock()
+	if fake.AddResourcesStub != nil {
+		return fake.AddResourcesStub(arg1)
+	} else {
+		return fake.addResourcesReturns.result1, fake.addResourcesReturns.result2
+	}
+}
+
+func (fake *FakeWorker) AddResourcesCallCount() int {
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	return len(fake.addResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) AddResourcesArgsForCall(i int) []atc.WorkerResourceType {
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	return fake.addResourcesArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) AddResourcesReturns(result1 bool, result2 error) {
+	fake.AddResourcesStub = nil
+	fake.addResourcesReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ClearCaches(pipelineName string, jobName string) error {
+	fake.clearCachesMutex.Lock()
+	fake.clearCachesArgsForCall = append(fake.clearCachesArgsForCall, struct {
+		pipelineName string
+		jobName      string
+	}{pipelineName, jobName})
+	fake.clearCachesMutex.Unlock()
+	if fake.ClearCachesStub != nil {
+		return fake.ClearCachesStub(pipelineName, jobName)
+	} else {
+		return fake.clearCachesReturns.result1
+	}
+}
+
+func (fake *FakeWorker) ClearCachesCallCount() int {
+	fake.clearCachesMutex.RLock()
+	defer fake.clearCachesMutex.RUnlock()
+	return len(fake.clearCachesArgsForCall)
+}
+
+func (fake *FakeWorker) ClearCachesArgsForCall(i int) (string, string) {
+	fake.clearCachesMutex.RLock()
+	defer fake.clearCachesMutex.RUnlock()
+	return fake.clearCachesArgsForCall[i].pipelineName, fake.clearCachesArgsForCall[i].jobName
+}
+
+func (fake *FakeWorker) ClearCachesReturns(result1 error) {
+	fake.ClearCachesStub = nil
+	fake.clearCachesReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorker) ActiveContainers() int {
 	fake.activeContainersMutex.Lock()
 	fake.activeContainersArgsForCall = append(fake.activeContainersArgsForCall, struct{}{})
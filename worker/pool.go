@@ -73,6 +73,13 @@ func (pool *Pool) CreateContainer(id Identifier, spec ContainerSpec) (Container,
 		}
 	}
 
+	if s, ok := spec.(TaskContainerSpec); ok && s.CacheID != nil {
+		warmWorkers := workersWithCache(compatibleWorkers, *s.CacheID)
+		if len(warmWorkers) > 0 {
+			compatibleWorkers = warmWorkers
+		}
+	}
+
 	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
 
 	return randomWorker.CreateContainer(id, spec)
@@ -182,6 +189,54 @@ func (pool *Pool) AddResources(newResources []atc.WorkerResourceType) (bool, err
 	return successes > 0, nil
 }
 
+func (pool *Pool) ClearCaches(pipelineName string, jobName string) error {
+	workers, err := pool.provider.Workers()
+	if err != nil {
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(len(workers))
+
+	errs := make(chan error, len(workers))
+
+	for _, worker := range workers {
+		go func(worker Worker) {
+			defer wg.Done()
+
+			err := worker.ClearCaches(pipelineName, jobName)
+			if err != nil {
+				errs <- err
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return <-errs
+	}
+
+	return nil
+}
+
+func workersWithCache(workers []Worker, cacheID Identifier) []Worker {
+	warmWorkers := []Worker{}
+
+	for _, worker := range workers {
+		cache, err := worker.LookupContainer(cacheID)
+		if err != nil {
+			continue
+		}
+
+		cache.Release()
+
+		warmWorkers = append(warmWorkers, worker)
+	}
+
+	return warmWorkers
+}
+
 type byActiveContainers []Worker
 
 func (cs byActiveContainers) Len() int { return len(cs) }
@@ -99,6 +99,62 @@ var _ = Describe("Pool", func() {
 				Ω(workerC.CreateContainerCallCount()).Should(BeZero())
 			})
 
+			Context("when the spec has a cache", func() {
+				var fakeCache *fakes.FakeContainer
+
+				BeforeEach(func() {
+					spec = TaskContainerSpec{
+						CacheID: &Identifier{
+							Name:         "some-task",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Type:         ContainerTypeCache,
+						},
+						CacheDir: "/tmp/build/some-guid",
+						Caches:   []string{"some-cache"},
+					}
+
+					fakeCache = new(fakes.FakeContainer)
+
+					workerA.LookupContainerReturns(nil, ErrContainerNotFound)
+					workerB.LookupContainerReturns(fakeCache, nil)
+				})
+
+				It("creates using a worker that already has the cache", func() {
+					for i := 1; i < 100; i++ { // account for initial create in JustBefore
+						_, createErr := pool.CreateContainer(id, spec)
+						Ω(createErr).ShouldNot(HaveOccurred())
+					}
+
+					Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+					Ω(workerB.CreateContainerCallCount()).Should(Equal(100))
+				})
+
+				It("looks for the cache by its identifier", func() {
+					Ω(workerB.LookupContainerArgsForCall(0)).Should(Equal(Identifier{
+						Name:         "some-task",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Type:         ContainerTypeCache,
+					}))
+				})
+
+				It("releases the found cache", func() {
+					Ω(fakeCache.ReleaseCallCount()).Should(Equal(1))
+				})
+
+				Context("when no worker has the cache", func() {
+					BeforeEach(func() {
+						workerB.LookupContainerReturns(nil, ErrContainerNotFound)
+					})
+
+					It("creates using any compatible worker", func() {
+						Ω(createErr).ShouldNot(HaveOccurred())
+						Ω(workerA.CreateContainerCallCount() + workerB.CreateContainerCallCount()).Should(Equal(1))
+					})
+				})
+			})
+
 			Context("when creating the container fails", func() {
 				disaster := errors.New("nope")
 
@@ -151,6 +207,70 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("ClearCaches", func() {
+		var clearErr error
+
+		JustBeforeEach(func() {
+			clearErr = pool.ClearCaches("some-pipeline", "some-job")
+		})
+
+		Context("with multiple workers", func() {
+			var (
+				workerA *fakes.FakeWorker
+				workerB *fakes.FakeWorker
+			)
+
+			BeforeEach(func() {
+				workerA = new(fakes.FakeWorker)
+				workerB = new(fakes.FakeWorker)
+
+				fakeProvider.WorkersReturns([]Worker{workerA, workerB}, nil)
+			})
+
+			It("clears the job's caches on every worker", func() {
+				Ω(clearErr).ShouldNot(HaveOccurred())
+
+				Ω(workerA.ClearCachesCallCount()).Should(Equal(1))
+				pipelineName, jobName := workerA.ClearCachesArgsForCall(0)
+				Ω(pipelineName).Should(Equal("some-pipeline"))
+				Ω(jobName).Should(Equal("some-job"))
+
+				Ω(workerB.ClearCachesCallCount()).Should(Equal(1))
+				pipelineName, jobName = workerB.ClearCachesArgsForCall(0)
+				Ω(pipelineName).Should(Equal("some-pipeline"))
+				Ω(jobName).Should(Equal("some-job"))
+			})
+
+			Context("when clearing a worker's caches fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					workerB.ClearCachesReturns(disaster)
+				})
+
+				It("still clears the other workers", func() {
+					Ω(workerA.ClearCachesCallCount()).Should(Equal(1))
+				})
+
+				It("returns the error", func() {
+					Ω(clearErr).Should(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when getting the workers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeProvider.WorkersReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(clearErr).Should(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("LookupContainer", func() {
 		var (
 			id Identifier
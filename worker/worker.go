@@ -98,7 +98,68 @@ dance:
 		return nil, err
 	}
 
-	return newGardenWorkerContainer(gardenContainer, worker.gardenClient, worker.clock), nil
+	s, isTask := spec.(TaskContainerSpec)
+	if !isTask {
+		return newGardenWorkerContainer(gardenContainer, worker.gardenClient, worker.clock), nil
+	}
+
+	err = worker.limitContainer(gardenContainer, s.Limits)
+	if err != nil {
+		worker.gardenClient.Destroy(gardenContainer.Handle())
+		return nil, err
+	}
+
+	if s.CacheID == nil || len(s.Caches) == 0 {
+		return newGardenWorkerContainer(gardenContainer, worker.gardenClient, worker.clock), nil
+	}
+
+	cache, err := worker.findOrCreateCache(*s.CacheID, s.Image)
+	if err != nil {
+		worker.gardenClient.Destroy(gardenContainer.Handle())
+		return nil, err
+	}
+
+	restoreCaches(cache, gardenContainer, s.CacheDir, s.Caches)
+
+	return &cachingContainer{
+		Container: newGardenWorkerContainer(gardenContainer, worker.gardenClient, worker.clock),
+
+		gardenContainer: gardenContainer,
+		cache:           cache,
+		dir:             s.CacheDir,
+		paths:           s.Caches,
+	}, nil
+}
+
+func (worker *gardenWorker) limitContainer(container garden.Container, limits atc.ContainerLimits) error {
+	if limits.Memory != 0 {
+		err := container.LimitMemory(garden.MemoryLimits{
+			LimitInBytes: limits.Memory,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if limits.CPU != 0 {
+		err := container.LimitCPU(garden.CPULimits{
+			LimitInShares: limits.CPU,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if limits.Disk != 0 {
+		err := container.LimitDisk(garden.DiskLimits{
+			ByteHard: limits.Disk,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (worker *gardenWorker) LookupContainer(id Identifier) (Container, error) {
@@ -10,6 +10,7 @@ import (
 	. "github.com/concourse/atc/worker"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/pivotal-golang/clock/fakeclock"
 )
 
@@ -63,6 +64,7 @@ var _ = Describe("Worker", func() {
 				BuildID:      42,
 				Type:         ContainerTypeGet,
 				StepLocation: 3,
+				Attempt:      2,
 				CheckType:    "some-check-type",
 				CheckSource:  atc.Source{"some": "source"},
 			}
@@ -103,6 +105,7 @@ var _ = Describe("Worker", func() {
 								"concourse:type":          "get",
 								"concourse:pipeline-name": "some-pipeline",
 								"concourse:location":      "3",
+								"concourse:attempt":       "2",
 								"concourse:check-type":    "some-check-type",
 								"concourse:check-source":  "{\"some\":\"source\"}",
 								"concourse:name":          "some-name",
@@ -128,6 +131,7 @@ var _ = Describe("Worker", func() {
 									"concourse:type":          "get",
 									"concourse:pipeline-name": "some-pipeline",
 									"concourse:location":      "3",
+									"concourse:attempt":       "2",
 									"concourse:check-type":    "some-check-type",
 									"concourse:check-source":  "{\"some\":\"source\"}",
 									"concourse:name":          "some-name",
@@ -226,6 +230,12 @@ var _ = Describe("Worker", func() {
 					Ω(createErr).ShouldNot(HaveOccurred())
 				})
 
+				It("does not limit the container", func() {
+					Ω(fakeContainer.LimitMemoryCallCount()).Should(Equal(0))
+					Ω(fakeContainer.LimitCPUCallCount()).Should(Equal(0))
+					Ω(fakeContainer.LimitDiskCallCount()).Should(Equal(0))
+				})
+
 				It("creates the container with the Garden client", func() {
 					Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
 					Ω(fakeGardenClient.CreateArgsForCall(0)).Should(Equal(garden.ContainerSpec{
@@ -235,6 +245,7 @@ var _ = Describe("Worker", func() {
 							"concourse:type":          "get",
 							"concourse:pipeline-name": "some-pipeline",
 							"concourse:location":      "3",
+							"concourse:attempt":       "2",
 							"concourse:check-type":    "some-check-type",
 							"concourse:check-source":  "{\"some\":\"source\"}",
 							"concourse:name":          "some-name",
@@ -294,6 +305,219 @@ var _ = Describe("Worker", func() {
 					Ω(createErr).Should(Equal(disaster))
 				})
 			})
+
+			Context("when the spec has container limits", func() {
+				var fakeContainer *gfakes.FakeContainer
+
+				BeforeEach(func() {
+					spec = TaskContainerSpec{
+						Image: "some-image",
+						Limits: atc.ContainerLimits{
+							Memory: 1024,
+							CPU:    512,
+							Disk:   4096,
+						},
+					}
+
+					fakeContainer = new(gfakes.FakeContainer)
+					fakeContainer.HandleReturns("some-handle")
+
+					fakeGardenClient.CreateReturns(fakeContainer, nil)
+				})
+
+				It("limits the container", func() {
+					Ω(createErr).ShouldNot(HaveOccurred())
+
+					Ω(fakeContainer.LimitMemoryCallCount()).Should(Equal(1))
+					Ω(fakeContainer.LimitMemoryArgsForCall(0)).Should(Equal(garden.MemoryLimits{
+						LimitInBytes: 1024,
+					}))
+
+					Ω(fakeContainer.LimitCPUCallCount()).Should(Equal(1))
+					Ω(fakeContainer.LimitCPUArgsForCall(0)).Should(Equal(garden.CPULimits{
+						LimitInShares: 512,
+					}))
+
+					Ω(fakeContainer.LimitDiskCallCount()).Should(Equal(1))
+					Ω(fakeContainer.LimitDiskArgsForCall(0)).Should(Equal(garden.DiskLimits{
+						ByteHard: 4096,
+					}))
+				})
+
+				Context("when limiting the container fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeContainer.LimitMemoryReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						Ω(createErr).Should(Equal(disaster))
+					})
+
+					It("destroys the container", func() {
+						Ω(fakeGardenClient.DestroyCallCount()).Should(Equal(1))
+						Ω(fakeGardenClient.DestroyArgsForCall(0)).Should(Equal("some-handle"))
+					})
+				})
+			})
+
+			Context("when the spec has caches", func() {
+				var (
+					cacheID Identifier
+
+					fakeContainer      *gfakes.FakeContainer
+					fakeCacheContainer *gfakes.FakeContainer
+				)
+
+				BeforeEach(func() {
+					cacheID = Identifier{
+						Name:         "some-task",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Type:         ContainerTypeCache,
+					}
+
+					spec = TaskContainerSpec{
+						Image:    "some-image",
+						CacheID:  &cacheID,
+						CacheDir: "/tmp/build/some-guid",
+						Caches:   []string{"some-cache"},
+					}
+
+					fakeContainer = new(gfakes.FakeContainer)
+					fakeContainer.HandleReturns("some-handle")
+
+					fakeCacheContainer = new(gfakes.FakeContainer)
+					fakeCacheContainer.HandleReturns("some-cache-handle")
+
+					fakeContainer.StreamOutReturns(gbytes.NewBuffer(), nil)
+					fakeCacheContainer.StreamOutReturns(gbytes.NewBuffer(), nil)
+				})
+
+				Context("when the worker already has the cache", func() {
+					BeforeEach(func() {
+						fakeGardenClient.ContainersReturns([]garden.Container{fakeCacheContainer}, nil)
+						fakeGardenClient.CreateReturns(fakeContainer, nil)
+					})
+
+					It("looks up the cache by its identifier", func() {
+						Ω(fakeGardenClient.ContainersCallCount()).Should(Equal(1))
+						Ω(fakeGardenClient.ContainersArgsForCall(0)).Should(Equal(garden.Properties{
+							"concourse:type":          "cache",
+							"concourse:pipeline-name": "some-pipeline",
+							"concourse:job-name":      "some-job",
+							"concourse:name":          "some-task",
+						}))
+
+						Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
+					})
+
+					It("restores the cached paths into the container", func() {
+						Ω(createErr).ShouldNot(HaveOccurred())
+
+						Ω(fakeCacheContainer.StreamOutCallCount()).Should(Equal(1))
+						Ω(fakeCacheContainer.StreamOutArgsForCall(0)).Should(Equal(garden.StreamOutSpec{
+							Path: "/tmp/cache/some-cache/",
+						}))
+
+						Ω(fakeContainer.StreamInCallCount()).Should(Equal(1))
+						Ω(fakeContainer.StreamInArgsForCall(0).Path).Should(Equal("/tmp/build/some-guid/some-cache"))
+					})
+
+					Context("when the cache has not been saved yet", func() {
+						BeforeEach(func() {
+							fakeCacheContainer.StreamOutReturns(nil, errors.New("nope"))
+						})
+
+						It("succeeds without restoring anything", func() {
+							Ω(createErr).ShouldNot(HaveOccurred())
+							Ω(fakeContainer.StreamInCallCount()).Should(BeZero())
+						})
+					})
+
+					Describe("releasing the created container", func() {
+						It("saves the cached paths exactly once", func() {
+							createdContainer.Release()
+							createdContainer.Release()
+
+							Ω(fakeContainer.StreamOutCallCount()).Should(Equal(1))
+							Ω(fakeContainer.StreamOutArgsForCall(0)).Should(Equal(garden.StreamOutSpec{
+								Path: "/tmp/build/some-guid/some-cache/",
+							}))
+
+							Ω(fakeCacheContainer.StreamInCallCount()).Should(Equal(1))
+							Ω(fakeCacheContainer.StreamInArgsForCall(0).Path).Should(Equal("/tmp/cache/some-cache"))
+						})
+
+						It("restores what it saved into a later build's container, whose working directory differs", func() {
+							createdContainer.Release()
+
+							savedPath := fakeCacheContainer.StreamInArgsForCall(0).Path
+
+							laterContainer := new(gfakes.FakeContainer)
+							laterContainer.HandleReturns("some-later-handle")
+							fakeGardenClient.CreateReturns(laterContainer, nil)
+
+							laterSpec := spec.(TaskContainerSpec)
+							laterSpec.CacheDir = "/tmp/build/some-other-guid"
+
+							_, err := worker.CreateContainer(id, laterSpec)
+							Ω(err).ShouldNot(HaveOccurred())
+
+							Ω(fakeCacheContainer.StreamOutCallCount()).Should(Equal(2))
+							Ω(fakeCacheContainer.StreamOutArgsForCall(1)).Should(Equal(garden.StreamOutSpec{
+								Path: savedPath + "/",
+							}))
+
+							Ω(laterContainer.StreamInCallCount()).Should(Equal(1))
+							Ω(laterContainer.StreamInArgsForCall(0).Path).Should(Equal("/tmp/build/some-other-guid/some-cache"))
+						})
+					})
+				})
+
+				Context("when the worker does not have the cache", func() {
+					BeforeEach(func() {
+						fakeGardenClient.CreateStub = func(spec garden.ContainerSpec) (garden.Container, error) {
+							if spec.Properties["concourse:type"] == "cache" {
+								return fakeCacheContainer, nil
+							}
+
+							return fakeContainer, nil
+						}
+					})
+
+					It("creates the cache with a grace time", func() {
+						Ω(createErr).ShouldNot(HaveOccurred())
+
+						Ω(fakeGardenClient.CreateCallCount()).Should(Equal(2))
+
+						cacheSpec := fakeGardenClient.CreateArgsForCall(1)
+						Ω(cacheSpec.RootFSPath).Should(Equal("some-image"))
+						Ω(cacheSpec.GraceTime).Should(Equal(24 * time.Hour))
+						Ω(cacheSpec.Properties).Should(HaveKeyWithValue("concourse:job-name", "some-job"))
+					})
+				})
+
+				Context("when looking up the cache fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeGardenClient.CreateReturns(fakeContainer, nil)
+						fakeGardenClient.ContainersReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Ω(createErr).Should(Equal(disaster))
+					})
+
+					It("destroys the container", func() {
+						Ω(fakeGardenClient.DestroyCallCount()).Should(Equal(1))
+						Ω(fakeGardenClient.DestroyArgsForCall(0)).Should(Equal("some-handle"))
+					})
+				})
+			})
+
 		})
 	})
 
@@ -423,6 +647,55 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("ClearCaches", func() {
+		var clearErr error
+
+		JustBeforeEach(func() {
+			clearErr = worker.ClearCaches("some-pipeline", "some-job")
+		})
+
+		Context("when the job has caches on the worker", func() {
+			BeforeEach(func() {
+				fakeContainer1 := new(gfakes.FakeContainer)
+				fakeContainer1.HandleReturns("handle-1")
+
+				fakeContainer2 := new(gfakes.FakeContainer)
+				fakeContainer2.HandleReturns("handle-2")
+
+				fakeGardenClient.ContainersReturns([]garden.Container{fakeContainer1, fakeContainer2}, nil)
+			})
+
+			It("looks for the job's caches", func() {
+				Ω(fakeGardenClient.ContainersCallCount()).Should(Equal(1))
+				Ω(fakeGardenClient.ContainersArgsForCall(0)).Should(Equal(garden.Properties{
+					"concourse:type":          "cache",
+					"concourse:pipeline-name": "some-pipeline",
+					"concourse:job-name":      "some-job",
+				}))
+			})
+
+			It("destroys them", func() {
+				Ω(clearErr).ShouldNot(HaveOccurred())
+
+				Ω(fakeGardenClient.DestroyCallCount()).Should(Equal(2))
+				Ω(fakeGardenClient.DestroyArgsForCall(0)).Should(Equal("handle-1"))
+				Ω(fakeGardenClient.DestroyArgsForCall(1)).Should(Equal("handle-2"))
+			})
+		})
+
+		Context("when finding the caches fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(clearErr).Should(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("Satisfies", func() {
 		Context("with a TaskContainerSpec", func() {
 			var (